@@ -0,0 +1,208 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/mattrust/iffmaster/iff"
+)
+
+// catalogCommands are the subcommands of the "catalog" command.
+var catalogCommands = []command{
+	{"export", "[options] catalog", "Convert a catalog to a catalog translation (.ct)", runCatalogExport},
+	{"compile", "[options] description translation", "Compile a .cd and a .ct file into a catalog", runCatalogCompile},
+	{"check", "[options] description catalog", "Compare a catalog with its .cd file", runCatalogCheck},
+}
+
+// runCatalog implements the "catalog" command, which runs one of the
+// catalogCommands.
+func runCatalog(args []string) int {
+	if len(args) > 0 {
+		for _, cmd := range catalogCommands {
+			if cmd.name == args[0] {
+				return cmd.run(args[1:])
+			}
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "Usage: %s catalog <command> [options] arguments\n\nCommands:\n", os.Args[0])
+	for _, cmd := range catalogCommands {
+		fmt.Fprintf(os.Stderr, "  %-14s %s\n", cmd.name, cmd.summary)
+	}
+	return exitUsage
+}
+
+// runCatalogExport implements "catalog export". It writes the strings of
+// a catalog as catalog translation.
+func runCatalogExport(args []string) int {
+	fs, verbose := newFlagSet("catalog export", "[options] catalog")
+	cd := fs.String("cd", "", "Catalog description for the names and the original strings")
+	output := fs.String("o", "", "Output file (default: stdout)")
+	if !parseFlags(fs, verbose, args) {
+		return exitUsage
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	var desc []iff.CatalogDescEntry
+	if *cd != "" {
+		var code int
+		var err error
+		if desc, code, err = readCatalogDescription(*cd); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", *cd, err)
+			return code
+		}
+	}
+
+	ctlg, code, err := readCatalog(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", fs.Arg(0), err)
+		return code
+	}
+
+	err = writeOutput(*output, func(w io.Writer) error {
+		return iff.WriteCatalogTranslation(w, ctlg, desc)
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitIOError
+	}
+
+	return exitOK
+}
+
+// runCatalogCompile implements "catalog compile". It creates a catalog
+// from a catalog description and a catalog translation. Length
+// violations are reported as warnings.
+func runCatalogCompile(args []string) int {
+	fs, verbose := newFlagSet("catalog compile", "[options] description translation")
+	output := fs.String("o", "", "Output file (default: stdout)")
+	if !parseFlags(fs, verbose, args) {
+		return exitUsage
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	desc, code, err := readCatalogDescription(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", fs.Arg(0), err)
+		return code
+	}
+
+	input, err := os.Open(fs.Arg(1))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitIOError
+	}
+	ct, err := iff.ParseCatalogTranslation(input)
+	input.Close()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", fs.Arg(1), err)
+		return exitParseError
+	}
+
+	ctlg, err := iff.CompileCatalog(desc, ct)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", fs.Arg(1), err)
+		return exitParseError
+	}
+	for _, problem := range iff.CheckCatalog(desc, ctlg) {
+		if problem.Kind != iff.CatalogMissing {
+			fmt.Fprintf(os.Stderr, "%s: warning: %s\n", fs.Arg(1), problem)
+		}
+	}
+
+	form, err := iff.EncodeCatalog(ctlg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", fs.Arg(1), err)
+		return exitParseError
+	}
+
+	err = writeOutput(*output, func(w io.Writer) error {
+		return iff.Write(w, form)
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitIOError
+	}
+
+	return exitOK
+}
+
+// runCatalogCheck implements "catalog check". It prints the strings which
+// are missing, extra or violate the length limits of the description.
+func runCatalogCheck(args []string) int {
+	fs, verbose := newFlagSet("catalog check", "[options] description catalog")
+	if !parseFlags(fs, verbose, args) {
+		return exitUsage
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	desc, code, err := readCatalogDescription(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", fs.Arg(0), err)
+		return code
+	}
+	ctlg, code, err := readCatalog(fs.Arg(1))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", fs.Arg(1), err)
+		return code
+	}
+
+	problems := iff.CheckCatalog(desc, ctlg)
+	for _, problem := range problems {
+		fmt.Printf("%s: %s\n", fs.Arg(1), problem)
+	}
+	if len(problems) > 0 {
+		return exitParseError
+	}
+	fmt.Printf("%s: ok\n", fs.Arg(1))
+
+	return exitOK
+}
+
+// readCatalogDescription reads a catalog description (.cd). It returns
+// the exit code to use in case of an error.
+func readCatalogDescription(filename string) ([]iff.CatalogDescEntry, int, error) {
+	input, err := os.Open(filename)
+	if err != nil {
+		return nil, exitIOError, err
+	}
+	defer input.Close()
+
+	desc, err := iff.ParseCatalogDescription(input)
+	if err != nil {
+		return nil, exitParseError, err
+	}
+	return desc, exitOK, nil
+}
+
+// readCatalog reads and decodes a catalog. It returns the exit code to
+// use in case of an error.
+func readCatalog(filename string) (*iff.Catalog, int, error) {
+	root, code, err := readIFF(filename, false)
+	if err != nil {
+		return nil, code, err
+	}
+
+	form := iff.FindForm(root, "CTLG")
+	if form == nil {
+		return nil, exitParseError, fmt.Errorf("file contains no catalog")
+	}
+	ctlg, err := iff.DecodeCatalog(form)
+	if err != nil {
+		return nil, exitParseError, err
+	}
+	return ctlg, exitOK, nil
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/mattrust/iffmaster/iff"
+)
+
+// Exit codes of the command line interface.
+const (
+	exitOK         = 0 // success
+	exitParseError = 1 // the IFF file couldn't be parsed
+	exitUsage      = 2 // invalid command line arguments
+	exitIOError    = 3 // a file couldn't be read or written
+)
+
+// command describes a subcommand of the command line interface.
+type command struct {
+	name     string
+	synopsis string
+	summary  string
+	run      func(args []string) int
+}
+
+// commands contains all available subcommands.
+// It's filled in init to avoid an initialization cycle with printCommands.
+var commands []command
+
+func init() {
+	commands = []command{
+		{"tree", "[options] filename", "Print the chunk tree of an IFF file", runTree},
+		{"export", "[options] filename", "Export the chunk tree as JSON or YAML", runExport},
+		{"validate", "[options] filename...", "Check IFF files for EA IFF 85 conformance", runValidate},
+		{"export-png", "[options] input [output]", "Convert ILBM and ACBM pictures to PNG", runExportPNG},
+		{"export-anim", "[options] input output", "Convert an ANIM to GIF or PNG frames", runExportAnim},
+		{"export-wav", "[options] input [output]", "Convert an 8SVX sound to WAV", runExportWAV},
+		{"export-midi", "[options] input [output]", "Convert an SMUS or CMUS score to a MIDI file", runExportMIDI},
+		{"export-text", "[options] input [output]", "Convert FTXT formatted text to plain text or HTML", runExportText},
+		{"import-image", "[options] input output", "Convert a PNG, GIF or JPEG image to ILBM", runImportImage},
+		{"import-anim", "[options] output frame...", "Convert a sequence of images to ANIM", runImportAnim},
+		{"import-wav", "[options] input output", "Convert a WAV file to 8SVX", runImportWAV},
+		{"catalog", "export|compile|check [options] ...", "Convert and check locale catalogs", runCatalog},
+	}
+}
+
+// findCommand returns the subcommand with the given name or nil
+// if there is no such command.
+func findCommand(name string) *command {
+	for i := range commands {
+		if commands[i].name == name {
+			return &commands[i]
+		}
+	}
+	return nil
+}
+
+// printCommands writes a short overview of all subcommands to w.
+func printCommands(w io.Writer) {
+	fmt.Fprintf(w, "\nCommands:\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-14s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintf(w, "\nUse \"%s <command> -h\" for more information about a command.\n",
+		os.Args[0])
+}
+
+// newFlagSet creates the flag set for a subcommand.
+// Every subcommand gets a -v flag which enables the log output
+// of the chunk handlers.
+func newFlagSet(name string, synopsis string) (*flag.FlagSet, *bool) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	verbose := fs.Bool("v", false, "Enable verbose log output")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s %s %s\n", os.Args[0], name, synopsis)
+		fs.PrintDefaults()
+	}
+	return fs, verbose
+}
+
+// parseFlags parses the arguments of a subcommand and sets up logging.
+// It returns false if the arguments are invalid or help was requested.
+func parseFlags(fs *flag.FlagSet, verbose *bool, args []string) bool {
+	if err := fs.Parse(args); err != nil {
+		return false
+	}
+	if !*verbose {
+		log.SetOutput(io.Discard)
+	}
+	return true
+}
+
+// readIFF reads and parses the IFF file with the given filename.
+// In lenient mode the diagnostics are printed to stderr and the partial
+// chunk tree is returned; the exit code then reports whether the file
+// contains errors.
+// It returns the exit code to use in case of an error.
+func readIFF(filename string, lenient bool) (*iff.Chunk, int, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, exitIOError, err
+	}
+
+	if lenient {
+		root, diags, err := iff.ParseLenient(bytes.NewReader(data), int64(len(data)))
+		printDiagnostics(filename, diags)
+		if err == nil && root == nil {
+			err = fmt.Errorf("file is empty")
+		}
+		if err != nil {
+			return nil, exitParseError, err
+		}
+		if iff.HasErrors(diags) {
+			return root, exitParseError, nil
+		}
+		return root, exitOK, nil
+	}
+
+	root, err := iff.Parse(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, exitParseError, err
+	}
+	if root == nil {
+		return nil, exitParseError, fmt.Errorf("file is empty")
+	}
+
+	return root, exitOK, nil
+}
+
+// printDiagnostics writes the diagnostics to stderr.
+func printDiagnostics(filename string, diags []iff.Diagnostic) {
+	for _, d := range diags {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", filename, d)
+	}
+}
+
+// writeOutput calls write with the file of the given name. If the filename
+// is empty or "-", stdout is used instead.
+func writeOutput(filename string, write func(w io.Writer) error) error {
+	if filename == "" || filename == "-" {
+		return write(os.Stdout)
+	}
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	err = write(file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
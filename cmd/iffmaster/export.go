@@ -0,0 +1,56 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/mattrust/iffmaster/iff"
+)
+
+// runExport implements the "export" command. It writes the parsed chunk
+// tree as JSON or YAML.
+func runExport(args []string) int {
+	fs, verbose := newFlagSet("export", "[options] filename")
+	format := fs.String("format", "json", "Output format: json or yaml")
+	withData := fs.Bool("data", false, "Include the base64 encoded chunk data")
+	output := fs.String("o", "", "Output file (default stdout)")
+	lenient := fs.Bool("lenient", false, "Export the partial tree of broken files")
+	if !parseFlags(fs, verbose, args) {
+		return exitUsage
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	var export func(io.Writer, *iff.Chunk, iff.ExportOptions) error
+	switch *format {
+	case "json":
+		export = iff.ExportJSON
+	case "yaml":
+		export = iff.ExportYAML
+	default:
+		fmt.Fprintf(os.Stderr, "unknown format: %s\n", *format)
+		return exitUsage
+	}
+
+	root, code, err := readIFF(fs.Arg(0), *lenient)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", fs.Arg(0), err)
+		return code
+	}
+
+	err = writeOutput(*output, func(w io.Writer) error {
+		return export(w, root, iff.ExportOptions{IncludeData: *withData})
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitIOError
+	}
+
+	return code
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mattrust/iffmaster/iff"
+)
+
+// runExportAnim implements the "export-anim" command. It converts the
+// first ANIM of an IFF file to an animated GIF or to numbered PNG files.
+func runExportAnim(args []string) int {
+	fs, verbose := newFlagSet("export-anim", "[options] input output")
+	format := fs.String("format", "", "Output format: gif or png (default: gif for *.gif, else png)")
+	lenient := fs.Bool("lenient", false, "Export the frames decoded before an error")
+	if !parseFlags(fs, verbose, args) {
+		return exitUsage
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return exitUsage
+	}
+	input, output := fs.Arg(0), fs.Arg(1)
+
+	if *format == "" {
+		*format = "png"
+		if strings.EqualFold(filepath.Ext(output), ".gif") {
+			*format = "gif"
+		}
+	}
+	if *format != "gif" && *format != "png" {
+		fmt.Fprintf(os.Stderr, "unknown format: %s\n", *format)
+		return exitUsage
+	}
+
+	root, code, err := readIFF(input, *lenient)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", input, err)
+		return code
+	}
+	form := iff.FindForm(root, "ANIM")
+	if form == nil {
+		fmt.Fprintf(os.Stderr, "%s: file contains no ANIM\n", input)
+		return exitParseError
+	}
+	anim, err := iff.DecodeANIM(form)
+	if anim == nil || (err != nil && !*lenient) {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", input, err)
+		return exitParseError
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", input, err)
+		code = exitParseError
+	}
+
+	if *format == "gif" {
+		err = writeOutput(output, func(w io.Writer) error {
+			return iff.EncodeGIF(w, anim)
+		})
+	} else {
+		err = exportFrames(anim, input, output)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitIOError
+	}
+
+	return code
+}
+
+// exportFrames writes each frame of the animation as PNG into the output
+// directory. The files are named after the input file and numbered from 1.
+func exportFrames(anim *iff.Animation, input string, outputDir string) error {
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return err
+	}
+
+	base := strings.TrimSuffix(filepath.Base(input), filepath.Ext(input))
+	for i, frame := range anim.Frames {
+		output := filepath.Join(outputDir, fmt.Sprintf("%s_%04d.png", base, i+1))
+		err := writeOutput(output, func(w io.Writer) error {
+			return iff.EncodePNG(w, frame.Picture)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mattrust/iffmaster/iff"
+)
+
+// runExportMIDI implements the "export-midi" command. It converts the
+// first SMUS or CMUS score of an IFF file to a Standard MIDI File.
+func runExportMIDI(args []string) int {
+	fs, verbose := newFlagSet("export-midi", "[options] input [output]")
+	lenient := fs.Bool("lenient", false, "Export scores of broken files")
+	if !parseFlags(fs, verbose, args) {
+		return exitUsage
+	}
+	if fs.NArg() < 1 || fs.NArg() > 2 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	input := fs.Arg(0)
+	output := fs.Arg(1)
+	if output == "" {
+		output = strings.TrimSuffix(input, filepath.Ext(input)) + ".mid"
+	}
+
+	root, code, err := readIFF(input, *lenient)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", input, err)
+		return code
+	}
+
+	form := iff.FindForm(root, "SMUS", "CMUS")
+	if form == nil {
+		fmt.Fprintf(os.Stderr, "%s: file contains no SMUS or CMUS score\n", input)
+		return exitParseError
+	}
+	score, err := iff.DecodeSMUS(form)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", input, err)
+		return exitParseError
+	}
+
+	err = writeOutput(output, func(w io.Writer) error {
+		return iff.WriteMIDI(w, score)
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitIOError
+	}
+
+	return code
+}
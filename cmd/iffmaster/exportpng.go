@@ -0,0 +1,159 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mattrust/iffmaster/iff"
+)
+
+// errNoPicture is returned by exportPNG for files without a picture.
+var errNoPicture = errors.New("file contains no ILBM or ACBM picture")
+
+// runExportPNG implements the "export-png" command. It converts the first
+// ILBM or ACBM picture of an IFF file to PNG. If the input is a directory,
+// all pictures in it are converted into the output directory.
+func runExportPNG(args []string) int {
+	fs, verbose := newFlagSet("export-png", "[options] input [output]")
+	lenient := fs.Bool("lenient", false, "Export the partial picture of broken files")
+	if !parseFlags(fs, verbose, args) {
+		return exitUsage
+	}
+	if fs.NArg() < 1 || fs.NArg() > 2 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	input := fs.Arg(0)
+	info, err := os.Stat(input)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitIOError
+	}
+
+	if !info.IsDir() {
+		output := fs.Arg(1)
+		if output == "" {
+			output = pngFilename(input)
+		}
+		code, err := exportPNG(input, output, *lenient)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", input, err)
+		}
+		return code
+	}
+
+	if fs.NArg() != 2 {
+		fmt.Fprintln(os.Stderr, "an output directory is needed in batch mode")
+		return exitUsage
+	}
+	return exportPNGDir(input, fs.Arg(1), *lenient)
+}
+
+// exportPNGDir converts the pictures of all files in the input directory.
+// Files which aren't IFF files or don't contain a picture are skipped and
+// don't change the exit code.
+func exportPNGDir(inputDir string, outputDir string, lenient bool) int {
+	entries, err := os.ReadDir(inputDir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitIOError
+	}
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitIOError
+	}
+
+	code := exitOK
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		input := filepath.Join(inputDir, entry.Name())
+		output := filepath.Join(outputDir, pngFilename(entry.Name()))
+		if !hasIFFHeader(input) {
+			fmt.Fprintf(os.Stderr, "%s: skipped, not an IFF file\n", input)
+			continue
+		}
+
+		fileCode, err := exportPNG(input, output, lenient)
+		if errors.Is(err, errNoPicture) {
+			fmt.Fprintf(os.Stderr, "%s: skipped, %s\n", input, err)
+			continue
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", input, err)
+		} else {
+			fmt.Printf("%s -> %s\n", input, output)
+		}
+		if fileCode > code {
+			code = fileCode
+		}
+	}
+
+	return code
+}
+
+// exportPNG converts the first picture of the input file and writes it to
+// the output file. It returns the exit code to use.
+func exportPNG(input string, output string, lenient bool) (int, error) {
+	root, code, err := readIFF(input, lenient)
+	if err != nil {
+		return code, err
+	}
+
+	form := iff.FindPictureForm(root)
+	if form == nil {
+		return exitParseError, errNoPicture
+	}
+	pic, err := iff.DecodeILBM(form)
+	if pic == nil || (err != nil && !lenient) {
+		return exitParseError, err
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", input, err)
+		code = exitParseError
+	}
+
+	err = writeOutput(output, func(w io.Writer) error {
+		return iff.EncodePNG(w, pic)
+	})
+	if err != nil {
+		return exitIOError, err
+	}
+
+	return code, nil
+}
+
+// hasIFFHeader returns true if the file starts with the ID of a FORM,
+// LIST or CAT chunk. Files which can't be read are reported as IFF files,
+// so the error is reported when they are converted.
+func hasIFFHeader(filename string) bool {
+	file, err := os.Open(filename)
+	if err != nil {
+		return true
+	}
+	defer file.Close()
+
+	var id [4]byte
+	if _, err := io.ReadFull(file, id[:]); err != nil {
+		return false
+	}
+	switch string(id[:]) {
+	case "FORM", "LIST", "CAT ":
+		return true
+	}
+	return false
+}
+
+// pngFilename replaces the extension of the filename with ".png".
+func pngFilename(filename string) string {
+	return strings.TrimSuffix(filename, filepath.Ext(filename)) + ".png"
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// testILBM is an ILBM file with a single black pixel.
+var testILBM = []byte{
+	'F', 'O', 'R', 'M', 0, 0, 0, 42, 'I', 'L', 'B', 'M',
+	'B', 'M', 'H', 'D', 0, 0, 0, 20,
+	0, 1, 0, 1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 1, 1, 0, 1, 0, 1,
+	'B', 'O', 'D', 'Y', 0, 0, 0, 2, 0, 0,
+}
+
+func TestExportPNGDir(t *testing.T) {
+	inputDir := t.TempDir()
+	outputDir := t.TempDir()
+	files := map[string][]byte{
+		"picture.iff": testILBM,
+		"README.txt":  []byte("Pictures of the game"),
+		"empty.wav":   nil,
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(inputDir, name), data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if code := exportPNGDir(inputDir, outputDir, false); code != exitOK {
+		t.Errorf("Exit code: got %d, want %d", code, exitOK)
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "picture.png")); err != nil {
+		t.Errorf("picture.png: %v", err)
+	}
+	for _, name := range []string{"README.png", "empty.png"} {
+		if _, err := os.Stat(filepath.Join(outputDir, name)); err == nil {
+			t.Errorf("%s: got file, want none", name)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mattrust/iffmaster/iff"
+)
+
+// runExportText implements the "export-text" command. It converts the
+// first FTXT document of an IFF file to plain text or HTML.
+func runExportText(args []string) int {
+	fs, verbose := newFlagSet("export-text", "[options] input [output]")
+	format := fs.String("format", "", "Output format: text or html (default: html for *.html and *.htm, else text)")
+	lenient := fs.Bool("lenient", false, "Export the text decoded before an error")
+	if !parseFlags(fs, verbose, args) {
+		return exitUsage
+	}
+	if fs.NArg() < 1 || fs.NArg() > 2 {
+		fs.Usage()
+		return exitUsage
+	}
+	input, output := fs.Arg(0), fs.Arg(1)
+
+	if *format == "" {
+		*format = "text"
+		ext := strings.ToLower(filepath.Ext(output))
+		if ext == ".html" || ext == ".htm" {
+			*format = "html"
+		}
+	}
+	if *format != "text" && *format != "html" {
+		fmt.Fprintf(os.Stderr, "unknown format: %s\n", *format)
+		return exitUsage
+	}
+
+	root, code, err := readIFF(input, *lenient)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", input, err)
+		return code
+	}
+	form := iff.FindForm(root, "FTXT")
+	if form == nil {
+		fmt.Fprintf(os.Stderr, "%s: file contains no FTXT\n", input)
+		return exitParseError
+	}
+	ftxt, err := iff.DecodeFTXT(form)
+	if ftxt == nil || (err != nil && !*lenient) {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", input, err)
+		return exitParseError
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", input, err)
+		code = exitParseError
+	}
+
+	err = writeOutput(output, func(w io.Writer) error {
+		if *format == "html" {
+			return ftxt.WriteHTML(w, filepath.Base(input))
+		}
+		_, err := io.WriteString(w, ftxt.PlainText())
+		return err
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitIOError
+	}
+
+	return code
+}
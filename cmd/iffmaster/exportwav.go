@@ -0,0 +1,72 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mattrust/iffmaster/iff"
+)
+
+// runExportWAV implements the "export-wav" command. It converts the first
+// 8SVX sound of an IFF file to WAV.
+func runExportWAV(args []string) int {
+	fs, verbose := newFlagSet("export-wav", "[options] input [output]")
+	octave := fs.Int("octave", 0, "Octave to export, 0 is the highest")
+	lenient := fs.Bool("lenient", false, "Export the partial sound of broken files")
+	if !parseFlags(fs, verbose, args) {
+		return exitUsage
+	}
+	if fs.NArg() < 1 || fs.NArg() > 2 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	input := fs.Arg(0)
+	output := fs.Arg(1)
+	if output == "" {
+		output = strings.TrimSuffix(input, filepath.Ext(input)) + ".wav"
+	}
+
+	root, code, err := readIFF(input, *lenient)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", input, err)
+		return code
+	}
+
+	form := iff.FindForm(root, "8SVX")
+	if form == nil {
+		fmt.Fprintf(os.Stderr, "%s: file contains no 8SVX sound\n", input)
+		return exitParseError
+	}
+	voice, err := iff.Decode8SVX(form)
+	if voice == nil || (err != nil && !*lenient) {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", input, err)
+		return exitParseError
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", input, err)
+		code = exitParseError
+	}
+
+	wav, err := voice.WAV(*octave)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", input, err)
+		return exitUsage
+	}
+
+	err = writeOutput(output, func(w io.Writer) error {
+		return iff.WriteWAV(w, wav)
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitIOError
+	}
+
+	return code
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package main
+
+import (
+	"fmt"
+	"image"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/mattrust/iffmaster/iff"
+)
+
+// runImportAnim implements the "import-anim" command. It converts a
+// sequence of PNG, GIF or JPEG images into an ANIM file.
+func runImportAnim(args []string) int {
+	fs, verbose := newFlagSet("import-anim", "[options] output frame...")
+	planes := fs.Int("planes", 0, "Number of planes from 1 to 8 (default: as needed)")
+	delay := fs.Duration("delay", 100*time.Millisecond, "Time to show each frame")
+	camg := fs.String("camg", "", "Display mode for the CAMG chunk, e.g. 0x8004 (default: no CAMG)")
+	if !parseFlags(fs, verbose, args) {
+		return exitUsage
+	}
+	if fs.NArg() < 2 {
+		fs.Usage()
+		return exitUsage
+	}
+	if *planes < 0 || *planes > 8 {
+		fmt.Fprintf(os.Stderr, "unsupported number of planes: %d\n", *planes)
+		return exitUsage
+	}
+
+	opts := iff.ILBMEncodeOptions{Planes: *planes, Compress: true}
+	if *camg != "" {
+		viewMode, err := strconv.ParseUint(*camg, 0, 32)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid display mode: %s\n", *camg)
+			return exitUsage
+		}
+		opts.ViewMode = uint32(viewMode)
+	}
+
+	maxColors := 256
+	if *planes > 0 {
+		maxColors = 1 << *planes
+	}
+	var frames []*image.Paletted
+	for _, filename := range fs.Args()[1:] {
+		img, code, err := readImage(filename)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", filename, err)
+			return code
+		}
+		paletted, ok := img.(*image.Paletted)
+		if !ok || len(paletted.Palette) > maxColors {
+			paletted = iff.QuantizeImage(img, maxColors)
+		}
+		frames = append(frames, paletted)
+	}
+
+	form, err := iff.EncodeANIM(frames, *delay, opts)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitUsage
+	}
+
+	err = writeOutput(fs.Arg(0), func(w io.Writer) error {
+		return iff.Write(w, form)
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitIOError
+	}
+
+	return exitOK
+}
+
+// readImage decodes a PNG, GIF or JPEG file. It returns the exit code to
+// use in case of an error.
+func readImage(filename string) (image.Image, int, error) {
+	input, err := os.Open(filename)
+	if err != nil {
+		return nil, exitIOError, err
+	}
+	defer input.Close()
+
+	img, _, err := image.Decode(input)
+	if err != nil {
+		return nil, exitParseError, err
+	}
+	return img, exitOK, nil
+}
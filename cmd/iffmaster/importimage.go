@@ -0,0 +1,81 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package main
+
+import (
+	"fmt"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/mattrust/iffmaster/iff"
+)
+
+// runImportImage implements the "import-image" command. It converts a
+// PNG, GIF or JPEG image into an ILBM file.
+func runImportImage(args []string) int {
+	fs, verbose := newFlagSet("import-image", "[options] input output")
+	planes := fs.Int("planes", 0, "Number of planes from 1 to 8 (default: as needed)")
+	compress := fs.Bool("compress", true, "Compress the BODY with ByteRun1")
+	mask := fs.String("mask", "none", "Masking: none, mask (mask plane) or color (transparent color)")
+	aspect := fs.String("aspect", "1:1", "Pixel aspect ratio x:y")
+	camg := fs.String("camg", "", "Display mode for the CAMG chunk, e.g. 0x8004 (default: no CAMG)")
+	if !parseFlags(fs, verbose, args) {
+		return exitUsage
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	opts := iff.ILBMEncodeOptions{Planes: *planes, Compress: *compress}
+	switch *mask {
+	case "none":
+		opts.Masking = iff.MaskNone
+	case "mask":
+		opts.Masking = iff.MaskHasMask
+	case "color":
+		opts.Masking = iff.MaskHasTransparentColor
+	default:
+		fmt.Fprintf(os.Stderr, "unknown masking: %s\n", *mask)
+		return exitUsage
+	}
+	if _, err := fmt.Sscanf(*aspect, "%d:%d", &opts.XAspect, &opts.YAspect); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid aspect ratio: %s\n", *aspect)
+		return exitUsage
+	}
+	if *camg != "" {
+		viewMode, err := strconv.ParseUint(*camg, 0, 32)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid display mode: %s\n", *camg)
+			return exitUsage
+		}
+		opts.ViewMode = uint32(viewMode)
+	}
+
+	img, code, err := readImage(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", fs.Arg(0), err)
+		return code
+	}
+
+	form, err := iff.EncodeILBM(img, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", fs.Arg(0), err)
+		return exitUsage
+	}
+
+	err = writeOutput(fs.Arg(1), func(w io.Writer) error {
+		return iff.Write(w, form)
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitIOError
+	}
+
+	return exitOK
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/mattrust/iffmaster/iff"
+)
+
+// runImportWAV implements the "import-wav" command. It converts a WAV
+// file into an 8SVX file.
+func runImportWAV(args []string) int {
+	fs, verbose := newFlagSet("import-wav", "[options] input output")
+	rate := fs.Uint("rate", 0, "Sample rate of the 8SVX in Hz (default: rate of the WAV)")
+	compress := fs.Bool("compress", false, "Compress the BODY with Fibonacci-delta")
+	if !parseFlags(fs, verbose, args) {
+		return exitUsage
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return exitUsage
+	}
+	if *rate > 0xFFFF {
+		fmt.Fprintf(os.Stderr, "sample rate is too high for 8SVX: %d\n", *rate)
+		return exitUsage
+	}
+
+	input, err := os.Open(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitIOError
+	}
+	wav, err := iff.ReadWAV(input)
+	input.Close()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", fs.Arg(0), err)
+		return exitParseError
+	}
+
+	form, err := iff.ConvertWAVTo8SVX(wav, iff.WAVImportOptions{
+		SampleRate: uint32(*rate),
+		Compress:   *compress,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", fs.Arg(0), err)
+		return exitParseError
+	}
+
+	err = writeOutput(fs.Arg(1), func(w io.Writer) error {
+		return iff.Write(w, form)
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitIOError
+	}
+
+	return exitOK
+}
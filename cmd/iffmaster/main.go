@@ -10,7 +10,84 @@ Usage: [options] filename
 
 		-version: Show the application's version.
 
+		-nogui: Print the chunk tree to stdout instead of opening the GUI.
+
 		filename: The IFF file to inspect (optional).
+
+Usage: command [options] arguments
+
+	iffmaster tree [options] filename
+
+		Print the chunk tree of an IFF file without opening the GUI.
+		-lenient prints the partial tree of broken files and diagnostics.
+
+	iffmaster export [options] filename
+
+		Export the chunk tree as JSON (-format json) or YAML (-format yaml).
+		-data adds the base64 encoded chunk data, -o sets the output file.
+
+	iffmaster validate [options] filename...
+
+		Check IFF files for conformance with EA IFF 85 and print the
+		problems found. -werror treats warnings as errors.
+
+	iffmaster export-png [options] input [output]
+
+		Convert the first ILBM or ACBM picture of a file to PNG. If input
+		is a directory, all pictures in it are converted into the output
+		directory. -lenient exports the partial picture of broken files.
+
+	iffmaster import-image [options] input output
+
+		Convert a PNG, GIF or JPEG image to ILBM. -planes sets the number
+		of planes, -compress=false disables ByteRun1, -mask selects none,
+		mask or color, -aspect the pixel aspect and -camg the display mode.
+
+	iffmaster export-wav [options] input [output]
+
+		Convert an 8SVX sound to WAV. -octave selects the octave, 0 is
+		the highest. -lenient exports the partial sound of broken files.
+
+	iffmaster import-wav [options] input output
+
+		Convert a WAV file to 8SVX. -rate sets the sample rate, -compress
+		compresses the BODY with Fibonacci-delta.
+
+	iffmaster export-anim [options] input output
+
+		Convert an ANIM to an animated GIF (-format gif) or to numbered
+		PNG frames (-format png). -lenient exports the frames decoded
+		before an error.
+
+	iffmaster import-anim [options] output frame...
+
+		Convert a sequence of images to an ANIM with op-5 deltas. -planes
+		sets the number of planes, -delay the time of each frame and -camg
+		the display mode.
+
+	iffmaster catalog export|compile|check [options] ...
+
+		Convert a catalog to a catalog translation (.ct), compile a
+		catalog from a description (.cd) and a translation, or check
+		a catalog against its description.
+
+	iffmaster export-text [options] input [output]
+
+		Convert FTXT formatted text to plain text (-format text) or HTML
+		(-format html). -lenient exports the text decoded before an error.
+
+	iffmaster export-midi [options] input [output]
+
+		Convert the first SMUS or CMUS score of a file to a Standard MIDI
+		File. -lenient exports scores of broken files.
+
+Chunk types can be described by YAML schemas without changing the
+program, see internal/chunks/schema.go. The schemas are loaded from
+iffmaster/schemas in the user's configuration directory and from the
+directories listed in the environment variable IFFMASTER_SCHEMAS.
+
+The exit code is 0 on success, 1 if the file couldn't be parsed,
+2 for invalid command line arguments and 3 for I/O errors.
 */
 package main
 
@@ -25,13 +102,23 @@ import (
 func main() {
 	var filename string
 
+	loadUserSchemas()
+
+	if len(os.Args) > 1 {
+		if cmd := findCommand(os.Args[1]); cmd != nil {
+			os.Exit(cmd.run(os.Args[2:]))
+		}
+	}
+
 	showVersion := flag.Bool("version", false, "Display the version of iffmaster")
+	noGui := flag.Bool("nogui", false, "Print the chunk tree instead of opening the GUI")
 	flag.Usage = func() {
 		fmt.Fprintf(flag.CommandLine.Output(),
 			"Usage: %s [options] filename\n", os.Args[0])
 		flag.PrintDefaults()
 		fmt.Fprintf(flag.CommandLine.Output(),
 			"\n  filename: The IFF file to inspect (optional).\n")
+		printCommands(flag.CommandLine.Output())
 	}
 	flag.Parse()
 
@@ -44,5 +131,13 @@ func main() {
 		filename = flag.Arg(0)
 	}
 
+	if *noGui {
+		if filename == "" {
+			flag.Usage()
+			os.Exit(exitUsage)
+		}
+		os.Exit(runTree([]string{filename}))
+	}
+
 	gui.OpenGUI(filename, Version)
 }
@@ -0,0 +1,44 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/mattrust/iffmaster/iff"
+)
+
+// schemaPathEnv is the environment variable with additional directories
+// of chunk schemas, separated like PATH.
+const schemaPathEnv = "IFFMASTER_SCHEMAS"
+
+// schemaDirs returns the directories with user-supplied chunk schemas:
+// iffmaster/schemas in the user's configuration directory, e.g.
+// ~/.config/iffmaster/schemas, and the directories of IFFMASTER_SCHEMAS.
+func schemaDirs() []string {
+	var dirs []string
+	if config, err := os.UserConfigDir(); err == nil {
+		dirs = append(dirs, filepath.Join(config, "iffmaster", "schemas"))
+	}
+	return append(dirs, filepath.SplitList(os.Getenv(schemaPathEnv))...)
+}
+
+// loadUserSchemas loads the user-supplied chunk schemas. Errors are
+// printed to stderr, the valid schemas are used anyway. Missing
+// directories are ignored.
+func loadUserSchemas() {
+	for _, dir := range schemaDirs() {
+		if dir == "" {
+			continue
+		}
+		err := iff.LoadSchemaDir(dir)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", dir, err)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/mattrust/iffmaster/iff"
+)
+
+// runTree implements the "tree" command. It prints an indented tree
+// of all chunks of an IFF file.
+func runTree(args []string) int {
+	fs, verbose := newFlagSet("tree", "[options] filename")
+	lenient := fs.Bool("lenient", false, "Print the partial tree of broken files and diagnostics")
+	if !parseFlags(fs, verbose, args) {
+		return exitUsage
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	root, code, err := readIFF(fs.Arg(0), *lenient)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", fs.Arg(0), err)
+		return code
+	}
+
+	printTree(os.Stdout, root)
+
+	return code
+}
+
+// printTree writes the chunk and its children as an indented table to w.
+func printTree(w io.Writer, root *iff.Chunk) {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "CHUNK\tTYPE\tSIZE\tOFFSET\tDESCRIPTION")
+
+	var traverse func(chunk *iff.Chunk, level int)
+	traverse = func(chunk *iff.Chunk, level int) {
+		description, _, _ := iff.Decode(chunk.Type(), chunk.Data)
+
+		name := chunk.ID
+		if chunk.SubID != "" {
+			name += " " + chunk.SubID
+		}
+		if chunk.Truncated {
+			name += " (truncated)"
+		}
+		fmt.Fprintf(tw, "%s%s\t%s\t%d\t0x%08X\t%s\n",
+			strings.Repeat("  ", level), name, chunk.Type(),
+			chunk.Size, chunk.Offset, description)
+
+		for _, child := range chunk.Children {
+			traverse(child, level+1)
+		}
+	}
+
+	traverse(root, 0)
+	tw.Flush()
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/mattrust/iffmaster/iff"
+)
+
+// runValidate implements the "validate" command. It checks IFF files for
+// conformance with EA IFF 85 and prints the problems found.
+func runValidate(args []string) int {
+	fs, verbose := newFlagSet("validate", "[options] filename...")
+	werror := fs.Bool("werror", false, "Treat warnings as errors")
+	if !parseFlags(fs, verbose, args) {
+		return exitUsage
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	code := exitOK
+	for _, filename := range fs.Args() {
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			code = exitIOError
+			continue
+		}
+
+		root, diags, err := iff.ParseLenient(bytes.NewReader(data), int64(len(data)))
+		diags = append(diags, iff.Validate(root)...)
+		for _, d := range diags {
+			fmt.Printf("%s: %s\n", filename, d)
+		}
+		if err != nil {
+			fmt.Printf("%s: %s\n", filename, err)
+		} else if len(diags) == 0 {
+			fmt.Printf("%s: ok\n", filename)
+		}
+
+		failed := err != nil || iff.HasErrors(diags) || (*werror && len(diags) > 0)
+		if failed && code == exitOK {
+			code = exitParseError
+		}
+	}
+
+	return code
+}
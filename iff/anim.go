@@ -0,0 +1,76 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package iff
+
+import (
+	"image"
+	"io"
+	"time"
+
+	"github.com/mattrust/iffmaster/internal/chunks"
+)
+
+// AnimFrame is a reconstructed frame of an animation.
+type AnimFrame struct {
+	Picture *Picture
+	Delay   time.Duration // time to show the frame
+}
+
+// Animation contains all frames of an ANIM FORM.
+type Animation struct {
+	Frames []AnimFrame
+}
+
+// IsAnimForm returns true for FORMs which can be decoded by DecodeANIM.
+func IsAnimForm(form *Chunk) bool {
+	return form != nil && form.ID == "FORM" && form.SubID == "ANIM"
+}
+
+// DecodeANIM reconstructs all frames of an ANIM FORM. The first ILBM
+// FORM contains the full picture, each following FORM a DLTA chunk
+// which is applied to the frame shown Interleave frames before.
+// In case of an error, the function returns nil and the error. If a
+// later frame is broken, the frames decoded so far are returned together
+// with the error.
+func DecodeANIM(form *Chunk) (*Animation, error) {
+	anim, err := chunks.DecodeANIM(form.internal())
+	return newAnimation(anim), err
+}
+
+// EncodeANIM converts a sequence of equally sized paletted images into
+// an ANIM FORM, which can be saved with Write. The first image becomes
+// a full ILBM, all following images byte vertical deltas (operation 5)
+// for double buffering. Every frame is shown for the given delay. The
+// options are used for the first ILBM, a mask plane isn't supported.
+func EncodeANIM(images []*image.Paletted, delay time.Duration, opts ILBMEncodeOptions) (*Chunk, error) {
+	form, err := chunks.EncodeANIM(images, delay, chunks.ILBMEncodeOptions(opts))
+	return newChunk(form), err
+}
+
+// EncodeGIF writes the frames of the animation as animated GIF which
+// loops forever. Frames with more than 256 colors, e.g. HAM, are reduced
+// with QuantizeImage.
+func EncodeGIF(w io.Writer, anim *Animation) error {
+	return chunks.EncodeGIF(w, anim.internal())
+}
+
+// newAnimation converts an internal animation to a public one.
+func newAnimation(anim *chunks.Animation) *Animation {
+	if anim == nil {
+		return nil
+	}
+	return &Animation{Frames: convertSlice(anim.Frames, func(frame chunks.AnimFrame) AnimFrame {
+		return AnimFrame{newPicture(frame.Picture), frame.Delay}
+	})}
+}
+
+// internal converts a public animation to an internal one.
+func (anim *Animation) internal() *chunks.Animation {
+	if anim == nil {
+		return nil
+	}
+	return &chunks.Animation{Frames: convertSlice(anim.Frames, func(frame AnimFrame) chunks.AnimFrame {
+		return chunks.AnimFrame{Picture: frame.Picture.internal(), Delay: frame.Delay}
+	})}
+}
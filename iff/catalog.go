@@ -0,0 +1,195 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package iff
+
+import (
+	"io"
+
+	"github.com/mattrust/iffmaster/internal/chunks"
+)
+
+// CatalogString is an entry of the STRS chunk of a catalog.
+type CatalogString struct {
+	ID     uint32
+	Length uint32 // number of bytes in the chunk without padding
+	Text   string // decoded text without trailing zero bytes
+}
+
+// Catalog contains the decoded data of a CTLG FORM.
+type Catalog struct {
+	Version  string // text of the FVER chunk
+	Language string // name of the language of the LANG chunk
+	CodeSet  uint32 // IANA MIBenum of the character set of the CSET chunk
+	Strings  []CatalogString
+	Chunks   []*Chunk // other chunks, e.g. AUTH
+}
+
+// CatalogDescEntry is a string of a catalog description (.cd).
+type CatalogDescEntry struct {
+	Name   string
+	ID     uint32
+	MinLen int // minimal length in bytes
+	MaxLen int // maximal length in bytes, 0 for no limit
+	Text   string
+}
+
+// CatalogTranslation contains the data of a catalog translation (.ct).
+type CatalogTranslation struct {
+	Version  string // "## version", e.g. "$VER: app.catalog 1.0 (01.01.2025)"
+	Language string // "## language"
+	CodeSet  uint32 // "## codeset"
+	Chunks   []*Chunk
+	Strings  []CatalogTranslationEntry
+}
+
+// CatalogTranslationEntry is a translated string of a catalog
+// translation.
+type CatalogTranslationEntry struct {
+	Name string
+	Text string
+}
+
+// Kinds of problems found by CheckCatalog.
+const (
+	CatalogMissing  = "missing"
+	CatalogExtra    = "extra"
+	CatalogTooShort = "too short"
+	CatalogTooLong  = "too long"
+)
+
+// CatalogProblem is a difference between a catalog and its catalog
+// description.
+type CatalogProblem struct {
+	Kind    string // CatalogMissing, CatalogExtra, CatalogTooShort or CatalogTooLong
+	ID      uint32
+	Name    string // empty for extra strings
+	Message string
+}
+
+// String formats the problem as a single line.
+func (p CatalogProblem) String() string {
+	return chunks.CatalogProblem(p).String()
+}
+
+// IsCatalogForm returns true for FORMs which can be decoded by
+// DecodeCatalog.
+func IsCatalogForm(form *Chunk) bool {
+	return form != nil && form.ID == "FORM" && form.SubID == "CTLG"
+}
+
+// DecodeCatalog decodes FVER, LANG, CSET and STRS of a CTLG FORM. All
+// other chunks are kept in Chunks.
+// In case of an error, the function returns nil and the error. If only
+// the STRS chunk is broken, the catalog with the strings decoded so far
+// is returned together with the error.
+func DecodeCatalog(form *Chunk) (*Catalog, error) {
+	ctlg, err := chunks.DecodeCatalog(form.internal())
+	return newCatalog(ctlg), err
+}
+
+// ParseCatalogDescription reads a catalog description (.cd). Strings
+// without ID get the ID of the string before plus 1. The texts are
+// decoded as ISO-8859-1.
+func ParseCatalogDescription(r io.Reader) ([]CatalogDescEntry, error) {
+	desc, err := chunks.ParseCatalogDescription(r)
+	return convertSlice(desc, func(entry chunks.CatalogDescEntry) CatalogDescEntry {
+		return CatalogDescEntry(entry)
+	}), err
+}
+
+// ParseCatalogTranslation reads a catalog translation (.ct). The texts
+// are decoded with the character set of "## codeset".
+func ParseCatalogTranslation(r io.Reader) (*CatalogTranslation, error) {
+	ct, err := chunks.ParseCatalogTranslation(r)
+	if ct == nil {
+		return nil, err
+	}
+	return &CatalogTranslation{
+		Version:  ct.Version,
+		Language: ct.Language,
+		CodeSet:  ct.CodeSet,
+		Chunks:   newChunks(ct.Chunks),
+		Strings: convertSlice(ct.Strings, func(entry chunks.CatalogTranslationEntry) CatalogTranslationEntry {
+			return CatalogTranslationEntry(entry)
+		}),
+	}, err
+}
+
+// WriteCatalogTranslation writes the catalog as catalog translation
+// (.ct) encoded with the character set of the catalog. With a catalog
+// description, the strings get its names and order and the original
+// texts are added as comments; strings which aren't translated yet are
+// left empty. Without it, the strings are named after their IDs.
+func WriteCatalogTranslation(w io.Writer, ctlg *Catalog, desc []CatalogDescEntry) error {
+	return chunks.WriteCatalogTranslation(w, ctlg.internal(), internalCatalogDesc(desc))
+}
+
+// CompileCatalog combines a catalog description and a catalog
+// translation into a catalog, which can be saved with EncodeCatalog.
+// Strings with an empty translation are left out like CatComp does.
+func CompileCatalog(desc []CatalogDescEntry, ct *CatalogTranslation) (*Catalog, error) {
+	ctlg, err := chunks.CompileCatalog(internalCatalogDesc(desc), &chunks.CatalogTranslation{
+		Version:  ct.Version,
+		Language: ct.Language,
+		CodeSet:  ct.CodeSet,
+		Chunks:   internalChunks(ct.Chunks),
+		Strings: convertSlice(ct.Strings, func(entry CatalogTranslationEntry) chunks.CatalogTranslationEntry {
+			return chunks.CatalogTranslationEntry(entry)
+		}),
+	})
+	return newCatalog(ctlg), err
+}
+
+// EncodeCatalog converts a catalog into a CTLG FORM, which can be saved
+// with Write. The strings are encoded with the character set of the
+// catalog. The length of a string is increased by 1 if it's a multiple
+// of 4, so that every string is terminated by a zero byte.
+func EncodeCatalog(ctlg *Catalog) (*Chunk, error) {
+	form, err := chunks.EncodeCatalog(ctlg.internal())
+	return newChunk(form), err
+}
+
+// CheckCatalog compares a catalog with its catalog description. It
+// reports strings which are missing in the catalog, strings which aren't
+// in the description and strings which violate the length limits.
+func CheckCatalog(desc []CatalogDescEntry, ctlg *Catalog) []CatalogProblem {
+	problems := chunks.CheckCatalog(internalCatalogDesc(desc), ctlg.internal())
+	return convertSlice(problems, func(p chunks.CatalogProblem) CatalogProblem { return CatalogProblem(p) })
+}
+
+// newCatalog converts an internal catalog to a public one.
+func newCatalog(ctlg *chunks.Catalog) *Catalog {
+	if ctlg == nil {
+		return nil
+	}
+	return &Catalog{
+		Version:  ctlg.Version,
+		Language: ctlg.Language,
+		CodeSet:  ctlg.CodeSet,
+		Strings:  convertSlice(ctlg.Strings, func(s chunks.CatalogString) CatalogString { return CatalogString(s) }),
+		Chunks:   newChunks(ctlg.Chunks),
+	}
+}
+
+// internal converts a public catalog to an internal one.
+func (ctlg *Catalog) internal() *chunks.Catalog {
+	if ctlg == nil {
+		return nil
+	}
+	return &chunks.Catalog{
+		Version:  ctlg.Version,
+		Language: ctlg.Language,
+		CodeSet:  ctlg.CodeSet,
+		Strings:  convertSlice(ctlg.Strings, func(s CatalogString) chunks.CatalogString { return chunks.CatalogString(s) }),
+		Chunks:   internalChunks(ctlg.Chunks),
+	}
+}
+
+// internalCatalogDesc converts a public catalog description to an
+// internal one.
+func internalCatalogDesc(desc []CatalogDescEntry) []chunks.CatalogDescEntry {
+	return convertSlice(desc, func(entry CatalogDescEntry) chunks.CatalogDescEntry {
+		return chunks.CatalogDescEntry(entry)
+	})
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package iff
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCatalog(t *testing.T) {
+	desc, err := ParseCatalogDescription(strings.NewReader(
+		"MSG_HELLO (1//)\nHello\nMSG_BYE (//)\nBye\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ct, err := ParseCatalogTranslation(strings.NewReader(
+		"## version $VER: test.catalog 1.0 (01.01.2025)\n## language deutsch\n## codeset 0\n" +
+			"MSG_HELLO\nHallo\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctlg, err := CompileCatalog(desc, ct)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []CatalogString{{1, 0, "Hallo"}}
+	if ctlg.Language != "deutsch" || !slices.Equal(ctlg.Strings, want) {
+		t.Errorf("CompileCatalog: got %+v, want %v", ctlg, want)
+	}
+
+	problems := CheckCatalog(desc, ctlg)
+	if len(problems) != 1 || problems[0].Kind != CatalogMissing || problems[0].Name != "MSG_BYE" {
+		t.Errorf("CheckCatalog: got %v", problems)
+	}
+
+	form, err := EncodeCatalog(ctlg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsCatalogForm(form) {
+		t.Fatalf("EncodeCatalog: got %v", form)
+	}
+	want[0].Length = 5
+	decoded, err := DecodeCatalog(form)
+	if err != nil || decoded.Version != ctlg.Version || !slices.Equal(decoded.Strings, want) {
+		t.Errorf("DecodeCatalog: got %+v, %v", decoded, err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCatalogTranslation(&buf, decoded, desc); err != nil {
+		t.Fatal(err)
+	}
+	if text := buf.String(); !strings.Contains(text, "MSG_HELLO\nHallo\n") {
+		t.Errorf("WriteCatalogTranslation: got %q", text)
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package iff
+
+import "github.com/mattrust/iffmaster/internal/chunks"
+
+// Chunk is a chunk of an IFF file. Group chunks (FORM, LIST, CAT and
+// PROP) contain their children, data chunks their payload.
+type Chunk struct {
+	// the chunk data from the file
+	ID    string
+	Size  uint32
+	SubID string // type of group chunks, e.g. ILBM
+	Data  []byte
+
+	// the children of group chunks
+	Children []*Chunk
+
+	// the sum of the size of the chunk and all its children
+	SumSize int64
+
+	// the SubID of the parent group chunk for data chunks, e.g. ILBM,
+	// see Type
+	ParentType string
+
+	// absolute file offsets of the chunk header, the payload (after
+	// the SubID for group chunks) and the end of the chunk including
+	// padding and children
+	Offset     int64
+	DataOffset int64
+	EndOffset  int64
+
+	// set by ParseLenient if the file ends before the chunk or its
+	// parent; Data then only contains the available bytes
+	Truncated bool
+
+	// number of bytes in the file after the end of the chunk,
+	// only set for the root chunk
+	TrailingSize int64
+}
+
+// Type returns the chunk type as used by Decode and RegisterHandler,
+// i.e. the SubID of group chunks, e.g. "ILBM", and the parent's SubID
+// and the ID of data chunks, e.g. "ILBM.BMHD". Data chunks which can
+// appear in any FORM, e.g. NAME, get the type "(any).NAME" unless a
+// handler is registered for the FORM type. The type is resolved with
+// the handlers registered at the time of the call.
+func (chunk *Chunk) Type() string {
+	return (&chunks.IFFChunk{ID: chunk.ID, SubID: chunk.SubID, ParentType: chunk.ParentType}).ChType()
+}
+
+// newChunk converts an internal chunk tree to a public one.
+func newChunk(chunk *chunks.IFFChunk) *Chunk {
+	if chunk == nil {
+		return nil
+	}
+	return &Chunk{
+		ID:           chunk.ID,
+		Size:         chunk.Size,
+		SubID:        chunk.SubID,
+		Data:         chunk.Data,
+		Children:     newChunks(chunk.Childs),
+		SumSize:      chunk.SumSize,
+		ParentType:   chunk.ParentType,
+		Offset:       chunk.Offset,
+		DataOffset:   chunk.DataOffset,
+		EndOffset:    chunk.EndOffset,
+		Truncated:    chunk.Truncated,
+		TrailingSize: chunk.TrailingSize,
+	}
+}
+
+// newChunks converts a list of internal chunks to public ones.
+func newChunks(list []*chunks.IFFChunk) []*Chunk {
+	return convertSlice(list, newChunk)
+}
+
+// internal converts a public chunk tree to an internal one.
+func (chunk *Chunk) internal() *chunks.IFFChunk {
+	if chunk == nil {
+		return nil
+	}
+	return &chunks.IFFChunk{
+		ID:           chunk.ID,
+		Size:         chunk.Size,
+		SubID:        chunk.SubID,
+		Data:         chunk.Data,
+		Childs:       internalChunks(chunk.Children),
+		SumSize:      chunk.SumSize,
+		ParentType:   chunk.ParentType,
+		Offset:       chunk.Offset,
+		DataOffset:   chunk.DataOffset,
+		EndOffset:    chunk.EndOffset,
+		Truncated:    chunk.Truncated,
+		TrailingSize: chunk.TrailingSize,
+	}
+}
+
+// internalChunks converts a list of public chunks to internal ones.
+func internalChunks(list []*Chunk) []*chunks.IFFChunk {
+	return convertSlice(list, (*Chunk).internal)
+}
+
+// convertSlice converts the elements of a slice between the public and
+// the internal types. A nil slice stays nil.
+func convertSlice[T, U any](list []T, convert func(T) U) []U {
+	if list == nil {
+		return nil
+	}
+	result := make([]U, len(list))
+	for i, item := range list {
+		result[i] = convert(item)
+	}
+	return result
+}
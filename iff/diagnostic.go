@@ -0,0 +1,52 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package iff
+
+import "github.com/mattrust/iffmaster/internal/chunks"
+
+// Severity is the severity of a diagnostic.
+type Severity int
+
+// Severities of diagnostics.
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityError
+)
+
+// String returns the name of the severity.
+func (s Severity) String() string {
+	return chunks.Severity(s).String()
+}
+
+// Diagnostic describes a problem found in an IFF file.
+type Diagnostic struct {
+	Severity Severity
+	Offset   int64  // absolute file offset of the problem
+	Path     string // path of the affected chunk, see Path
+	Message  string
+}
+
+// String formats the diagnostic as a single line.
+func (d Diagnostic) String() string {
+	return chunks.Diagnostic{Severity: chunks.Severity(d.Severity), Offset: d.Offset,
+		Path: d.Path, Message: d.Message}.String()
+}
+
+// HasErrors returns true if one of the diagnostics has SeverityError.
+func HasErrors(diags []Diagnostic) bool {
+	for _, d := range diags {
+		if d.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// newDiagnostics converts internal diagnostics to public ones.
+func newDiagnostics(diags []chunks.Diagnostic) []Diagnostic {
+	return convertSlice(diags, func(d chunks.Diagnostic) Diagnostic {
+		return Diagnostic{Severity(d.Severity), d.Offset, d.Path, d.Message}
+	})
+}
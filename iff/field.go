@@ -0,0 +1,86 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package iff
+
+import (
+	"fmt"
+
+	"github.com/mattrust/iffmaster/internal/chunks"
+)
+
+// Field is a decoded field of a data chunk. Raw is the decoded value,
+// e.g. an uint16, a string or a color, and Value its formatted text.
+// Unit and Enum are set for values with a unit like "Hz" or with a name
+// like "Byte Run 1". Offset and Length give the bytes of the payload the
+// value was decoded from. Length is 0 for fields which don't belong to
+// any bytes, e.g. error messages. Children contains nested fields, e.g.
+// the colors of a color map.
+type Field struct {
+	Name     string
+	Value    string
+	Offset   uint32
+	Length   uint32
+	Raw      any
+	Unit     string
+	Enum     string
+	Children Fields
+}
+
+// Fields is a list of decoded fields.
+type Fields []Field
+
+// Type returns the Go type of the raw value, e.g. "uint16", or "" if the
+// field has no value.
+func (field Field) Type() string {
+	if field.Raw == nil {
+		return ""
+	}
+	return fmt.Sprintf("%T", field.Raw)
+}
+
+// Rows returns the fields and their children depth-first as a flat list
+// without children. The names of nested fields are indented by two
+// spaces per level.
+func (fields Fields) Rows() Fields {
+	return newFields(fields.internal().Rows())
+}
+
+// FieldAt returns the index of the smallest field which contains the
+// byte at the given offset or -1 if no field contains it. It's used with
+// the flat list returned by Rows, where groups contain their children.
+func (fields Fields) FieldAt(offset uint32) int {
+	return fields.internal().FieldAt(offset)
+}
+
+// newFields converts internal fields to public ones.
+func newFields(result chunks.StructResult) Fields {
+	return convertSlice(result, func(field chunks.StructField) Field {
+		return Field{
+			Name:     field.Name,
+			Value:    field.Value,
+			Offset:   field.Offset,
+			Length:   field.Length,
+			Raw:      field.Raw,
+			Unit:     field.Unit,
+			Enum:     field.Enum,
+			Children: newFields(field.Children),
+		}
+	})
+}
+
+// internal converts public fields to internal ones.
+func (fields Fields) internal() chunks.StructResult {
+	return convertSlice(fields, func(field Field) chunks.StructField {
+		return chunks.StructField{
+			Name:     field.Name,
+			Value:    field.Value,
+			Offset:   field.Offset,
+			Length:   field.Length,
+			Raw:      field.Raw,
+			Unit:     field.Unit,
+			Enum:     field.Enum,
+			Children: field.Children.internal(),
+		}
+	})
+}
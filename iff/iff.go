@@ -0,0 +1,221 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+// Package iff reads, writes and decodes IFF files as defined by EA IFF 85.
+// It's the public API of iffmaster, the GUI and the command line tool are
+// clients of it.
+//
+// A file is parsed into a tree of chunks with Parse or ParseLenient,
+// traversed with Walk and Find, and written back with Write. Decode
+// returns the decoded fields of a data chunk. The decoders of the
+// built-in chunk types can be replaced and new chunk types can be added
+// with RegisterHandler or with YAML schemas, see LoadSchemaFile.
+//
+// The content of the supported FORM types is decoded and encoded by
+// format specific functions: pictures (ILBM, ACBM) by DecodeILBM,
+// EncodeILBM and EncodePNG, sounds (8SVX, 16SV, AIFF, AIFC) by
+// DecodeSound, Decode8SVX, ReadWAV, WriteWAV and ConvertWAVTo8SVX,
+// animations (ANIM) by DecodeANIM, EncodeANIM and EncodeGIF, scores
+// (SMUS, CMUS) by DecodeSMUS and WriteMIDI, catalogs (CTLG) by
+// DecodeCatalog, CompileCatalog and EncodeCatalog and formatted text
+// (FTXT) by DecodeFTXT.
+//
+// # Compatibility
+//
+// The package follows the semantic versioning of the iffmaster module.
+// Within a major version exported identifiers are neither removed nor
+// changed incompatibly, new functions, types and struct fields may be
+// added. APIVersion is increased with every incompatible change together
+// with the major version of the module. The types of this package are
+// independent of the internal implementation of iffmaster. The names and
+// the formatted values of decoded fields may be improved in any release;
+// programs should use the raw values.
+package iff
+
+import (
+	"errors"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/mattrust/iffmaster/internal/chunks"
+)
+
+// APIVersion is the version of the API of this package.
+const APIVersion = 1
+
+// ExportOptions controls which data is exported by ExportJSON and
+// ExportYAML.
+type ExportOptions struct {
+	// IncludeData adds the base64 encoded payload of every data chunk.
+	IncludeData bool
+}
+
+// Parse reads an IFF file of the given size and returns the root chunk.
+func Parse(r io.Reader, size int64) (*Chunk, error) {
+	root, err := chunks.ReadIFFFile(r, size)
+	if err != nil {
+		return nil, err
+	}
+	return newChunk(root), nil
+}
+
+// ParseLenient reads an IFF file like Parse, but truncated or corrupt
+// files are parsed as far as possible. It returns the partial chunk tree
+// and the problems found. The error is only set if nothing could be
+// parsed.
+func ParseLenient(r io.Reader, size int64) (*Chunk, []Diagnostic, error) {
+	root, diags, err := chunks.ReadIFFFileLenient(r, size)
+	return newChunk(root), newDiagnostics(diags), err
+}
+
+// ParseFile reads the IFF file with the given name.
+func ParseFile(filename string) (*Chunk, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return Parse(file, info.Size())
+}
+
+// Write writes the chunk tree as IFF file. The sizes of the chunks are
+// calculated from their data and children.
+func Write(w io.Writer, root *Chunk) error {
+	return chunks.WriteIFFFile(w, root.internal())
+}
+
+// WalkFunc is called by Walk for every chunk. path is the chunk path
+// (see Path) and level the nesting depth, 0 for the root chunk. If the
+// function returns an error, the walk is stopped.
+type WalkFunc func(chunk *Chunk, path string, level int) error
+
+// Walk calls fn for the root chunk and all its children in file order
+// with the path of the chunk, e.g. "FORM:ILBM/BMHD[0]", and its nesting
+// depth. It stops at the first error returned by fn and returns it.
+func Walk(root *Chunk, fn WalkFunc) error {
+	if root == nil {
+		return nil
+	}
+	return walkChunk(root, Path("", root, -1), 0, fn)
+}
+
+// walkChunk recursively calls fn for the chunk and its children.
+func walkChunk(chunk *Chunk, path string, level int, fn WalkFunc) error {
+	if err := fn(chunk, path, level); err != nil {
+		return err
+	}
+	for i, child := range chunk.Children {
+		if err := walkChunk(child, Path(path, child, i), level+1, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Path returns the path of a chunk from the path of its parent and its
+// index within the parent. The root chunk has the index -1.
+func Path(parentPath string, chunk *Chunk, index int) string {
+	return chunks.ChunkPath(parentPath, &chunks.IFFChunk{ID: chunk.ID, SubID: chunk.SubID}, index)
+}
+
+// errFound stops Walk in Find and FindForm.
+var errFound = errors.New("found")
+
+// Find returns the chunk with the given path, as passed to WalkFunc, or
+// nil if there is none.
+func Find(root *Chunk, path string) *Chunk {
+	var found *Chunk
+	_ = Walk(root, func(chunk *Chunk, chunkPath string, level int) error {
+		if chunkPath == path {
+			found = chunk
+			return errFound
+		}
+		return nil
+	})
+	return found
+}
+
+// FindForm returns the first FORM in file order whose type is one of the
+// given types, e.g. "ILBM", or nil if there is none.
+func FindForm(root *Chunk, types ...string) *Chunk {
+	var found *Chunk
+	_ = Walk(root, func(chunk *Chunk, path string, level int) error {
+		if chunk.ID == "FORM" && slices.Contains(types, chunk.SubID) {
+			found = chunk
+			return errFound
+		}
+		return nil
+	})
+	return found
+}
+
+// Decode decodes the payload of a data chunk with the handler of its
+// type, e.g. "ILBM.BMHD" (see Chunk.Type). It returns the description
+// of the chunk type and the decoded fields. In case of an error the
+// fields decoded so far are returned.
+func Decode(chType string, data []byte) (string, Fields, error) {
+	description, result, err := chunks.GetStructData(chType, data)
+	return description, newFields(result), err
+}
+
+// ChunkHandler decodes the payload of a data chunk. In case of an error
+// it returns the fields decoded so far and the error.
+type ChunkHandler func(data []byte) (Fields, error)
+
+// RegisterHandler registers the handler and the description of a chunk
+// type, e.g. "ILBM.BMHD" or "(any).NAME" for a chunk in any FORM. It
+// replaces the handler of a built-in chunk type. An empty description
+// keeps the description of a built-in chunk type. The type of a chunk is
+// resolved when it's used, so the handler also applies to chunks parsed
+// before.
+func RegisterHandler(chType string, handler ChunkHandler, description string) error {
+	var internal chunks.ChunkHandler
+	if handler != nil {
+		internal = func(data []byte) (chunks.StructResult, error) {
+			fields, err := handler(data)
+			return fields.internal(), err
+		}
+	}
+	return chunks.RegisterHandler(chType, internal, description)
+}
+
+// UnregisterHandler removes the handler and the description of a chunk
+// type registered with RegisterHandler. A built-in handler which was
+// replaced is restored.
+func UnregisterHandler(chType string) {
+	chunks.UnregisterHandler(chType)
+}
+
+// LoadSchemaFile registers the chunk types described by a YAML schema
+// file. The syntax is described in the README of the iffmaster
+// repository.
+func LoadSchemaFile(filename string) error {
+	return chunks.LoadSchemaFile(filename)
+}
+
+// LoadSchemaDir registers the chunk types of all .yaml and .yml files of
+// a directory.
+func LoadSchemaDir(dir string) error {
+	return chunks.LoadSchemaDir(dir)
+}
+
+// Validate checks the chunk tree for conformance with EA IFF 85.
+func Validate(root *Chunk) []Diagnostic {
+	return newDiagnostics(chunks.Validate(root.internal()))
+}
+
+// ExportJSON writes the chunk tree with the decoded fields as JSON.
+func ExportJSON(w io.Writer, root *Chunk, opts ExportOptions) error {
+	return chunks.ExportJSON(w, root.internal(), chunks.ExportOptions(opts))
+}
+
+// ExportYAML writes the chunk tree with the decoded fields as YAML.
+func ExportYAML(w io.Writer, root *Chunk, opts ExportOptions) error {
+	return chunks.ExportYAML(w, root.internal(), chunks.ExportOptions(opts))
+}
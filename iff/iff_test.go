@@ -0,0 +1,202 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package iff
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// makeTestFile returns an ILBM FORM with a BMHD and a custom chunk.
+func makeTestFile(t *testing.T) []byte {
+	t.Helper()
+	root := &Chunk{ID: "FORM", SubID: "ILBM", Children: []*Chunk{
+		{ID: "BMHD", Data: []byte{0, 64, 0, 32, 0, 0, 0, 0, 4, 0, 1, 0, 0, 0, 10, 11, 1, 64, 0, 200}},
+		{ID: "ACME", Data: []byte{7}},
+		{ID: "BODY", Data: []byte{1, 2, 3}},
+	}}
+	var buf bytes.Buffer
+	if err := Write(&buf, root); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestParseWrite(t *testing.T) {
+	data := makeTestFile(t)
+	root, err := Parse(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, root); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), data) {
+		t.Errorf("Write: got % X, want % X", buf.Bytes(), data)
+	}
+
+	root, diags, err := ParseLenient(bytes.NewReader(data[:len(data)-2]), int64(len(data)-2))
+	if err != nil || root == nil || !HasErrors(diags) {
+		t.Errorf("ParseLenient: got %v, %v, %v", root, diags, err)
+	}
+}
+
+func TestWalkFind(t *testing.T) {
+	data := makeTestFile(t)
+	root, err := Parse(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var paths []string
+	err = Walk(root, func(chunk *Chunk, path string, level int) error {
+		paths = append(paths, fmt.Sprintf("%d %s", level, path))
+		return nil
+	})
+	want := []string{"0 FORM:ILBM", "1 FORM:ILBM/BMHD[0]", "1 FORM:ILBM/ACME[1]", "1 FORM:ILBM/BODY[2]"}
+	if err != nil || !slices.Equal(paths, want) {
+		t.Errorf("Walk: got %v, %v, want %v", paths, err, want)
+	}
+
+	var tests = []struct {
+		path string
+		want string
+	}{
+		{"FORM:ILBM", "FORM"},
+		{"FORM:ILBM/BODY[2]", "BODY"},
+		{"FORM:ILBM/BODY[1]", ""},
+	}
+	for _, tt := range tests {
+		chunk := Find(root, tt.path)
+		if (chunk == nil && tt.want != "") || (chunk != nil && chunk.ID != tt.want) {
+			t.Errorf("Find %s: got %v, want %s", tt.path, chunk, tt.want)
+		}
+	}
+
+	if form := FindForm(root, "ANIM", "ILBM"); form != root {
+		t.Errorf("FindForm: got %v, want root", form)
+	}
+	if child := root.Children[1]; Path("FORM:ILBM", child, 1) != "FORM:ILBM/ACME[1]" {
+		t.Errorf("Path: got %s", Path("FORM:ILBM", child, 1))
+	}
+}
+
+func TestDecode(t *testing.T) {
+	data := makeTestFile(t)
+	root, err := Parse(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bmhd := Find(root, "FORM:ILBM/BMHD[0]")
+	description, fields, err := Decode(bmhd.Type(), bmhd.Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if description != "Bitmap Header" || len(fields) == 0 || fields[0].Value != "64 : 32" {
+		t.Errorf("BMHD: got %q, %v", description, fields)
+	}
+
+	// custom handler for an unknown chunk type
+	acme := Find(root, "FORM:ILBM/ACME[1]")
+	if description, _, err := Decode(acme.Type(), acme.Data); description != "(unknown)" || err != nil {
+		t.Errorf("ACME before registration: got %q, %v", description, err)
+	}
+	err = RegisterHandler("ILBM.ACME", func(data []byte) (Fields, error) {
+		return Fields{{Name: "Level", Value: fmt.Sprint(data[0]), Raw: data[0], Length: 1}}, nil
+	}, "ACME Settings")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { UnregisterHandler("ILBM.ACME") })
+	description, fields, err = Decode(acme.Type(), acme.Data)
+	if err != nil || description != "ACME Settings" || len(fields) != 1 || fields[0].Raw != uint8(7) {
+		t.Errorf("ACME: got %q, %v, %v", description, fields, err)
+	}
+
+	if err := RegisterHandler("ACME", nil, ""); err == nil {
+		t.Errorf("Invalid chunk type: got no error, want error")
+	}
+}
+
+func TestRegisterHandler(t *testing.T) {
+	probe := func(data []byte) (Fields, error) {
+		return Fields{{Name: "Size", Value: fmt.Sprint(len(data)), Raw: len(data)}}, nil
+	}
+	var buf bytes.Buffer
+	err := Write(&buf, &Chunk{ID: "FORM", SubID: "ILBM", Children: []*Chunk{
+		{ID: "ZZZZ", Data: []byte{1, 2}},
+		{ID: "NAME", Data: []byte("Test")},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+
+	// a handler of (any) is used for chunks parsed before its registration
+	root, err := Parse(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterHandler("(any).ZZZZ", probe, "Probe"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { UnregisterHandler("(any).ZZZZ") })
+	zzzz := Find(root, "FORM:ILBM/ZZZZ[0]")
+	if description, fields, err := Decode(zzzz.Type(), zzzz.Data); description != "Probe" ||
+		err != nil || len(fields) != 1 || fields[0].Raw != 2 {
+
+		t.Errorf("(any).ZZZZ: got %q, %v, %v", description, fields, err)
+	}
+
+	// a handler of a FORM type overrides (any) for chunks parsed before
+	name := Find(root, "FORM:ILBM/NAME[1]")
+	if name.Type() != "(any).NAME" {
+		t.Errorf("NAME before registration: got %s, want (any).NAME", name.Type())
+	}
+	if err := RegisterHandler("ILBM.NAME", probe, "ILBM Name"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { UnregisterHandler("ILBM.NAME") })
+	if description, fields, err := Decode(name.Type(), name.Data); name.Type() != "ILBM.NAME" ||
+		description != "ILBM Name" || err != nil || len(fields) != 1 || fields[0].Raw != 4 {
+
+		t.Errorf("ILBM.NAME: got %s, %q, %v, %v", name.Type(), description, fields, err)
+	}
+	UnregisterHandler("ILBM.NAME")
+	if name.Type() != "(any).NAME" {
+		t.Errorf("NAME after unregistration: got %s, want (any).NAME", name.Type())
+	}
+}
+
+func TestUnregisterHandler(t *testing.T) {
+	data := makeTestFile(t)
+	root, err := Parse(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bmhd := Find(root, "FORM:ILBM/BMHD[0]")
+
+	err = RegisterHandler("ILBM.BMHD", func(data []byte) (Fields, error) {
+		return Fields{{Name: "Override", Value: "1"}}, nil
+	}, "Override")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { UnregisterHandler("ILBM.BMHD") })
+	if description, _, _ := Decode(bmhd.Type(), bmhd.Data); description != "Override" {
+		t.Errorf("Override: got %q, want Override", description)
+	}
+
+	// the built-in handler is restored
+	UnregisterHandler("ILBM.BMHD")
+	description, fields, err := Decode(bmhd.Type(), bmhd.Data)
+	if err != nil || description != "Bitmap Header" || len(fields) == 0 || fields[0].Value != "64 : 32" {
+		t.Errorf("Built-in: got %q, %v, %v", description, fields, err)
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package iff
+
+import (
+	"image"
+	"image/color"
+	"io"
+
+	"github.com/mattrust/iffmaster/internal/chunks"
+)
+
+// Masking techniques of the BMHD chunk.
+const (
+	MaskNone                = 0
+	MaskHasMask             = 1
+	MaskHasTransparentColor = 2
+	MaskLasso               = 3
+)
+
+// Compression algorithms of the BMHD chunk.
+const (
+	CompressionNone     = 0
+	CompressionByteRun1 = 1
+)
+
+// BitmapHeader is the content of a BMHD chunk.
+type BitmapHeader struct {
+	Width, Height         uint16
+	X, Y                  int16
+	NPlanes               uint8
+	Masking               uint8
+	Compression           uint8
+	TransparentColor      uint16
+	XAspect, YAspect      uint8
+	PageWidth, PageHeight int16
+}
+
+// Bitmap is a planar bitmap as used by the Amiga hardware.
+// Each plane contains Height rows of BytesPerRow bytes.
+type Bitmap struct {
+	Width       int
+	Height      int
+	BytesPerRow int
+	Planes      [][]byte
+}
+
+// ViewMode is the display mode ID of a CAMG chunk.
+type ViewMode uint32
+
+// IsHAM returns true for Hold-And-Modify modes.
+func (vm ViewMode) IsHAM() bool {
+	return chunks.ViewMode(vm).IsHAM()
+}
+
+// IsEHB returns true for Extra-Halfbrite modes.
+func (vm ViewMode) IsEHB() bool {
+	return chunks.ViewMode(vm).IsEHB()
+}
+
+// MonitorName returns the name of the monitor.
+func (vm ViewMode) MonitorName() string {
+	return chunks.ViewMode(vm).MonitorName()
+}
+
+// FlagNames returns the names of all flags which are set. Flags of
+// graphics card modes have no defined meaning and are not decoded.
+func (vm ViewMode) FlagNames() []string {
+	return chunks.ViewMode(vm).FlagNames()
+}
+
+// String returns the monitor name and the flags.
+func (vm ViewMode) String() string {
+	return chunks.ViewMode(vm).String()
+}
+
+// Picture contains the decoded data of an ILBM or ACBM FORM.
+type Picture struct {
+	Header       BitmapHeader
+	Palette      color.Palette   // colors of the CMAP chunk
+	ViewMode     ViewMode        // sanitized display mode of the CAMG chunk
+	HasViewMode  bool            // true if the FORM contains a CAMG chunk
+	LinePalettes []color.Palette // palette of each line from SHAM, CTBL or PCHG, else nil
+	Hotspot      *image.Point    // position of the GRAB chunk, else nil
+	XDPI, YDPI   uint16          // resolution of the DPI chunk, else 0
+	Bitmap       *Bitmap
+	Mask         []byte // mask plane if Masking is MaskHasMask, else nil
+}
+
+// Image converts the picture to an image.
+// Pictures with up to 8 planes, without mask plane, HAM and line palettes
+// are returned as *image.Paletted, all other pictures as *image.NRGBA.
+func (pic *Picture) Image() image.Image {
+	return pic.internal().Image()
+}
+
+// IsHAM returns true if the picture uses Hold-And-Modify with 6 or 8 planes.
+func (pic *Picture) IsHAM() bool {
+	return pic.internal().IsHAM()
+}
+
+// IsEHB returns true if the picture uses Extra-Halfbrite. Without a CAMG
+// chunk, pictures with 6 planes and at most 32 colors are treated as
+// Extra-Halfbrite.
+func (pic *Picture) IsEHB() bool {
+	return pic.internal().IsEHB()
+}
+
+// ILBMEncodeOptions controls how EncodeILBM and EncodeANIM convert
+// images.
+type ILBMEncodeOptions struct {
+	// number of planes from 1 to 8, 0 selects the smallest number
+	// which holds all colors
+	Planes int
+
+	// compress the BODY with ByteRun1
+	Compress bool
+
+	// MaskNone, MaskHasMask or MaskHasTransparentColor; transparent
+	// pixels are those with an alpha value below 128
+	Masking uint8
+
+	// pixel aspect ratio, 0 is written as 1
+	XAspect, YAspect uint8
+
+	// display mode of the CAMG chunk, which is omitted if 0
+	ViewMode uint32
+}
+
+// IsPictureForm returns true for FORMs which can be decoded by
+// DecodeILBM.
+func IsPictureForm(form *Chunk) bool {
+	return form != nil && form.ID == "FORM" && (form.SubID == "ILBM" || form.SubID == "ACBM")
+}
+
+// FindPictureForm returns the first FORM in the chunk tree which can be
+// decoded by DecodeILBM or nil if there is none.
+func FindPictureForm(root *Chunk) *Chunk {
+	return FindForm(root, "ILBM", "ACBM")
+}
+
+// DecodeILBM decodes the picture of an ILBM or ACBM FORM.
+// In case of an error, the function returns nil and the error. If only
+// the BODY or the line palettes are broken, the picture is returned
+// together with the error.
+func DecodeILBM(form *Chunk) (*Picture, error) {
+	pic, err := chunks.DecodeILBMPicture(form.internal())
+	return newPicture(pic), err
+}
+
+// EncodeILBM converts an image into an ILBM FORM with BMHD, CMAP, CAMG and
+// BODY chunk, which can be saved with Write. Paletted images with few
+// enough colors keep their palette, all other images are quantized to
+// the number of colors the planes can hold.
+func EncodeILBM(img image.Image, opts ILBMEncodeOptions) (*Chunk, error) {
+	form, err := chunks.EncodeILBM(img, chunks.ILBMEncodeOptions(opts))
+	return newChunk(form), err
+}
+
+// EncodePNG writes the picture as PNG. Transparency from the mask plane
+// or the transparent color becomes the alpha channel. The hotspot of the
+// GRAB chunk is stored in a tEXt chunk, the resolution of the DPI chunk
+// in a pHYs chunk.
+func EncodePNG(w io.Writer, pic *Picture) error {
+	return chunks.EncodePNG(w, pic.internal())
+}
+
+// QuantizeImage reduces the colors of the image to at most maxColors with
+// the median cut algorithm, e.g. before it's passed to EncodeILBM or
+// EncodeANIM. Pixels with an alpha value below 128 get color index 0 if
+// the image contains any. Images which already have few enough colors
+// keep them exactly.
+func QuantizeImage(img image.Image, maxColors int) *image.Paletted {
+	return chunks.QuantizeImage(img, maxColors)
+}
+
+// newPicture converts an internal picture to a public one.
+func newPicture(pic *chunks.ILBMPicture) *Picture {
+	if pic == nil {
+		return nil
+	}
+	return &Picture{
+		Header:       BitmapHeader(pic.Header),
+		Palette:      pic.Palette,
+		ViewMode:     ViewMode(pic.ViewMode),
+		HasViewMode:  pic.HasViewMode,
+		LinePalettes: pic.LinePalettes,
+		Hotspot:      pic.Hotspot,
+		XDPI:         pic.XDPI,
+		YDPI:         pic.YDPI,
+		Bitmap:       (*Bitmap)(pic.Bitmap),
+		Mask:         pic.Mask,
+	}
+}
+
+// internal converts a public picture to an internal one.
+func (pic *Picture) internal() *chunks.ILBMPicture {
+	if pic == nil {
+		return nil
+	}
+	return &chunks.ILBMPicture{
+		Header:       chunks.BitmapHeader(pic.Header),
+		Palette:      pic.Palette,
+		ViewMode:     chunks.ViewMode(pic.ViewMode),
+		HasViewMode:  pic.HasViewMode,
+		LinePalettes: pic.LinePalettes,
+		Hotspot:      pic.Hotspot,
+		XDPI:         pic.XDPI,
+		YDPI:         pic.YDPI,
+		Bitmap:       (*chunks.Bitmap)(pic.Bitmap),
+		Mask:         pic.Mask,
+	}
+}
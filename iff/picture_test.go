@@ -0,0 +1,106 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package iff
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"testing"
+	"time"
+)
+
+// makeTestImage returns a 4x2 paletted image with the given colors.
+func makeTestImage(colors ...color.Color) *image.Paletted {
+	img := image.NewPaletted(image.Rect(0, 0, 4, 2), colors)
+	for i := range img.Pix {
+		img.Pix[i] = uint8(i % len(colors))
+	}
+	return img
+}
+
+func TestPicture(t *testing.T) {
+	img := makeTestImage(color.RGBA{0, 0, 0, 255}, color.RGBA{255, 0, 0, 255})
+	form, err := EncodeILBM(img, ILBMEncodeOptions{Compress: true, ViewMode: 0x8000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := Write(&buf, form); err != nil {
+		t.Fatal(err)
+	}
+	root, err := Parse(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	form = FindPictureForm(root)
+	if !IsPictureForm(form) {
+		t.Fatalf("FindPictureForm: got %v", form)
+	}
+	pic, err := DecodeILBM(form)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pic.Header.Width != 4 || pic.Header.NPlanes != 1 || len(pic.Bitmap.Planes) != 1 {
+		t.Errorf("Header: got %+v", pic.Header)
+	}
+	if pic.ViewMode.String() != "Default: HIRES" || pic.IsHAM() || pic.IsEHB() {
+		t.Errorf("ViewMode: got %s", pic.ViewMode)
+	}
+	decoded := pic.Image()
+	for i := range img.Pix {
+		x, y := i%4, i/4
+		r1, g1, b1, _ := img.At(x, y).RGBA()
+		r2, g2, b2, _ := decoded.At(x, y).RGBA()
+		if r1 != r2 || g1 != g2 || b1 != b2 {
+			t.Errorf("Pixel %d,%d: got %v, want %v", x, y, decoded.At(x, y), img.At(x, y))
+		}
+	}
+
+	buf.Reset()
+	if err := EncodePNG(&buf, pic); err != nil || !bytes.HasPrefix(buf.Bytes(), []byte("\x89PNG")) {
+		t.Errorf("EncodePNG: got % X, %v", buf.Bytes(), err)
+	}
+}
+
+func TestAnimation(t *testing.T) {
+	palette := color.Palette{color.RGBA{0, 0, 0, 255}, color.RGBA{0, 0, 255, 255}}
+	frames := []*image.Paletted{makeTestImage(palette...), makeTestImage(palette[1], palette[0])}
+	delay := 6 * (time.Second / 60) // ANIM stores jiffies
+	form, err := EncodeANIM(frames, delay, ILBMEncodeOptions{Planes: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsAnimForm(form) {
+		t.Fatalf("EncodeANIM: got %v", form)
+	}
+	var buf bytes.Buffer
+	if err := Write(&buf, form); err != nil {
+		t.Fatal(err)
+	}
+	root, err := Parse(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	anim, err := DecodeANIM(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(anim.Frames) != 2 || anim.Frames[1].Delay != delay {
+		t.Fatalf("Frames: got %+v", anim.Frames)
+	}
+	if got := color.RGBAModel.Convert(anim.Frames[1].Picture.Image().At(0, 0)); got != palette[1] {
+		t.Errorf("Frame 1: got %v, want %v", got, palette[1])
+	}
+
+	buf.Reset()
+	if err := EncodeGIF(&buf, anim); err != nil || !bytes.HasPrefix(buf.Bytes(), []byte("GIF89a")) {
+		t.Errorf("EncodeGIF: got % X, %v", buf.Bytes(), err)
+	}
+	if q := QuantizeImage(frames[0], 2); len(q.Palette) != 2 {
+		t.Errorf("QuantizeImage: got %d colors, want 2", len(q.Palette))
+	}
+}
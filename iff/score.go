@@ -0,0 +1,99 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package iff
+
+import (
+	"io"
+
+	"github.com/mattrust/iffmaster/internal/chunks"
+)
+
+// Instrument reference types of INS1 chunks.
+const (
+	INS1Name = 0 // the instrument is found by its name
+	INS1MIDI = 1 // MIDI channel and preset
+)
+
+// SMUSHeader contains the data of an SHDR chunk.
+type SMUSHeader struct {
+	Tempo  uint16 // 128ths of quarter notes per minute
+	Volume uint8  // 0-127
+	Tracks uint8
+}
+
+// BPM returns the tempo in quarter notes per minute.
+func (shdr SMUSHeader) BPM() float64 {
+	return chunks.SMUSHeader(shdr).BPM()
+}
+
+// SMUSInstrument contains the data of an INS1 chunk.
+type SMUSInstrument struct {
+	Register uint8
+	Type     uint8 // INS1Name or INS1MIDI
+	Data1    uint8 // MIDI channel for INS1MIDI
+	Data2    uint8 // MIDI preset for INS1MIDI
+	Name     string
+}
+
+// SMUSEvent is an event of a TRAK chunk. SID 0 to 127 are notes.
+type SMUSEvent struct {
+	SID  uint8
+	Data uint8
+}
+
+// String describes the event, e.g. "Note C4, dotted quarter, chord".
+func (e SMUSEvent) String() string {
+	return chunks.SMUSEvent(e).String()
+}
+
+// SMUSScore contains the decoded data of an SMUS or CMUS FORM.
+type SMUSScore struct {
+	Header      SMUSHeader
+	Name        string
+	Instruments []SMUSInstrument
+	Tracks      [][]SMUSEvent
+}
+
+// IsScoreForm returns true for FORMs which can be decoded by DecodeSMUS.
+// Besides SMUS these are CMUS scores, which use the same chunks.
+func IsScoreForm(form *Chunk) bool {
+	return form != nil && form.ID == "FORM" && (form.SubID == "SMUS" || form.SubID == "CMUS")
+}
+
+// DecodeSMUS decodes the SHDR, NAME, INS1 and TRAK chunks of an SMUS or
+// CMUS FORM.
+func DecodeSMUS(form *Chunk) (*SMUSScore, error) {
+	score, err := chunks.DecodeSMUS(form.internal())
+	if score == nil {
+		return nil, err
+	}
+	return &SMUSScore{
+		Header: SMUSHeader(score.Header),
+		Name:   score.Name,
+		Instruments: convertSlice(score.Instruments, func(ins chunks.SMUSInstrument) SMUSInstrument {
+			return SMUSInstrument(ins)
+		}),
+		Tracks: convertSlice(score.Tracks, func(track []chunks.SMUSEvent) []SMUSEvent {
+			return convertSlice(track, func(e chunks.SMUSEvent) SMUSEvent { return SMUSEvent(e) })
+		}),
+	}, err
+}
+
+// WriteMIDI writes the score as Standard MIDI File of format 1. The first
+// MIDI track contains the name and the tempo of the score, each TRAK
+// becomes a MIDI track which starts on the channel with its number.
+// Instruments with a MIDI reference select their channel and preset,
+// the names of all instruments are added as meta events.
+func WriteMIDI(w io.Writer, score *SMUSScore) error {
+	return chunks.WriteMIDI(w, &chunks.SMUSScore{
+		Header: chunks.SMUSHeader(score.Header),
+		Name:   score.Name,
+		Instruments: convertSlice(score.Instruments, func(ins SMUSInstrument) chunks.SMUSInstrument {
+			return chunks.SMUSInstrument(ins)
+		}),
+		Tracks: convertSlice(score.Tracks, func(track []SMUSEvent) []chunks.SMUSEvent {
+			return convertSlice(track, func(e SMUSEvent) chunks.SMUSEvent { return chunks.SMUSEvent(e) })
+		}),
+	})
+}
@@ -0,0 +1,215 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package iff
+
+import (
+	"io"
+
+	"github.com/mattrust/iffmaster/internal/chunks"
+)
+
+// SoundMarker is a named position in a sound, e.g. the start of the
+// repeat part.
+type SoundMarker struct {
+	Position int // sample frame
+	Name     string
+}
+
+// EnvelopePoint is a point of a volume envelope.
+type EnvelopePoint struct {
+	Position int     // sample frame
+	Level    float64 // 0 is silent, 1 is full volume
+}
+
+// Sound contains the decoded samples of a sampled-sound FORM in a common
+// format for display.
+type Sound struct {
+	SampleRate float64
+	Channels   [][]float64 // samples of each channel from -1 to 1
+	Markers    []SoundMarker
+
+	// attack and release envelope as polyline, nil if there is none
+	Attack  []EnvelopePoint
+	Release []EnvelopePoint
+}
+
+// IsSoundForm returns true for FORMs which can be decoded by DecodeSound.
+func IsSoundForm(form *Chunk) bool {
+	if form == nil || form.ID != "FORM" {
+		return false
+	}
+	switch form.SubID {
+	case "8SVX", "16SV", "AIFF", "AIFC":
+		return true
+	}
+	return false
+}
+
+// DecodeSound decodes the samples of an 8SVX, 16SV, AIFF or AIFC FORM.
+// The one-shot and repeat parts and octaves of 8SVX and 16SV become
+// markers as well as the MARK chunk of AIFF.
+// In case of an error, the function returns nil and the error. If only
+// the sample data is too short, the available samples are returned
+// together with the error.
+func DecodeSound(form *Chunk) (*Sound, error) {
+	snd, err := chunks.DecodeSound(form.internal())
+	if snd == nil {
+		return nil, err
+	}
+	return &Sound{
+		SampleRate: snd.SampleRate,
+		Channels:   snd.Channels,
+		Markers:    convertSlice(snd.Markers, func(m chunks.SoundMarker) SoundMarker { return SoundMarker(m) }),
+		Attack:     newEnvelope(snd.Attack),
+		Release:    newEnvelope(snd.Release),
+	}, err
+}
+
+// newEnvelope converts an internal envelope to a public one.
+func newEnvelope(points []chunks.EnvelopePoint) []EnvelopePoint {
+	return convertSlice(points, func(p chunks.EnvelopePoint) EnvelopePoint { return EnvelopePoint(p) })
+}
+
+// Voice8Header is the content of a VHDR chunk.
+type Voice8Header struct {
+	OneShotHiSamples  uint32
+	RepeatHiSamples   uint32
+	SamplesPerHiCycle uint32
+	SamplesPerSec     uint16
+	CtOctave          uint8
+	Compression       uint8
+	Volume            int32 // Fixed, 0x10000 is full volume
+}
+
+// Voice8Octave contains the samples of one octave. OneShot is played
+// once, then Repeat is looped.
+type Voice8Octave struct {
+	OneShot []int8
+	Repeat  []int8
+}
+
+// Voice8 contains the decoded data of an 8SVX FORM.
+type Voice8 struct {
+	Header Voice8Header
+
+	// octaves from the highest to the lowest; all are played with
+	// the sample rate of the header, each octave has twice the samples
+	// of the previous one
+	Octaves []Voice8Octave
+}
+
+// Decode8SVX decodes the samples of an 8SVX FORM and splits them into
+// the one-shot and repeat part of each octave.
+// In case of an error, the function returns nil and the error. If only
+// the BODY is too short, the available samples are returned together
+// with the error.
+func Decode8SVX(form *Chunk) (*Voice8, error) {
+	voice, err := chunks.Decode8SVX(form.internal())
+	if voice == nil {
+		return nil, err
+	}
+	return &Voice8{
+		Header:  Voice8Header(voice.Header),
+		Octaves: convertSlice(voice.Octaves, func(o chunks.Voice8Octave) Voice8Octave { return Voice8Octave(o) }),
+	}, err
+}
+
+// WAV converts an octave to an 8 bit WAV. A repeat part becomes a loop.
+func (voice *Voice8) WAV(octave int) (*WAV, error) {
+	internal := &chunks.Voice8{
+		Header:  chunks.Voice8Header(voice.Header),
+		Octaves: convertSlice(voice.Octaves, func(o Voice8Octave) chunks.Voice8Octave { return chunks.Voice8Octave(o) }),
+	}
+	wav, err := internal.WAV(octave)
+	return newWAV(wav), err
+}
+
+// WAVLoop is a sustain loop of a WAV file. Start and End are the indices
+// of the first and the last frame of the loop.
+type WAVLoop struct {
+	Start uint32
+	End   uint32
+}
+
+// WAV contains the data of a RIFF WAVE file with PCM samples.
+type WAV struct {
+	SampleRate    uint32
+	Channels      uint16
+	BitsPerSample uint16 // 8 (unsigned samples) or more (signed samples)
+	Float         bool   // samples are IEEE floats, only read by ReadWAV
+	Data          []byte // interleaved samples in WAV format
+	Loops         []WAVLoop
+	Info          map[string]string // texts of the LIST INFO chunk by ID
+}
+
+// Samples returns the samples of each channel from -1 to 1.
+func (wav *WAV) Samples() [][]float64 {
+	return wav.internal().Samples()
+}
+
+// ReadWAV reads a RIFF WAVE file with PCM or IEEE float samples
+// including the loops of the smpl chunk and the texts of the LIST INFO
+// chunk.
+func ReadWAV(r io.Reader) (*WAV, error) {
+	wav, err := chunks.ReadWAV(r)
+	return newWAV(wav), err
+}
+
+// WriteWAV writes the WAV as RIFF WAVE file with 8 or 16 bit PCM samples.
+// Loops are stored in a smpl chunk.
+func WriteWAV(w io.Writer, wav *WAV) error {
+	return chunks.WriteWAV(w, wav.internal())
+}
+
+// WAVImportOptions controls how ConvertWAVTo8SVX converts a WAV.
+type WAVImportOptions struct {
+	// sample rate of the 8SVX, 0 keeps the rate of the WAV
+	SampleRate uint32
+
+	// compress the BODY with Fibonacci-delta
+	Compress bool
+}
+
+// ConvertWAVTo8SVX converts a WAV into an 8SVX FORM, which can be saved
+// with Write. The channels are mixed down to mono and quantized to 8 bit.
+// The first loop of the WAV becomes the repeat part, samples after the
+// loop are dropped as 8SVX can't play them. The name, copyright, author
+// and comment of the LIST INFO chunk become NAME, (c), AUTH and ANNO
+// chunks.
+func ConvertWAVTo8SVX(wav *WAV, opts WAVImportOptions) (*Chunk, error) {
+	form, err := chunks.ConvertWAVTo8SVX(wav.internal(), chunks.WAVImportOptions(opts))
+	return newChunk(form), err
+}
+
+// newWAV converts an internal WAV to a public one.
+func newWAV(wav *chunks.WAV) *WAV {
+	if wav == nil {
+		return nil
+	}
+	return &WAV{
+		SampleRate:    wav.SampleRate,
+		Channels:      wav.Channels,
+		BitsPerSample: wav.BitsPerSample,
+		Float:         wav.Float,
+		Data:          wav.Data,
+		Loops:         convertSlice(wav.Loops, func(l chunks.WAVLoop) WAVLoop { return WAVLoop(l) }),
+		Info:          wav.Info,
+	}
+}
+
+// internal converts a public WAV to an internal one.
+func (wav *WAV) internal() *chunks.WAV {
+	if wav == nil {
+		return nil
+	}
+	return &chunks.WAV{
+		SampleRate:    wav.SampleRate,
+		Channels:      wav.Channels,
+		BitsPerSample: wav.BitsPerSample,
+		Float:         wav.Float,
+		Data:          wav.Data,
+		Loops:         convertSlice(wav.Loops, func(l WAVLoop) chunks.WAVLoop { return chunks.WAVLoop(l) }),
+		Info:          wav.Info,
+	}
+}
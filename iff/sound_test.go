@@ -0,0 +1,53 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package iff
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+)
+
+func TestSound(t *testing.T) {
+	wav := &WAV{SampleRate: 8000, Channels: 1, BitsPerSample: 8,
+		Data: []byte{0x80, 0xC0, 0xFF, 0xC0, 0x80, 0x40}, Loops: []WAVLoop{{2, 5}}}
+	var buf bytes.Buffer
+	if err := WriteWAV(&buf, wav); err != nil {
+		t.Fatal(err)
+	}
+	wav, err := ReadWAV(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(wav.Samples()) != 1 || len(wav.Samples()[0]) != 6 {
+		t.Errorf("ReadWAV: got %v", wav.Samples())
+	}
+
+	form, err := ConvertWAVTo8SVX(wav, WAVImportOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsSoundForm(form) {
+		t.Fatalf("ConvertWAVTo8SVX: got %v", form)
+	}
+	voice, err := Decode8SVX(form)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if voice.Header.SamplesPerSec != 8000 || len(voice.Octaves) != 1 ||
+		len(voice.Octaves[0].OneShot) != 2 || len(voice.Octaves[0].Repeat) != 4 {
+
+		t.Errorf("Decode8SVX: got %+v", voice)
+	}
+
+	converted, err := voice.WAV(0)
+	if err != nil || !bytes.Equal(converted.Data, wav.Data) || !slices.Equal(converted.Loops, wav.Loops) {
+		t.Errorf("WAV: got %+v, %v, want %+v", converted, err, wav)
+	}
+
+	snd, err := DecodeSound(form)
+	if err != nil || snd.SampleRate != 8000 || len(snd.Channels) != 1 || len(snd.Markers) == 0 {
+		t.Errorf("DecodeSound: got %+v, %v", snd, err)
+	}
+}
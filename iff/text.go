@@ -0,0 +1,108 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package iff
+
+import (
+	"io"
+
+	"github.com/mattrust/iffmaster/internal/chunks"
+)
+
+// Values of the proportional and serif fields of a FONS chunk.
+const (
+	FontUnknown = 0
+	FontNo      = 1
+	FontYes     = 2
+)
+
+// TextPenColors are the colors of the pens 0 to 7 which can be selected
+// with the SGR sequences 30-37 and 40-47. They are the default colors of
+// the Workbench.
+var TextPenColors = chunks.TextPenColors
+
+// TextStyle is the rendition of text selected by SGR sequences.
+type TextStyle struct {
+	Bold       bool
+	Italic     bool
+	Underline  bool
+	Inverse    bool
+	Foreground int // pen 0-7 or -1 for the default color
+	Background int // pen 0-7 or -1 for the default color
+	Font       int // ID of the FONS chunk, 0 is the default font
+}
+
+// TextSpan is a text with the same style.
+type TextSpan struct {
+	Text  string
+	Style TextStyle
+}
+
+// FontSpec is the font specification of a FONS chunk.
+type FontSpec struct {
+	ID           uint8
+	Proportional uint8 // FontUnknown, FontNo or FontYes
+	Serif        uint8 // FontUnknown, FontNo or FontYes
+	Name         string
+}
+
+// FormattedText contains the decoded data of an FTXT FORM.
+type FormattedText struct {
+	Fonts []FontSpec
+	Spans []TextSpan
+}
+
+// IsFormattedTextForm returns true for FORMs which can be decoded by
+// DecodeFTXT.
+func IsFormattedTextForm(form *Chunk) bool {
+	return form != nil && form.ID == "FORM" && form.SubID == "FTXT"
+}
+
+// DecodeFTXT decodes the FONS and CHRS chunks of an FTXT FORM. The CHRS
+// chunks are concatenated, the style continues from one to the next.
+// In case of an error, the text decoded so far is returned together
+// with the error.
+func DecodeFTXT(form *Chunk) (*FormattedText, error) {
+	ftxt, err := chunks.DecodeFTXT(form.internal())
+	if ftxt == nil {
+		return nil, err
+	}
+	return &FormattedText{
+		Fonts: convertSlice(ftxt.Fonts, func(font chunks.FontSpec) FontSpec { return FontSpec(font) }),
+		Spans: convertSlice(ftxt.Spans, func(span chunks.TextSpan) TextSpan {
+			return TextSpan{span.Text, TextStyle(span.Style)}
+		}),
+	}, err
+}
+
+// Font returns the font specification with the given ID or nil.
+func (ftxt *FormattedText) Font(id int) *FontSpec {
+	for i := range ftxt.Fonts {
+		if int(ftxt.Fonts[i].ID) == id {
+			return &ftxt.Fonts[i]
+		}
+	}
+	return nil
+}
+
+// PlainText returns the text without styles.
+func (ftxt *FormattedText) PlainText() string {
+	return ftxt.internal().PlainText()
+}
+
+// WriteHTML writes the text as HTML document. Pens are shown in the
+// colors of TextPenColors, fonts by their name with a generic family
+// as fallback.
+func (ftxt *FormattedText) WriteHTML(w io.Writer, title string) error {
+	return ftxt.internal().WriteHTML(w, title)
+}
+
+// internal converts public formatted text to internal formatted text.
+func (ftxt *FormattedText) internal() *chunks.FormattedText {
+	return &chunks.FormattedText{
+		Fonts: convertSlice(ftxt.Fonts, func(font FontSpec) chunks.FontSpec { return chunks.FontSpec(font) }),
+		Spans: convertSlice(ftxt.Spans, func(span TextSpan) chunks.TextSpan {
+			return chunks.TextSpan{Text: span.Text, Style: chunks.TextStyle(span.Style)}
+		}),
+	}
+}
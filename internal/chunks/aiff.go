@@ -0,0 +1,132 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// AIFFCommon is the content of a COMM chunk of an AIFF or AIFC FORM.
+type AIFFCommon struct {
+	NumChannels     int16
+	NumSampleFrames uint32
+	SampleSize      int16
+	SampleRate      float64
+
+	// only in AIFC, "NONE" for AIFF
+	CompressionType string
+	CompressionName string
+}
+
+// AIFFMarker is an entry of a MARK chunk.
+type AIFFMarker struct {
+	ID       int16
+	Position uint32 // sample frame
+	Name     string
+}
+
+// DecodeExtended converts an IEEE 754 80 bit extended precision number
+// as used for the sample rate of AIFF.
+func DecodeExtended(data []byte) (float64, error) {
+	if len(data) < 10 {
+		return 0, fmt.Errorf("extended number is too short")
+	}
+
+	sign := 1.0
+	if data[0]&0x80 != 0 {
+		sign = -1.0
+	}
+	exponent := int(binary.BigEndian.Uint16(data)) & 0x7FFF
+	mantissa := binary.BigEndian.Uint64(data[2:])
+
+	switch {
+	case exponent == 0 && mantissa == 0:
+		return 0, nil
+	case exponent == 0x7FFF:
+		if mantissa<<1 == 0 {
+			return math.Inf(int(sign)), nil
+		}
+		return math.NaN(), nil
+	}
+
+	// the mantissa has an explicit integer bit
+	return sign * math.Ldexp(float64(mantissa), exponent-16383-63), nil
+}
+
+// getPString reads a Pascal string with a count byte. The count byte and
+// the text are padded to an even length.
+func getPString(data []byte, offset *uint32) (string, error) {
+	count, err := getUbyte(data, offset)
+	if err != nil {
+		return "", err
+	}
+	text, err := getStringBuffer(data, offset, uint32(count))
+	if err != nil {
+		return "", err
+	}
+	if count%2 == 0 {
+		*offset++ // pad byte
+	}
+	return text, nil
+}
+
+// ParseAIFFCommon decodes the data of a COMM chunk. isAIFC selects the
+// extended variant of AIFC with compression type and name.
+func ParseAIFFCommon(data []byte, isAIFC bool) (AIFFCommon, error) {
+	var comm AIFFCommon
+	var offset uint32
+	var err error
+
+	if len(data) < 18 {
+		return comm, fmt.Errorf("COMM is too short")
+	}
+
+	// the length has been checked, so no errors are expected below
+	comm.NumChannels, _ = getBeWord(data, &offset)
+	comm.NumSampleFrames, _ = getBeUlong(data, &offset)
+	comm.SampleSize, _ = getBeWord(data, &offset)
+	comm.SampleRate, _ = DecodeExtended(data[offset:])
+	offset += 10
+
+	comm.CompressionType = "NONE"
+	if !isAIFC {
+		return comm, nil
+	}
+	comm.CompressionType, err = getStringBuffer(data, &offset, 4)
+	if err != nil {
+		return comm, err
+	}
+	comm.CompressionName, err = getPString(data, &offset)
+
+	return comm, err
+}
+
+// ParseAIFFMarkers decodes the data of a MARK chunk.
+func ParseAIFFMarkers(data []byte) ([]AIFFMarker, error) {
+	var offset uint32
+
+	count, err := getBeUword(data, &offset)
+	if err != nil {
+		return nil, err
+	}
+
+	markers := make([]AIFFMarker, 0, count)
+	for i := 0; i < int(count); i++ {
+		var marker AIFFMarker
+		if marker.ID, err = getBeWord(data, &offset); err != nil {
+			return markers, err
+		}
+		if marker.Position, err = getBeUlong(data, &offset); err != nil {
+			return markers, err
+		}
+		if marker.Name, err = getPString(data, &offset); err != nil {
+			return markers, err
+		}
+		markers = append(markers, marker)
+	}
+
+	return markers, nil
+}
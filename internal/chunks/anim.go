@@ -0,0 +1,484 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// Operations of the ANHD chunk.
+const (
+	AnimOpDirect               = 0
+	AnimOpXOR                  = 1
+	AnimOpLongDelta            = 2
+	AnimOpShortDelta           = 3
+	AnimOpShortLongDelta       = 4
+	AnimOpByteVertical         = 5
+	AnimOpStereo               = 6
+	AnimOpShortLongVertical    = 7
+	AnimOpShortLongVerticalOps = 8
+	AnimOpGraham               = 74 // 'J'
+)
+
+// Flags of the bits field of the ANHD chunk.
+const (
+	AnimBitLongData = 1 << 0
+	AnimBitXOR      = 1 << 1
+)
+
+// AnimJiffy is the unit of the times of the ANHD chunk.
+const AnimJiffy = time.Second / 60
+
+// AnimHeader is the content of an ANHD chunk.
+type AnimHeader struct {
+	Operation     uint8
+	Mask          uint8
+	Width, Height uint16
+	X, Y          int16
+	AbsTime       uint32
+	RelTime       uint32 // in jiffies
+	Interleave    uint8  // 0 means 2, i.e. double buffering
+	Bits          uint32
+}
+
+// AnimFrame is a reconstructed frame of an animation.
+type AnimFrame struct {
+	Picture *ILBMPicture
+	Delay   time.Duration // time to show the frame
+}
+
+// Animation contains all frames of an ANIM FORM.
+type Animation struct {
+	Frames []AnimFrame
+}
+
+// ParseAnimHeader decodes the data of an ANHD chunk.
+func ParseAnimHeader(data []byte) (AnimHeader, error) {
+	var anhd AnimHeader
+	var offset uint32
+	var err error
+
+	if len(data) < 24 {
+		return anhd, fmt.Errorf("ANHD is too short")
+	}
+
+	// the length has been checked, so no errors are expected below
+	anhd.Operation, _ = getUbyte(data, &offset)
+	anhd.Mask, _ = getUbyte(data, &offset)
+	anhd.Width, _ = getBeUword(data, &offset)
+	anhd.Height, _ = getBeUword(data, &offset)
+	anhd.X, _ = getBeWord(data, &offset)
+	anhd.Y, _ = getBeWord(data, &offset)
+	anhd.AbsTime, _ = getBeUlong(data, &offset)
+	anhd.RelTime, _ = getBeUlong(data, &offset)
+	anhd.Interleave, _ = getUbyte(data, &offset)
+	offset++ // ignore pad0
+	anhd.Bits, err = getBeUlong(data, &offset)
+
+	return anhd, err
+}
+
+// IsAnimForm returns true for FORMs which can be decoded by DecodeANIM.
+func IsAnimForm(form *IFFChunk) bool {
+	return form != nil && form.ID == "FORM" && form.SubID == "ANIM"
+}
+
+// DecodeANIM reconstructs all frames of an ANIM FORM. The first ILBM
+// FORM contains the full picture, each following FORM a DLTA chunk
+// which is applied to the frame shown Interleave frames before.
+// In case of an error, the function returns nil and the error. If a
+// later frame is broken, the frames decoded so far are returned together
+// with the error.
+func DecodeANIM(form *IFFChunk) (*Animation, error) {
+	var anim Animation
+
+	if !IsAnimForm(form) {
+		return nil, fmt.Errorf("not an ANIM FORM")
+	}
+
+	var frames []*IFFChunk
+	for _, child := range form.Childs {
+		if child.ID == "FORM" && child.SubID == "ILBM" {
+			frames = append(frames, child)
+		}
+	}
+	if len(frames) == 0 {
+		return nil, fmt.Errorf("ANIM contains no ILBM FORM")
+	}
+
+	first, err := DecodeILBMPicture(frames[0])
+	if first == nil {
+		return nil, err
+	}
+	if err != nil {
+		return &Animation{Frames: []AnimFrame{{first, frameDelay(frames[0])}}}, err
+	}
+	anim.Frames = append(anim.Frames, AnimFrame{first, frameDelay(frames[0])})
+
+	for i, frame := range frames[1:] {
+		pic, err := decodeAnimFrame(frame, &anim, i+1)
+		if err != nil {
+			return &anim, fmt.Errorf("frame %d: %w", i+1, err)
+		}
+		anim.Frames = append(anim.Frames, AnimFrame{pic, frameDelay(frame)})
+	}
+
+	return &anim, nil
+}
+
+// frameDelay returns the time to show the frame from the reltime of its
+// ANHD chunk. The first frame has no ANHD, so the default is one jiffy.
+func frameDelay(frame *IFFChunk) time.Duration {
+	if chunk := findChild(frame, "ANHD"); chunk != nil {
+		if anhd, err := ParseAnimHeader(chunk.Data); err == nil && anhd.RelTime > 0 {
+			return time.Duration(anhd.RelTime) * AnimJiffy
+		}
+	}
+	return AnimJiffy
+}
+
+// decodeAnimFrame reconstructs frame number index of the animation from
+// the frames decoded before.
+func decodeAnimFrame(frame *IFFChunk, anim *Animation, index int) (*ILBMPicture, error) {
+	prev := anim.Frames[index-1].Picture
+	pic := *prev
+
+	// a CMAP changes the palette from this frame on
+	if cmap := findChild(frame, "CMAP"); cmap != nil {
+		pic.Palette = ParseColorMap(cmap.Data)
+	}
+
+	anhdChunk := findChild(frame, "ANHD")
+	if anhdChunk == nil {
+		return nil, fmt.Errorf("ANHD chunk is missing")
+	}
+	anhd, err := ParseAnimHeader(anhdChunk.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	if anhd.Operation == AnimOpDirect {
+		body := findChild(frame, "BODY")
+		if body == nil {
+			return nil, fmt.Errorf("BODY chunk is missing")
+		}
+		if bmhd := findChild(frame, "BMHD"); bmhd != nil {
+			if pic.Header, err = ParseBitmapHeader(bmhd.Data); err != nil {
+				return nil, err
+			}
+		}
+		pic.Bitmap, pic.Mask, err = decodeILBMBody(&pic.Header, body.Data)
+		if pic.Bitmap == nil {
+			return nil, err
+		}
+		return &pic, err
+	}
+
+	// the delta refers to the frame shown interleave frames before
+	interleave := int(anhd.Interleave)
+	if interleave == 0 {
+		interleave = 2
+	}
+	base := anim.Frames[max(index-interleave, 0)].Picture
+	pic.Bitmap = base.Bitmap.clone()
+
+	dlta := findChild(frame, "DLTA")
+	if dlta == nil {
+		return nil, fmt.Errorf("DLTA chunk is missing")
+	}
+	xor := anhd.Bits&AnimBitXOR != 0
+
+	switch anhd.Operation {
+	case AnimOpByteVertical:
+		err = applyByteVerticalDelta(pic.Bitmap, dlta.Data, xor)
+	case AnimOpShortLongVertical:
+		err = applyVerticalDelta(pic.Bitmap, dlta.Data, anhd.Bits&AnimBitLongData != 0, xor, false)
+	case AnimOpShortLongVerticalOps:
+		err = applyVerticalDelta(pic.Bitmap, dlta.Data, anhd.Bits&AnimBitLongData != 0, xor, true)
+	case AnimOpGraham:
+		err = applyGrahamDelta(pic.Bitmap, dlta.Data)
+	default:
+		return nil, fmt.Errorf("unsupported ANIM operation: %d", anhd.Operation)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &pic, nil
+}
+
+// clone returns a deep copy of the bitmap.
+func (bm *Bitmap) clone() *Bitmap {
+	dup := *bm
+	dup.Planes = make([][]byte, len(bm.Planes))
+	for i, plane := range bm.Planes {
+		dup.Planes[i] = append([]byte(nil), plane...)
+	}
+	return &dup
+}
+
+// deltaPointer returns the offset of the i-th long word of the pointer
+// table at the start of a DLTA chunk.
+func deltaPointer(data []byte, i int) (int, error) {
+	if len(data) < (i+1)*4 {
+		return 0, fmt.Errorf("DLTA is too short")
+	}
+	ptr := int(binary.BigEndian.Uint32(data[i*4:]))
+	if ptr >= len(data) {
+		return 0, fmt.Errorf("DLTA pointer beyond the end of the chunk: %d", ptr)
+	}
+	return ptr, nil
+}
+
+// deltaReader reads the elements of a delta list.
+type deltaReader struct {
+	data []byte
+	pos  int
+}
+
+// next reads an element of the given size in bytes.
+func (r *deltaReader) next(size int) (uint32, error) {
+	if r.pos+size > len(r.data) {
+		return 0, fmt.Errorf("DLTA is too short")
+	}
+	var value uint32
+	for _, b := range r.data[r.pos : r.pos+size] {
+		value = value<<8 | uint32(b)
+	}
+	r.pos += size
+	return value, nil
+}
+
+// column writes elements of a byte column of a plane from top to bottom.
+type column struct {
+	plane []byte
+	pos   int // offset of the current element
+	pitch int // bytes per row
+	size  int // bytes per element
+	xor   bool
+}
+
+// write stores or XORs the value at the current element and moves to
+// the next row. Values below the bottom of the plane are dropped.
+func (c *column) write(value uint32) {
+	if c.pos+c.size <= len(c.plane) {
+		for i := c.size - 1; i >= 0; i-- {
+			if c.xor {
+				c.plane[c.pos+i] ^= byte(value)
+			} else {
+				c.plane[c.pos+i] = byte(value)
+			}
+			value >>= 8
+		}
+	}
+	c.pos += c.pitch
+}
+
+// applyByteVerticalDelta applies a DLTA chunk of operation 5. For each
+// plane a pointer leads to a list of opcodes per byte column: 0 is
+// followed by a count and a byte to repeat, values with bit 7 set are
+// followed by that many bytes to copy and all other values skip rows.
+func applyByteVerticalDelta(bm *Bitmap, data []byte, xor bool) error {
+	for p, plane := range bm.Planes[:min(len(bm.Planes), 8)] {
+		ptr, err := deltaPointer(data, p)
+		if err != nil {
+			return err
+		}
+		if ptr == 0 {
+			continue // plane is unchanged
+		}
+
+		ops := &deltaReader{data: data, pos: ptr}
+		for x := 0; x < bm.BytesPerRow; x++ {
+			col := column{plane: plane, pos: x, pitch: bm.BytesPerRow, size: 1, xor: xor}
+			if err := applyColumnOps(&col, ops, ops, 1, 1); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// applyVerticalDelta applies a DLTA chunk of operation 7 or 8, which
+// work like operation 5 on columns of words or long words. Operation 7
+// keeps byte opcodes and data in separate lists, operation 8 stores both
+// in one list with elements of the data size.
+func applyVerticalDelta(bm *Bitmap, data []byte, long bool, xor bool, sameSize bool) error {
+	size := 2
+	if long {
+		size = 4
+	}
+
+	for p, plane := range bm.Planes[:min(len(bm.Planes), 8)] {
+		ptr, err := deltaPointer(data, p)
+		if err != nil {
+			return err
+		}
+		if ptr == 0 {
+			continue
+		}
+
+		ops := &deltaReader{data: data, pos: ptr}
+		values := ops
+		opSize := size
+		if !sameSize {
+			dataPtr, err := deltaPointer(data, p+8)
+			if err != nil {
+				return err
+			}
+			values = &deltaReader{data: data, pos: dataPtr}
+			opSize = 1
+		}
+
+		for x := 0; x < bm.BytesPerRow; x += size {
+			// the last column of long data may only be a word wide
+			col := column{plane: plane, pos: x, pitch: bm.BytesPerRow,
+				size: min(size, bm.BytesPerRow-x), xor: xor}
+			if err := applyColumnOps(&col, ops, values, opSize, col.size); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// applyColumnOps applies the opcodes of one column. Opcodes and counts
+// have opSize bytes, the data elements dataSize bytes.
+func applyColumnOps(col *column, ops *deltaReader, values *deltaReader, opSize int, dataSize int) error {
+	uniqFlag := uint32(0x80) << (8 * (opSize - 1))
+
+	opCount, err := ops.next(opSize)
+	if err != nil {
+		return err
+	}
+	for ; opCount > 0; opCount-- {
+		op, err := ops.next(opSize)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case op == 0:
+			// same: repeat a value count times
+			count, err := ops.next(opSize)
+			if err != nil {
+				return err
+			}
+			value, err := values.next(dataSize)
+			if err != nil {
+				return err
+			}
+			for ; count > 0; count-- {
+				col.write(value)
+			}
+		case op&uniqFlag != 0:
+			// uniq: copy count values
+			for count := op &^ uniqFlag; count > 0; count-- {
+				value, err := values.next(dataSize)
+				if err != nil {
+					return err
+				}
+				col.write(value)
+			}
+		default:
+			// skip count rows
+			col.pos += int(op) * col.pitch
+		}
+	}
+	return nil
+}
+
+// applyGrahamDelta applies a DLTA chunk of operation J. It consists of
+// blocks which change either one byte of each plane in consecutive rows
+// (type 1) or a rectangle of bytes in all planes (type 2). The offsets
+// are relative to an interleaved bitmap with byte aligned rows.
+func applyGrahamDelta(bm *Bitmap, data []byte) error {
+	depth := len(bm.Planes)
+	rowBytes := (bm.Width + 7) / 8
+	r := &deltaReader{data: data}
+
+	// converts an offset of the delta into plane, offset within the plane
+	locate := func(offset uint32, row int, plane int) ([]byte, int) {
+		pos := int(offset)/rowBytes*bm.BytesPerRow + int(offset)%rowBytes + row*bm.BytesPerRow
+		return bm.Planes[plane], pos
+	}
+	put := func(plane []byte, pos int, value byte, xor bool) {
+		if pos >= 0 && pos < len(plane) {
+			if xor {
+				plane[pos] ^= value
+			} else {
+				plane[pos] = value
+			}
+		}
+	}
+
+	for r.pos+2 <= len(data) {
+		blockType, _ := r.next(2)
+		switch blockType {
+		case 0:
+			return nil
+		case 1:
+			// flag, cols, groups
+			header := make([]uint32, 3)
+			for i := range header {
+				var err error
+				if header[i], err = r.next(2); err != nil {
+					return err
+				}
+			}
+			xor, cols, groups := header[0] != 0, int(header[1]), int(header[2])
+			for g := 0; g < groups; g++ {
+				offset, err := r.next(2)
+				if err != nil {
+					return err
+				}
+				for row := 0; row < cols; row++ {
+					for p := 0; p < depth; p++ {
+						value, err := r.next(1)
+						if err != nil {
+							return err
+						}
+						plane, pos := locate(offset, row, p)
+						put(plane, pos, byte(value), xor)
+					}
+				}
+				r.pos += cols * depth % 2 // pad byte
+			}
+		case 2:
+			// flag, rows, bytes, groups
+			header := make([]uint32, 4)
+			for i := range header {
+				var err error
+				if header[i], err = r.next(2); err != nil {
+					return err
+				}
+			}
+			xor, rows, width, groups := header[0] != 0, int(header[1]), int(header[2]), int(header[3])
+			for g := 0; g < groups; g++ {
+				offset, err := r.next(2)
+				if err != nil {
+					return err
+				}
+				for row := 0; row < rows; row++ {
+					for p := 0; p < depth; p++ {
+						plane, pos := locate(offset, row, p)
+						for b := 0; b < width; b++ {
+							value, err := r.next(1)
+							if err != nil {
+								return err
+							}
+							put(plane, pos+b, byte(value), xor)
+						}
+					}
+				}
+				r.pos += rows * width * depth % 2 // pad byte
+			}
+		default:
+			return fmt.Errorf("unknown block type of operation J: %d", blockType)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,227 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"math/bits"
+	"slices"
+	"time"
+)
+
+// Limits of the opcodes of operation 5.
+const (
+	op5MaxSkip = 0x7F
+	op5MaxUniq = 0x7F
+	op5MaxSame = 0xFF
+	op5MaxOps  = 0xFF
+	op5MinSame = 3 // shorter runs are cheaper as uniq
+)
+
+// Sizes of the ANHD and DLTA chunks.
+const (
+	anhdSize     = 40
+	dltaPtrSize  = 16 * 4 // pointer table at the start of a DLTA chunk
+	dltaMaxPlane = 8      // planes with a pointer in the table
+)
+
+// EncodeANIM converts a sequence of equally sized paletted images into
+// an ANIM FORM, which can be saved with WriteIFFFile. The first image
+// becomes a full ILBM, all following images byte vertical deltas
+// (operation 5) for double buffering, i.e. each delta is relative to the
+// image two frames before. Every frame is shown for the given delay.
+// Frames whose palette differs from the frame before get a CMAP chunk.
+// The options are used for the first ILBM, a mask plane isn't supported.
+func EncodeANIM(images []*image.Paletted, delay time.Duration, opts ILBMEncodeOptions) (*IFFChunk, error) {
+	if len(images) == 0 {
+		return nil, fmt.Errorf("animation has no frames")
+	}
+	if opts.Masking == MaskHasMask {
+		return nil, fmt.Errorf("ANIM deltas don't support a mask plane")
+	}
+
+	// all frames use the same number of planes
+	size := images[0].Bounds().Size()
+	depth := opts.Planes
+	for i, img := range images {
+		if img.Bounds().Size() != size {
+			return nil, fmt.Errorf("frame %d: size %v differs from %v", i, img.Bounds().Size(), size)
+		}
+		if opts.Planes == 0 {
+			depth = max(depth, bits.Len(uint(len(img.Palette)-1)), 1)
+		} else if len(img.Palette) > 1<<opts.Planes {
+			return nil, fmt.Errorf("frame %d: %d colors don't fit into %d planes",
+				i, len(img.Palette), opts.Planes)
+		}
+	}
+	if depth > dltaMaxPlane {
+		return nil, fmt.Errorf("unsupported number of planes: %d", depth)
+	}
+	opts.Planes = depth
+
+	reltime := uint32(max((delay+AnimJiffy/2)/AnimJiffy, 1))
+	anhd := AnimHeader{
+		Width:   uint16(size.X),
+		Height:  uint16(size.Y),
+		RelTime: reltime,
+	}
+
+	first, err := EncodeILBM(images[0], opts)
+	if err != nil {
+		return nil, err
+	}
+	first.Childs = slices.Insert(first.Childs, 1, &IFFChunk{ID: "ANHD", Data: encodeAnimHeader(&anhd)})
+	anim := &IFFChunk{ID: "FORM", SubID: "ANIM", Childs: []*IFFChunk{first}}
+
+	// both buffers start with the first frame
+	bitmaps := []*Bitmap{bitmapFromPaletted(images[0], depth)}
+	anhd.Operation = AnimOpByteVertical
+	for i, img := range images[1:] {
+		bm := bitmapFromPaletted(img, depth)
+		base := bitmaps[max(i-1, 0)]
+		dlta, err := encodeByteVerticalDelta(base, bm)
+		if err != nil {
+			return nil, fmt.Errorf("frame %d: %w", i+1, err)
+		}
+		bitmaps = append(bitmaps, bm)
+
+		anhd.AbsTime += reltime
+		frame := &IFFChunk{ID: "FORM", SubID: "ILBM"}
+		frame.Childs = append(frame.Childs, &IFFChunk{ID: "ANHD", Data: encodeAnimHeader(&anhd)})
+		if !samePalette(img.Palette, images[i].Palette) {
+			frame.Childs = append(frame.Childs, &IFFChunk{ID: "CMAP", Data: encodeColorMap(img.Palette)})
+		}
+		frame.Childs = append(frame.Childs, &IFFChunk{ID: "DLTA", Data: dlta})
+		anim.Childs = append(anim.Childs, frame)
+	}
+
+	return anim, nil
+}
+
+// samePalette returns true if both palettes contain the same colors.
+func samePalette(a color.Palette, b color.Palette) bool {
+	return slices.EqualFunc(a, b, func(c1 color.Color, c2 color.Color) bool {
+		r1, g1, b1, a1 := c1.RGBA()
+		r2, g2, b2, a2 := c2.RGBA()
+		return r1 == r2 && g1 == g2 && b1 == b2 && a1 == a2
+	})
+}
+
+// encodeAnimHeader returns the data of an ANHD chunk.
+func encodeAnimHeader(anhd *AnimHeader) []byte {
+	data := make([]byte, 0, anhdSize)
+	data = append(data, anhd.Operation, anhd.Mask)
+	data = binary.BigEndian.AppendUint16(data, anhd.Width)
+	data = binary.BigEndian.AppendUint16(data, anhd.Height)
+	data = binary.BigEndian.AppendUint16(data, uint16(anhd.X))
+	data = binary.BigEndian.AppendUint16(data, uint16(anhd.Y))
+	data = binary.BigEndian.AppendUint32(data, anhd.AbsTime)
+	data = binary.BigEndian.AppendUint32(data, anhd.RelTime)
+	data = append(data, anhd.Interleave, 0)
+	data = binary.BigEndian.AppendUint32(data, anhd.Bits)
+	return append(data, make([]byte, anhdSize-len(data))...) // pad
+}
+
+// encodeByteVerticalDelta returns the data of a DLTA chunk of operation 5
+// which changes the base bitmap into the bitmap. Unchanged planes get a
+// null pointer.
+func encodeByteVerticalDelta(base *Bitmap, bm *Bitmap) ([]byte, error) {
+	data := make([]byte, dltaPtrSize)
+
+	for p, plane := range bm.Planes {
+		if slices.Equal(plane, base.Planes[p]) {
+			continue
+		}
+		binary.BigEndian.PutUint32(data[p*4:], uint32(len(data)))
+
+		for x := 0; x < bm.BytesPerRow; x++ {
+			cur := make([]byte, bm.Height)
+			old := make([]byte, bm.Height)
+			for y := range cur {
+				cur[y] = plane[y*bm.BytesPerRow+x]
+				old[y] = base.Planes[p][y*bm.BytesPerRow+x]
+			}
+
+			count, ops := encodeOp5Column(cur, old)
+			if count > op5MaxOps {
+				// fall back to copying the whole column
+				count, ops = encodeOp5Column(cur, make([]byte, 0))
+				if count > op5MaxOps {
+					return nil, fmt.Errorf("too many changes in column %d of plane %d", x, p)
+				}
+			}
+			data = append(data, byte(count))
+			data = append(data, ops...)
+		}
+		if len(data)%2 != 0 {
+			data = append(data, 0) // lists start at even offsets
+		}
+	}
+
+	return data, nil
+}
+
+// encodeOp5Column returns the number of opcodes and the opcodes which
+// change the old column into cur. If old is shorter than cur, the rows
+// beyond it count as changed. Unchanged rows at the end are omitted.
+func encodeOp5Column(cur []byte, old []byte) (int, []byte) {
+	var ops []byte
+	count := 0
+
+	unchanged := func(y int) bool {
+		return y < len(old) && cur[y] == old[y]
+	}
+	runLength := func(y int, limit int) int {
+		n := 1
+		for y+n < len(cur) && n < limit && cur[y+n] == cur[y] {
+			n++
+		}
+		return n
+	}
+
+	y := 0
+	for y < len(cur) {
+		// skip unchanged rows
+		skip := 0
+		for y+skip < len(cur) && skip < op5MaxSkip && unchanged(y+skip) {
+			skip++
+		}
+		if skip > 0 {
+			if y+skip == len(cur) {
+				break
+			}
+			ops = append(ops, byte(skip))
+			count++
+			y += skip
+			continue
+		}
+
+		// repeat the same byte
+		if n := runLength(y, op5MaxSame); n >= op5MinSame {
+			ops = append(ops, 0, byte(n), cur[y])
+			count++
+			y += n
+			continue
+		}
+
+		// copy bytes until the next run or two unchanged rows, single
+		// unchanged rows are cheaper to copy than to skip
+		start := y
+		for y < len(cur) && y-start < op5MaxUniq {
+			if y > start && (runLength(y, op5MinSame) >= op5MinSame ||
+				(unchanged(y) && (y+1 == len(cur) || unchanged(y+1)))) {
+				break
+			}
+			y++
+		}
+		ops = append(ops, 0x80|byte(y-start))
+		ops = append(ops, cur[start:y]...)
+		count++
+	}
+
+	return count, ops
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"testing"
+	"time"
+)
+
+func TestEncodeOp5Column(t *testing.T) {
+	var tests = []struct {
+		name      string
+		cur       []byte
+		old       []byte
+		wantCount int
+		wantOps   []byte
+	}{
+		{"Unchanged", []byte{1, 2, 3}, []byte{1, 2, 3}, 0, nil},
+		{"Skip", []byte{1, 2, 9}, []byte{1, 2, 3}, 2, []byte{2, 0x81, 9}},
+		{"Same", []byte{7, 7, 7, 7}, []byte{0, 0, 0, 0}, 1, []byte{0, 4, 7}},
+		{"UniqOverSingleRow", []byte{5, 2, 6}, []byte{0, 2, 0}, 1, []byte{0x83, 5, 2, 6}},
+		{"UniqThenRun", []byte{5, 7, 7, 7}, []byte{0, 0, 0, 0}, 2, []byte{0x81, 5, 0, 3, 7}},
+		{"TrailingSkip", []byte{5, 1, 1}, []byte{0, 1, 1}, 1, []byte{0x81, 5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count, ops := encodeOp5Column(tt.cur, tt.old)
+			if count != tt.wantCount || !bytes.Equal(ops, tt.wantOps) {
+				t.Errorf("got %d % X, want %d % X", count, ops, tt.wantCount, tt.wantOps)
+			}
+		})
+	}
+}
+
+// makeTestFrames returns a sequence of paletted images with a moving bar.
+// The palette changes in the last frame.
+func makeTestFrames(count int, width int, height int) []*image.Paletted {
+	var frames []*image.Paletted
+	palette := color.Palette{
+		color.NRGBA{0, 0, 0, 0xFF}, color.NRGBA{0xFF, 0, 0, 0xFF},
+		color.NRGBA{0, 0xFF, 0, 0xFF}, color.NRGBA{0, 0, 0xFF, 0xFF},
+	}
+	for i := 0; i < count; i++ {
+		if i == count-1 {
+			palette = append(color.Palette{color.NRGBA{0xFF, 0xFF, 0xFF, 0xFF}}, palette[1:]...)
+		}
+		img := image.NewPaletted(image.Rect(0, 0, width, height), palette)
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				if (x+i*3)%width < 8 {
+					img.SetColorIndex(x, y, uint8(1+y%3))
+				}
+			}
+		}
+		frames = append(frames, img)
+	}
+	return frames
+}
+
+func TestEncodeANIMRoundTrip(t *testing.T) {
+	frames := makeTestFrames(6, 40, 20)
+	form, err := EncodeANIM(frames, 50*time.Millisecond, ILBMEncodeOptions{Compress: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteIFFFile(&buf, form); err != nil {
+		t.Fatal(err)
+	}
+	root, err := ReadIFFFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	anim, err := DecodeANIM(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(anim.Frames) != len(frames) {
+		t.Fatalf("Frames: got %d, want %d", len(anim.Frames), len(frames))
+	}
+
+	for i, frame := range anim.Frames {
+		if frame.Delay != 3*AnimJiffy {
+			t.Errorf("Frame %d delay: got %v, want %v", i, frame.Delay, 3*AnimJiffy)
+		}
+		img := frame.Picture.Image()
+		for y := 0; y < 20; y++ {
+			for x := 0; x < 40; x++ {
+				got := color.NRGBAModel.Convert(img.At(x, y))
+				want := color.NRGBAModel.Convert(frames[i].At(x, y))
+				if got != want {
+					t.Fatalf("Frame %d pixel %d,%d: got %v, want %v", i, x, y, got, want)
+				}
+			}
+		}
+	}
+
+	// only the last frame changes the palette
+	for i, child := range root.Childs[1:] {
+		hasCMAP := findChild(child, "CMAP") != nil
+		if hasCMAP != (i == len(frames)-2) {
+			t.Errorf("Frame %d: got CMAP %t", i+1, hasCMAP)
+		}
+	}
+}
+
+func TestEncodeANIMErrors(t *testing.T) {
+	frames := makeTestFrames(2, 16, 4)
+	frames[1] = image.NewPaletted(image.Rect(0, 0, 8, 4), frames[1].Palette)
+	if _, err := EncodeANIM(frames, time.Second, ILBMEncodeOptions{}); err == nil {
+		t.Errorf("Size: got no error, want error")
+	}
+	if _, err := EncodeANIM(nil, time.Second, ILBMEncodeOptions{}); err == nil {
+		t.Errorf("Empty: got no error, want error")
+	}
+	if _, err := EncodeANIM(frames[:1], time.Second, ILBMEncodeOptions{Planes: 1}); err == nil {
+		t.Errorf("Planes: got no error, want error")
+	}
+}
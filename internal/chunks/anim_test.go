@@ -0,0 +1,195 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image/gif"
+	"slices"
+	"testing"
+	"time"
+)
+
+// makeAnimHeader returns the data of an ANHD chunk.
+func makeAnimHeader(operation uint8, interleave uint8, bits uint32, reltime uint32) []byte {
+	data := make([]byte, 40)
+	data[0] = operation
+	binary.BigEndian.PutUint32(data[14:], reltime)
+	data[18] = interleave
+	binary.BigEndian.PutUint32(data[20:], bits)
+	return data
+}
+
+// makeDelta returns the data of a DLTA chunk with a pointer table of 16
+// long words. The lists are appended in the given order, their pointers
+// are stored at the given indices of the table.
+func makeDelta(lists map[int][]byte) []byte {
+	data := make([]byte, 64)
+	for i := 0; i < 16; i++ {
+		if list, exists := lists[i]; exists {
+			binary.BigEndian.PutUint32(data[i*4:], uint32(len(data)))
+			data = append(data, list...)
+		}
+	}
+	return data
+}
+
+func TestApplyDelta(t *testing.T) {
+	var tests = []struct {
+		name   string
+		width  int
+		height int
+		depth  int
+		fill   byte
+		apply  func(bm *Bitmap) error
+		want   [][]byte
+	}{
+		{"Op5", 16, 4, 1, 0, func(bm *Bitmap) error {
+			delta := makeDelta(map[int][]byte{0: {2, 0x82, 0xAA, 0xBB, 0, 2, 0xCC, 1, 3}})
+			return applyByteVerticalDelta(bm, delta, false)
+		}, [][]byte{{0xAA, 0, 0xBB, 0, 0xCC, 0, 0xCC, 0}}},
+		{"Op5XOR", 16, 2, 1, 0xFF, func(bm *Bitmap) error {
+			delta := makeDelta(map[int][]byte{0: {1, 0x81, 0xAA, 0}})
+			return applyByteVerticalDelta(bm, delta, true)
+		}, [][]byte{{0x55, 0xFF, 0xFF, 0xFF}}},
+		{"Op7Short", 32, 2, 1, 0, func(bm *Bitmap) error {
+			delta := makeDelta(map[int][]byte{
+				0: {1, 0x81, 1, 0, 2, 0},
+				8: {0x12, 0x34, 0xAB, 0xCD},
+			})
+			return applyVerticalDelta(bm, delta, false, false, false)
+		}, [][]byte{{0x12, 0x34, 0xAB, 0xCD, 0, 0, 0xAB, 0xCD}}},
+		{"Op7Long", 48, 1, 1, 0, func(bm *Bitmap) error {
+			// the last column is only a word wide
+			delta := makeDelta(map[int][]byte{
+				0: {1, 0x81, 1, 0x81},
+				8: {0x11, 0x22, 0x33, 0x44, 0x55, 0x66},
+			})
+			return applyVerticalDelta(bm, delta, true, false, false)
+		}, [][]byte{{0x11, 0x22, 0x33, 0x44, 0x55, 0x66}}},
+		{"Op8Short", 32, 2, 1, 0, func(bm *Bitmap) error {
+			delta := makeDelta(map[int][]byte{0: {
+				0, 1, 0x80, 1, 0x12, 0x34,
+				0, 1, 0, 0, 0, 2, 0xAB, 0xCD,
+			}})
+			return applyVerticalDelta(bm, delta, false, false, true)
+		}, [][]byte{{0x12, 0x34, 0xAB, 0xCD, 0, 0, 0xAB, 0xCD}}},
+		{"OpJ", 16, 2, 2, 0, func(bm *Bitmap) error {
+			delta := []byte{
+				0, 1, 0, 0, 0, 2, 0, 1, 0, 1, 0x11, 0x22, 0x33, 0x44,
+				0, 2, 0, 1, 0, 1, 0, 1, 0, 1, 0, 0, 0xF0, 0x0F,
+				0, 0,
+			}
+			return applyGrahamDelta(bm, delta)
+		}, [][]byte{{0xF0, 0x11, 0, 0x33}, {0x0F, 0x22, 0, 0x44}}},
+		{"Truncated", 16, 2, 1, 0, func(bm *Bitmap) error {
+			delta := makeDelta(map[int][]byte{0: {1, 0x82, 0xAA}})
+			if applyByteVerticalDelta(bm, delta, false) == nil {
+				t.Errorf("Truncated: got no error, want error")
+			}
+			return nil
+		}, [][]byte{{0xAA, 0, 0, 0}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bm := NewBitmap(tt.width, tt.height, tt.depth)
+			for _, plane := range bm.Planes {
+				for i := range plane {
+					plane[i] = tt.fill
+				}
+			}
+			if err := tt.apply(bm); err != nil {
+				t.Fatal(err)
+			}
+			for p, want := range tt.want {
+				if !bytes.Equal(bm.Planes[p], want) {
+					t.Errorf("Plane %d: got % X, want % X", p, bm.Planes[p], want)
+				}
+			}
+		})
+	}
+}
+
+// makeTestANIM returns an ANIM with a 16x1 picture and two frames with
+// operation 5 which set the first and the second byte of the row.
+func makeTestANIM() *IFFChunk {
+	first := makeTestILBM([][]uint32{make([]uint32, 16)}, 1,
+		&IFFChunk{ID: "CMAP", Data: []byte{0, 0, 0, 0xFF, 0xFF, 0xFF}})
+	anim := &IFFChunk{ID: "FORM", SubID: "ANIM", Childs: []*IFFChunk{first}}
+	for i := 0; i < 2; i++ {
+		ops := []byte{0, 0}
+		ops[i] = 1
+		ops = slices.Insert(ops, i+1, 0x81, 0xFF)
+		anim.Childs = append(anim.Childs, &IFFChunk{ID: "FORM", SubID: "ILBM", Childs: []*IFFChunk{
+			{ID: "ANHD", Data: makeAnimHeader(AnimOpByteVertical, 0, 0, uint32(i+2))},
+			{ID: "DLTA", Data: makeDelta(map[int][]byte{0: ops})},
+		}})
+	}
+	return anim
+}
+
+func TestDecodeANIM(t *testing.T) {
+	anim, err := DecodeANIM(makeTestANIM())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(anim.Frames) != 3 {
+		t.Fatalf("Frames: got %d, want 3", len(anim.Frames))
+	}
+
+	// with double buffering both deltas refer to the first frame
+	want := [][]byte{{0, 0}, {0xFF, 0}, {0, 0xFF}}
+	for i, frame := range anim.Frames {
+		if got := frame.Picture.Bitmap.Planes[0]; !bytes.Equal(got, want[i]) {
+			t.Errorf("Frame %d: got % X, want % X", i, got, want[i])
+		}
+	}
+	if got := anim.Frames[2].Delay; got != 3*AnimJiffy {
+		t.Errorf("Delay: got %v, want %v", got, 3*AnimJiffy)
+	}
+
+	// interleave 1 applies the delta to the previous frame
+	form := makeTestANIM()
+	form.Childs[2].Childs[0].Data = makeAnimHeader(AnimOpByteVertical, 1, 0, 1)
+	if anim, err = DecodeANIM(form); err != nil {
+		t.Fatal(err)
+	}
+	if got := anim.Frames[2].Picture.Bitmap.Planes[0]; !bytes.Equal(got, []byte{0xFF, 0xFF}) {
+		t.Errorf("Interleave 1: got % X", got)
+	}
+
+	// an unsupported operation stops the decoding
+	form.Childs[2].Childs[0].Data = makeAnimHeader(AnimOpLongDelta, 0, 0, 1)
+	anim, err = DecodeANIM(form)
+	if err == nil || anim == nil || len(anim.Frames) != 2 {
+		t.Errorf("Unsupported: got %v, want 2 frames and error", err)
+	}
+}
+
+func TestEncodeGIF(t *testing.T) {
+	anim, err := DecodeANIM(makeTestANIM())
+	if err != nil {
+		t.Fatal(err)
+	}
+	anim.Frames[0].Delay = time.Second
+
+	var buf bytes.Buffer
+	if err := EncodeGIF(&buf, anim); err != nil {
+		t.Fatal(err)
+	}
+	g, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Image) != 3 {
+		t.Fatalf("Frames: got %d, want 3", len(g.Image))
+	}
+	if want := []int{100, 3, 5}; !slices.Equal(g.Delay, want) {
+		t.Errorf("Delay: got %v, want %v", g.Delay, want)
+	}
+	if got := g.Image[1].ColorIndexAt(0, 0); got != 1 {
+		t.Errorf("Frame 1 pixel: got %d, want 1", got)
+	}
+}
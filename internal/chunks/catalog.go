@@ -0,0 +1,181 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
+)
+
+// CodeSetUTF8 is the IANA MIBenum of UTF-8 as used in the CSET chunk.
+const CodeSetUTF8 = 106
+
+// codeSets maps the IANA MIBenum of the CSET chunk of catalogs to the
+// name and the decoder of the character set. 0 is the default of
+// locale.library, which is ISO-8859-1.
+var codeSets = map[uint32]struct {
+	name     string
+	encoding encoding.Encoding
+}{
+	0:    {"Default (ISO-8859-1)", charmap.ISO8859_1},
+	3:    {"US-ASCII", charmap.ISO8859_1},
+	4:    {"ISO-8859-1", charmap.ISO8859_1},
+	5:    {"ISO-8859-2", charmap.ISO8859_2},
+	6:    {"ISO-8859-3", charmap.ISO8859_3},
+	7:    {"ISO-8859-4", charmap.ISO8859_4},
+	8:    {"ISO-8859-5", charmap.ISO8859_5},
+	9:    {"ISO-8859-6", charmap.ISO8859_6},
+	10:   {"ISO-8859-7", charmap.ISO8859_7},
+	11:   {"ISO-8859-8", charmap.ISO8859_8},
+	12:   {"ISO-8859-9", charmap.ISO8859_9},
+	13:   {"ISO-8859-10", charmap.ISO8859_10},
+	106:  {"UTF-8", unicode.UTF8},
+	109:  {"ISO-8859-13", charmap.ISO8859_13},
+	110:  {"ISO-8859-14", charmap.ISO8859_14},
+	111:  {"ISO-8859-15", charmap.ISO8859_15},
+	112:  {"ISO-8859-16", charmap.ISO8859_16},
+	2084: {"KOI8-R", charmap.KOI8R},
+	2250: {"Windows-1250", charmap.Windows1250},
+	2251: {"Windows-1251", charmap.Windows1251},
+	2252: {"Windows-1252", charmap.Windows1252},
+}
+
+// CatalogString is an entry of the STRS chunk of a catalog.
+type CatalogString struct {
+	ID     uint32
+	Length uint32 // number of bytes in the chunk without padding
+	Text   string // decoded text without trailing zero bytes
+}
+
+// Catalog contains the decoded data of a CTLG FORM.
+type Catalog struct {
+	Version  string // text of the FVER chunk
+	Language string // name of the language of the LANG chunk
+	CodeSet  uint32 // IANA MIBenum of the character set of the CSET chunk
+	Strings  []CatalogString
+	Chunks   []*IFFChunk // other chunks, e.g. AUTH
+}
+
+// CodeSetName returns the name of the character set with the given
+// IANA MIBenum.
+func CodeSetName(codeSet uint32) string {
+	if cs, exists := codeSets[codeSet]; exists {
+		return cs.name
+	}
+	return fmt.Sprintf("Unknown (%d)", codeSet)
+}
+
+// catalogEncoding returns the encoding of the character set with the
+// given IANA MIBenum. Unknown character sets are treated as ISO-8859-1.
+func catalogEncoding(codeSet uint32) encoding.Encoding {
+	if cs, exists := codeSets[codeSet]; exists {
+		return cs.encoding
+	}
+	return charmap.ISO8859_1
+}
+
+// ParseCatalogCodeSet decodes the character set of a CSET chunk of a
+// catalog.
+func ParseCatalogCodeSet(data []byte) (uint32, error) {
+	var offset uint32
+
+	//typedef struct {
+	//	ULONG CodeSet;
+	//	ULONG Reserved[7];
+	//} CSETChunk;
+
+	return getBeUlong(data, &offset)
+}
+
+// ParseCatalogStrings decodes the entries of a STRS chunk. Each entry
+// consists of the ID, the length and the text, which is padded to a
+// multiple of 4 bytes. The texts are decoded with the given character
+// set, unknown character sets are treated as ISO-8859-1.
+// In case of an error, the entries decoded so far are returned together
+// with the error.
+func ParseCatalogStrings(data []byte, codeSet uint32) ([]CatalogString, error) {
+	var offset uint32
+	var result []CatalogString
+
+	decoder := catalogEncoding(codeSet).NewDecoder()
+
+	for int(offset) < len(data) {
+		var entry CatalogString
+		var err error
+
+		if entry.ID, err = getBeUlong(data, &offset); err != nil {
+			return result, err
+		}
+		if entry.Length, err = getBeUlong(data, &offset); err != nil {
+			return result, err
+		}
+		if uint64(offset)+uint64(entry.Length) > uint64(len(data)) {
+			return result, fmt.Errorf("string %d is longer than the chunk", entry.ID)
+		}
+		text := bytes.TrimRight(data[offset:offset+entry.Length], "\x00")
+		decoded, err := decoder.Bytes(text)
+		if err != nil {
+			return result, fmt.Errorf("string %d: %w", entry.ID, err)
+		}
+		entry.Text = string(decoded)
+		result = append(result, entry)
+
+		offset += (entry.Length + 3) &^ 3 // padding
+	}
+
+	return result, nil
+}
+
+// IsCatalogForm returns true for FORMs which can be decoded by
+// DecodeCatalog.
+func IsCatalogForm(form *IFFChunk) bool {
+	return form != nil && form.ID == "FORM" && form.SubID == "CTLG"
+}
+
+// DecodeCatalog decodes FVER, LANG, CSET and STRS of a CTLG FORM. All
+// other chunks are kept in Chunks.
+// In case of an error, the function returns nil and the error. If only
+// the STRS chunk is broken, the catalog with the strings decoded so far
+// is returned together with the error.
+func DecodeCatalog(form *IFFChunk) (*Catalog, error) {
+	var ctlg Catalog
+	var err error
+
+	if !IsCatalogForm(form) {
+		return nil, fmt.Errorf("not a CTLG FORM")
+	}
+
+	for _, child := range form.Childs {
+		switch child.ID {
+		case "FVER":
+			ctlg.Version = cString(child.Data)
+		case "LANG":
+			ctlg.Language = cString(child.Data)
+		case "CSET", "STRS":
+		default:
+			ctlg.Chunks = append(ctlg.Chunks, child)
+		}
+	}
+	if cset := findChild(form, "CSET"); cset != nil {
+		if ctlg.CodeSet, err = ParseCatalogCodeSet(cset.Data); err != nil {
+			return nil, err
+		}
+	}
+	if strs := findChild(form, "STRS"); strs != nil {
+		ctlg.Strings, err = ParseCatalogStrings(strs.Data, ctlg.CodeSet)
+	}
+
+	return &ctlg, err
+}
+
+// cString returns the text up to the first zero byte.
+func cString(data []byte) string {
+	text, _, _ := strings.Cut(string(data), "\x00")
+	return text
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"testing"
+)
+
+func TestDecodeCatalog(t *testing.T) {
+	var tests = []struct {
+		name     string
+		language string
+		codeSet  uint32
+		count    int
+		first    CatalogString
+	}{
+		{"KeyShow.catalog", "deutsch", 0, 16,
+			CatalogString{0, 23, "Zeige Tastaturanordnung"}},
+		{"TextEditor_mcp.catalog", "deutsch", 111, 144,
+			CatalogString{2, 35, "Pixelgenaues Scrolling ermöglichen."}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, root := readTestIFF(t, tt.name)
+			ctlg, err := DecodeCatalog(root)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ctlg.Language != tt.language {
+				t.Errorf("Language: got %q, want %q", ctlg.Language, tt.language)
+			}
+			if ctlg.CodeSet != tt.codeSet {
+				t.Errorf("CodeSet: got %d, want %d", ctlg.CodeSet, tt.codeSet)
+			}
+			if len(ctlg.Strings) != tt.count {
+				t.Fatalf("Strings: got %d, want %d", len(ctlg.Strings), tt.count)
+			}
+			for _, entry := range ctlg.Strings {
+				if entry.ID == tt.first.ID && entry != tt.first {
+					t.Errorf("String %d: got %+v, want %+v", entry.ID, entry, tt.first)
+				}
+			}
+		})
+	}
+}
+
+func TestParseCatalogStrings(t *testing.T) {
+	data := []byte{
+		0, 0, 0, 7, 0, 0, 0, 3, 'a', 'b', 0, 0, // padded to 4 bytes
+		0, 0, 0, 9, 0, 0, 0, 4, 0xE4, 'x', 'y', 'z',
+		0, 0, 0, 1, 0, 0, 0, 9, 'b', 'r', 'o', // broken
+	}
+	want := []CatalogString{{7, 3, "ab"}, {9, 4, "äxyz"}}
+
+	got, err := ParseCatalogStrings(data, 4)
+	if err == nil {
+		t.Errorf("got no error, want error")
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Strings: got %d, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("String %d: got %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestHandleCtlg(t *testing.T) {
+	cset := make([]byte, 32)
+	cset[3] = 111
+	_, result, err := GetStructData("CTLG.CSET", cset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result) != 1 || result[0].Value != "111 (ISO-8859-15)" {
+		t.Errorf("CSET: got %v, want 111 (ISO-8859-15)", result)
+	}
+
+	if got := CodeSetName(4242); got != "Unknown (4242)" {
+		t.Errorf("CodeSetName: got %q, want %q", got, "Unknown (4242)")
+	}
+}
@@ -0,0 +1,540 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+)
+
+// This file implements the text formats of CatComp and FlexCat:
+// catalog descriptions (.cd) define the names, IDs, length limits and
+// built-in strings of an application, catalog translations (.ct)
+// contain the translated strings for these names.
+
+// CatalogDescEntry is a string of a catalog description (.cd).
+type CatalogDescEntry struct {
+	Name   string
+	ID     uint32
+	MinLen int // minimal length in bytes
+	MaxLen int // maximal length in bytes, 0 for no limit
+	Text   string
+}
+
+// CatalogTranslation contains the data of a catalog translation (.ct).
+type CatalogTranslation struct {
+	Version  string // "## version", e.g. "$VER: app.catalog 1.0 (01.01.2025)"
+	Language string // "## language"
+	CodeSet  uint32 // "## codeset"
+	Chunks   []*IFFChunk
+	Strings  []CatalogTranslationEntry
+}
+
+// CatalogTranslationEntry is a translated string of a catalog
+// translation.
+type CatalogTranslationEntry struct {
+	Name string
+	Text string
+}
+
+// Kinds of problems found by CheckCatalog.
+const (
+	CatalogMissing  = "missing"
+	CatalogExtra    = "extra"
+	CatalogTooShort = "too short"
+	CatalogTooLong  = "too long"
+)
+
+// CatalogProblem is a difference between a catalog and its catalog
+// description.
+type CatalogProblem struct {
+	Kind    string // CatalogMissing, CatalogExtra, CatalogTooShort or CatalogTooLong
+	ID      uint32
+	Name    string // empty for extra strings
+	Message string
+}
+
+// String formats the problem as a single line.
+func (p CatalogProblem) String() string {
+	if p.Name == "" {
+		return fmt.Sprintf("%s string %d: %s", p.Kind, p.ID, p.Message)
+	}
+	return fmt.Sprintf("%s string %s (%d): %s", p.Kind, p.Name, p.ID, p.Message)
+}
+
+// cdLabel matches a string definition of a catalog description, e.g.
+// "MSG_HELLO (5/0/20)". Each part in the parentheses may be empty.
+var cdLabel = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*)\s*\(([^/]*)/([^/]*)/([^/)]*)\)\s*$`)
+
+// ParseCatalogDescription reads a catalog description (.cd). Strings
+// without ID get the ID of the string before plus 1. The texts are
+// decoded as ISO-8859-1.
+func ParseCatalogDescription(reader io.Reader) ([]CatalogDescEntry, error) {
+	var result []CatalogDescEntry
+
+	lines, err := readCatalogLines(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := charmap.ISO8859_1.NewDecoder()
+	names := make(map[string]bool)
+	nextID := uint32(0)
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if len(line) == 0 || line[0] == ';' || line[0] == '#' {
+			continue // comments and commands
+		}
+
+		match := cdLabel.FindSubmatch(line)
+		if match == nil {
+			return nil, fmt.Errorf("line %d: invalid string definition %q", i+1, line)
+		}
+		entry := CatalogDescEntry{Name: string(match[1]), ID: nextID}
+		if names[entry.Name] {
+			return nil, fmt.Errorf("line %d: string %s is defined twice", i+1, entry.Name)
+		}
+		names[entry.Name] = true
+
+		if id := strings.TrimSpace(string(match[2])); id != "" {
+			if entry.ID, err = parseCatalogNumber(id); err != nil {
+				return nil, fmt.Errorf("line %d: invalid ID %q", i+1, id)
+			}
+		}
+		limits := []*int{&entry.MinLen, &entry.MaxLen}
+		for j, limit := range limits {
+			text := strings.TrimSpace(string(match[3+j]))
+			if text == "" {
+				continue
+			}
+			value, err := strconv.Atoi(text)
+			if err != nil || value < 0 {
+				return nil, fmt.Errorf("line %d: invalid length %q", i+1, text)
+			}
+			*limit = value
+		}
+
+		text, next, err := catalogText(lines, i+1)
+		if err != nil {
+			return nil, err
+		}
+		if entry.Text, err = decoder.String(string(text)); err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+2, err)
+		}
+		i = next - 1
+
+		result = append(result, entry)
+		nextID = entry.ID + 1
+	}
+
+	return result, nil
+}
+
+// ParseCatalogTranslation reads a catalog translation (.ct). The texts
+// are decoded with the character set of "## codeset".
+func ParseCatalogTranslation(reader io.Reader) (*CatalogTranslation, error) {
+	var ct CatalogTranslation
+
+	lines, err := readCatalogLines(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool)
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if header, found := bytes.CutPrefix(line, []byte("##")); found {
+			if err := ct.parseHeader(string(bytes.TrimSpace(header))); err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+1, err)
+			}
+			continue
+		}
+		if len(bytes.TrimSpace(line)) == 0 || line[0] == ';' {
+			continue
+		}
+
+		// the name is followed by the translation
+		entry := CatalogTranslationEntry{Name: string(bytes.TrimSpace(line))}
+		if names[entry.Name] {
+			return nil, fmt.Errorf("line %d: string %s is translated twice", i+1, entry.Name)
+		}
+		names[entry.Name] = true
+
+		text, next, err := catalogText(lines, i+1)
+		if err != nil {
+			return nil, err
+		}
+		decoded, err := catalogEncoding(ct.CodeSet).NewDecoder().Bytes(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+2, err)
+		}
+		entry.Text = string(decoded)
+		i = next - 1
+
+		ct.Strings = append(ct.Strings, entry)
+	}
+
+	return &ct, nil
+}
+
+// parseHeader processes a "##" line of a catalog translation.
+func (ct *CatalogTranslation) parseHeader(header string) error {
+	keyword, value, _ := strings.Cut(header, " ")
+	value = strings.TrimSpace(value)
+
+	switch strings.ToLower(keyword) {
+	case "version":
+		ct.Version = value
+	case "language":
+		ct.Language = value
+	case "codeset":
+		codeSet, err := parseCatalogNumber(value)
+		if err != nil {
+			return fmt.Errorf("invalid code set %q", value)
+		}
+		ct.CodeSet = codeSet
+	case "chunk":
+		id, text, _ := strings.Cut(value, " ")
+		if len(id) != 4 {
+			return fmt.Errorf("invalid chunk ID %q", id)
+		}
+		ct.Chunks = append(ct.Chunks, &IFFChunk{ID: id, Data: append([]byte(text), 0)})
+	}
+	// other headers like "## rcsid" are ignored like CatComp does
+	return nil
+}
+
+// parseCatalogNumber parses a decimal number or a hexadecimal number
+// with the prefix "$" or "0x".
+func parseCatalogNumber(text string) (uint32, error) {
+	base := 10
+	if hex, found := strings.CutPrefix(text, "$"); found {
+		text, base = hex, 16
+	} else if hex, found := strings.CutPrefix(strings.ToLower(text), "0x"); found {
+		text, base = hex, 16
+	}
+	value, err := strconv.ParseUint(text, base, 32)
+	return uint32(value), err
+}
+
+// readCatalogLines reads all lines of a .cd or .ct file without the line
+// endings.
+func readCatalogLines(reader io.Reader) ([][]byte, error) {
+	var lines [][]byte
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, bytes.TrimRight(slices.Clone(scanner.Bytes()), "\r"))
+	}
+	return lines, scanner.Err()
+}
+
+// catalogText returns the unescaped text which starts at the given line
+// and the index of the line after it. Lines which end with a backslash
+// are continued in the next line. A comment instead of the text means
+// that the text is empty.
+func catalogText(lines [][]byte, start int) ([]byte, int, error) {
+	var text []byte
+
+	if start < len(lines) && bytes.HasPrefix(lines[start], []byte(";")) {
+		return nil, start, nil
+	}
+
+	i := start
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if line, found := bytes.CutSuffix(line, []byte(`\`)); found && !escapedBackslash(line) {
+			text = append(text, line...)
+			continue
+		}
+		text = append(text, line...)
+		i++
+		break
+	}
+
+	unescaped, err := unescapeCatalogText(text)
+	if err != nil {
+		return nil, i, fmt.Errorf("line %d: %w", start+1, err)
+	}
+	return unescaped, i, nil
+}
+
+// escapedBackslash returns true if the line ends with an odd number of
+// backslashes, i.e. a backslash after it is escaped.
+func escapedBackslash(line []byte) bool {
+	count := len(line) - len(bytes.TrimRight(line, `\`))
+	return count%2 != 0
+}
+
+// catalogEscapes maps the escape sequences of CatComp to their bytes.
+var catalogEscapes = map[byte]byte{
+	'a': 0x07, 'b': 0x08, 'c': 0x9B, 'e': 0x1B, 'f': 0x0C,
+	'n': '\n', 'r': '\r', 't': '\t', 'v': 0x0B, '\\': '\\',
+}
+
+// unescapeCatalogText replaces the escape sequences of CatComp, i.e.
+// \n, \t etc., \xHH and octal numbers like \033.
+func unescapeCatalogText(text []byte) ([]byte, error) {
+	var result []byte
+
+	for i := 0; i < len(text); i++ {
+		if text[i] != '\\' {
+			result = append(result, text[i])
+			continue
+		}
+		i++
+		if i == len(text) {
+			return nil, fmt.Errorf("backslash at end of text")
+		}
+
+		switch c := text[i]; {
+		case c == 'x':
+			end := i + 1
+			for end < len(text) && end < i+3 && isHexDigit(text[end]) {
+				end++
+			}
+			value, err := strconv.ParseUint(string(text[i+1:end]), 16, 8)
+			if err != nil {
+				return nil, fmt.Errorf("invalid escape sequence %q", text[i-1:end])
+			}
+			result = append(result, byte(value))
+			i = end - 1
+		case c >= '0' && c <= '7':
+			end := i + 1
+			for end < len(text) && end < i+3 && text[end] >= '0' && text[end] <= '7' {
+				end++
+			}
+			value, err := strconv.ParseUint(string(text[i:end]), 8, 8)
+			if err != nil {
+				return nil, fmt.Errorf("invalid escape sequence %q", text[i-1:end])
+			}
+			result = append(result, byte(value))
+			i = end - 1
+		default:
+			value, exists := catalogEscapes[c]
+			if !exists {
+				return nil, fmt.Errorf("invalid escape sequence %q", text[i-1:i+1])
+			}
+			result = append(result, value)
+		}
+	}
+
+	return result, nil
+}
+
+// isHexDigit returns true for 0-9, a-f and A-F.
+func isHexDigit(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+// escapeCatalogText replaces backslashes and control characters by
+// escape sequences.
+func escapeCatalogText(text []byte) []byte {
+	var result []byte
+
+	for _, c := range text {
+		switch {
+		case c == '\\':
+			result = append(result, `\\`...)
+		case c == '\n':
+			result = append(result, `\n`...)
+		case c == '\t':
+			result = append(result, `\t`...)
+		case c == 0x1B:
+			result = append(result, `\e`...)
+		case c < 0x20 || c == 0x7F:
+			result = append(result, fmt.Sprintf(`\x%02X`, c)...)
+		default:
+			result = append(result, c)
+		}
+	}
+
+	return result
+}
+
+// WriteCatalogTranslation writes the catalog as catalog translation
+// (.ct) encoded with the character set of the catalog. With a catalog
+// description, the strings get its names and order and the original
+// texts are added as comments; strings which aren't translated yet are
+// left empty. Without it, the strings are named after their IDs.
+func WriteCatalogTranslation(writer io.Writer, ctlg *Catalog, desc []CatalogDescEntry) error {
+	encoder := catalogEncoding(ctlg.CodeSet).NewEncoder()
+	comments := encoding.ReplaceUnsupported(catalogEncoding(ctlg.CodeSet).NewEncoder())
+
+	out := bufio.NewWriter(writer)
+	if ctlg.Version != "" {
+		fmt.Fprintf(out, "## version %s\n", ctlg.Version)
+	}
+	if ctlg.Language != "" {
+		fmt.Fprintf(out, "## language %s\n", ctlg.Language)
+	}
+	fmt.Fprintf(out, "## codeset %d\n", ctlg.CodeSet)
+	for _, chunk := range ctlg.Chunks {
+		fmt.Fprintf(out, "## chunk %s %s\n", chunk.ID, cString(chunk.Data))
+	}
+	fmt.Fprintln(out, ";")
+
+	writeEntry := func(name string, text string, original *string) error {
+		encoded, err := encoder.String(text)
+		if err != nil {
+			return fmt.Errorf("string %s: %w", name, err)
+		}
+		fmt.Fprintln(out, name)
+		out.Write(escapeCatalogText([]byte(encoded)))
+		fmt.Fprintln(out)
+		if original != nil {
+			encoded, _ := comments.String(*original)
+			fmt.Fprintf(out, "; %s\n", escapeCatalogText([]byte(encoded)))
+		}
+		fmt.Fprintln(out, ";")
+		return nil
+	}
+
+	if desc == nil {
+		for _, str := range ctlg.Strings {
+			if err := writeEntry(fmt.Sprintf("MSG_%d", str.ID), str.Text, nil); err != nil {
+				return err
+			}
+		}
+	} else {
+		texts := make(map[uint32]string)
+		for _, str := range ctlg.Strings {
+			texts[str.ID] = str.Text
+		}
+		for _, entry := range desc {
+			if err := writeEntry(entry.Name, texts[entry.ID], &entry.Text); err != nil {
+				return err
+			}
+		}
+	}
+
+	return out.Flush()
+}
+
+// CompileCatalog combines a catalog description and a catalog
+// translation into a catalog, which can be saved with EncodeCatalog.
+// Strings with an empty translation are left out like CatComp does.
+func CompileCatalog(desc []CatalogDescEntry, ct *CatalogTranslation) (*Catalog, error) {
+	ctlg := &Catalog{
+		Version:  ct.Version,
+		Language: ct.Language,
+		CodeSet:  ct.CodeSet,
+		Chunks:   ct.Chunks,
+	}
+
+	ids := make(map[string]uint32)
+	for _, entry := range desc {
+		ids[entry.Name] = entry.ID
+	}
+	for _, entry := range ct.Strings {
+		id, exists := ids[entry.Name]
+		if !exists {
+			return nil, fmt.Errorf("string %s isn't in the catalog description", entry.Name)
+		}
+		if entry.Text == "" {
+			continue
+		}
+		ctlg.Strings = append(ctlg.Strings, CatalogString{ID: id, Text: entry.Text})
+	}
+	slices.SortFunc(ctlg.Strings, func(a, b CatalogString) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return ctlg, nil
+}
+
+// EncodeCatalog converts a catalog into a CTLG FORM, which can be saved
+// with WriteIFFFile. The strings are encoded with the character set of
+// the catalog. The length of a string is increased by 1 if it's a
+// multiple of 4, so that every string is terminated by a zero byte.
+func EncodeCatalog(ctlg *Catalog) (*IFFChunk, error) {
+	form := &IFFChunk{ID: "FORM", SubID: "CTLG"}
+	if ctlg.Version != "" {
+		form.Childs = append(form.Childs, &IFFChunk{ID: "FVER", Data: append([]byte(ctlg.Version), 0)})
+	}
+	form.Childs = append(form.Childs, ctlg.Chunks...)
+	if ctlg.Language != "" {
+		form.Childs = append(form.Childs, &IFFChunk{ID: "LANG", Data: append([]byte(ctlg.Language), 0)})
+	}
+
+	cset := make([]byte, 32)
+	binary.BigEndian.PutUint32(cset, ctlg.CodeSet)
+	form.Childs = append(form.Childs, &IFFChunk{ID: "CSET", Data: cset})
+
+	var strs []byte
+	encoder := catalogEncoding(ctlg.CodeSet).NewEncoder()
+	for _, str := range ctlg.Strings {
+		text, err := encoder.Bytes([]byte(str.Text))
+		if err != nil {
+			return nil, fmt.Errorf("string %d: %w", str.ID, err)
+		}
+		length := len(text)
+		if length%4 == 0 {
+			length++
+		}
+		strs = binary.BigEndian.AppendUint32(strs, str.ID)
+		strs = binary.BigEndian.AppendUint32(strs, uint32(length))
+		strs = append(strs, text...)
+		strs = append(strs, make([]byte, (length+3)&^3-len(text))...) // padding
+	}
+	form.Childs = append(form.Childs, &IFFChunk{ID: "STRS", Data: strs})
+
+	return form, nil
+}
+
+// CheckCatalog compares a catalog with its catalog description. It
+// reports strings which are missing in the catalog, strings which aren't
+// in the description and strings which violate the length limits.
+func CheckCatalog(desc []CatalogDescEntry, ctlg *Catalog) []CatalogProblem {
+	var problems []CatalogProblem
+
+	encoder := encoding.ReplaceUnsupported(catalogEncoding(ctlg.CodeSet).NewEncoder())
+	strs := make(map[uint32]string)
+	for _, str := range ctlg.Strings {
+		strs[str.ID] = str.Text
+	}
+
+	known := make(map[uint32]bool)
+	for _, entry := range desc {
+		known[entry.ID] = true
+		text, exists := strs[entry.ID]
+		if !exists {
+			problems = append(problems, CatalogProblem{CatalogMissing, entry.ID, entry.Name,
+				"not in the catalog"})
+			continue
+		}
+
+		encoded, _ := encoder.String(text)
+		length := len(encoded)
+		if length < entry.MinLen {
+			problems = append(problems, CatalogProblem{CatalogTooShort, entry.ID, entry.Name,
+				fmt.Sprintf("%d bytes, at least %d required", length, entry.MinLen)})
+		}
+		if entry.MaxLen > 0 && length > entry.MaxLen {
+			problems = append(problems, CatalogProblem{CatalogTooLong, entry.ID, entry.Name,
+				fmt.Sprintf("%d bytes, at most %d allowed", length, entry.MaxLen)})
+		}
+	}
+
+	for _, str := range ctlg.Strings {
+		if !known[str.ID] {
+			problems = append(problems, CatalogProblem{CatalogExtra, str.ID, "",
+				"not in the catalog description"})
+		}
+	}
+
+	return problems
+}
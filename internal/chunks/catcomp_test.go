@@ -0,0 +1,189 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const testCatalogDescription = `; test description
+#language english
+MSG_HELLO (5/2/10)
+Hello\tworld
+MSG_NEXT (//)
+Next\
+ line
+MSG_HEX ($10//4)
+\x41\033\\
+MSG_EMPTY (//)
+;
+`
+
+func TestParseCatalogDescription(t *testing.T) {
+	want := []CatalogDescEntry{
+		{"MSG_HELLO", 5, 2, 10, "Hello\tworld"},
+		{"MSG_NEXT", 6, 0, 0, "Next line"},
+		{"MSG_HEX", 16, 0, 4, "A\x1b\\"},
+		{"MSG_EMPTY", 17, 0, 0, ""},
+	}
+
+	got, err := ParseCatalogDescription(strings.NewReader(testCatalogDescription))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Entries: got %d, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Entry %d: got %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	var errors = []string{
+		"MSG_A (//)\na\nMSG_A (//)\nb\n",
+		"MSG_A (x//)\na\n",
+		"MSG_A (/-1/)\na\n",
+		"MSG_A\na\n",
+		"MSG_A (//)\n\\q\n",
+	}
+	for _, text := range errors {
+		if _, err := ParseCatalogDescription(strings.NewReader(text)); err == nil {
+			t.Errorf("%q: got no error, want error", text)
+		}
+	}
+}
+
+func TestCatalogTranslationRoundTrip(t *testing.T) {
+	var tests = []string{
+		"KeyShow.catalog",
+		"TextEditor_mcp.catalog",
+	}
+	for _, name := range tests {
+		t.Run(name, func(t *testing.T) {
+			data, root := readTestIFF(t, name)
+			ctlg, err := DecodeCatalog(root)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// the names of the exported strings are derived from the IDs
+			var cd strings.Builder
+			for _, str := range ctlg.Strings {
+				fmt.Fprintf(&cd, "MSG_%d (%d//)\nx\n", str.ID, str.ID)
+			}
+			desc, err := ParseCatalogDescription(strings.NewReader(cd.String()))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var ct bytes.Buffer
+			if err := WriteCatalogTranslation(&ct, ctlg, nil); err != nil {
+				t.Fatal(err)
+			}
+			translation, err := ParseCatalogTranslation(&ct)
+			if err != nil {
+				t.Fatal(err)
+			}
+			compiled, err := CompileCatalog(desc, translation)
+			if err != nil {
+				t.Fatal(err)
+			}
+			form, err := EncodeCatalog(compiled)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var buf bytes.Buffer
+			if err := WriteIFFFile(&buf, form); err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(buf.Bytes(), data) {
+				t.Errorf("compiled catalog differs from %s", name)
+			}
+		})
+	}
+}
+
+func TestWriteCatalogTranslation(t *testing.T) {
+	desc, err := ParseCatalogDescription(strings.NewReader(testCatalogDescription))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctlg := &Catalog{
+		Version:  "$VER: test.catalog 1.0 (01.01.2025)",
+		Language: "deutsch",
+		Strings:  []CatalogString{{ID: 5, Text: "Hallo\tWelt"}, {ID: 16, Text: "Ä\n"}},
+	}
+	want := "## version $VER: test.catalog 1.0 (01.01.2025)\n" +
+		"## language deutsch\n" +
+		"## codeset 0\n" +
+		";\n" +
+		"MSG_HELLO\nHallo\\tWelt\n; Hello\\tworld\n;\n" +
+		"MSG_NEXT\n\n; Next line\n;\n" +
+		"MSG_HEX\n\xc4\\n\n; A\\e\\\\\n;\n" +
+		"MSG_EMPTY\n\n; \n;\n"
+
+	var buf bytes.Buffer
+	if err := WriteCatalogTranslation(&buf, ctlg, desc); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != want {
+		t.Errorf("got\n%q, want\n%q", buf.String(), want)
+	}
+
+	ct, err := ParseCatalogTranslation(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	compiled, err := CompileCatalog(desc, ct)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(compiled.Strings) != 2 || compiled.Strings[1].Text != "Ä\n" {
+		t.Errorf("Strings: got %+v, want %+v", compiled.Strings, ctlg.Strings)
+	}
+
+	ct.Strings = append(ct.Strings, CatalogTranslationEntry{"MSG_UNKNOWN", "x"})
+	if _, err := CompileCatalog(desc, ct); err == nil {
+		t.Errorf("Unknown: got no error, want error")
+	}
+}
+
+func TestCheckCatalog(t *testing.T) {
+	desc, err := ParseCatalogDescription(strings.NewReader(testCatalogDescription))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctlg := &Catalog{Strings: []CatalogString{
+		{ID: 5, Text: "H"},
+		{ID: 6, Text: "Nächste"},
+		{ID: 16, Text: "Hexadezimal"},
+		{ID: 17, Text: ""},
+		{ID: 99, Text: "Extra"},
+	}}
+	want := []CatalogProblem{
+		{CatalogTooShort, 5, "MSG_HELLO", "1 bytes, at least 2 required"},
+		{CatalogTooLong, 16, "MSG_HEX", "11 bytes, at most 4 allowed"},
+		{CatalogExtra, 99, "", "not in the catalog description"},
+	}
+
+	got := CheckCatalog(desc, ctlg)
+	if len(got) != len(want) {
+		t.Fatalf("Problems: got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Problem %d: got %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	missing := CheckCatalog(desc, &Catalog{})
+	if len(missing) != len(desc) || missing[0].Kind != CatalogMissing {
+		t.Errorf("Missing: got %v", missing)
+	}
+}
@@ -8,7 +8,6 @@ import (
 	"encoding/binary"
 	"fmt"
 	"io"
-	"slices"
 )
 
 // IFFChunk represents a chunk in an IFF file.
@@ -25,9 +24,24 @@ type IFFChunk struct {
 	// the sum of the size of the chunk and all its children
 	SumSize int64
 
-	// SubID for group chunks, e.g. ILBM
-	// parent's SubID + ID for data chunks, e.g. ILBM.BMHD
-	ChType string
+	// the SubID of the parent group chunk for data chunks, e.g. ILBM,
+	// see ChType
+	ParentType string
+
+	// absolute file offsets of the chunk header, the payload (after
+	// the SubID for group chunks) and the end of the chunk including
+	// padding and children
+	Offset     int64
+	DataOffset int64
+	EndOffset  int64
+
+	// set by ReadIFFFileLenient if the file ends before the chunk
+	// or its parent; Data then only contains the available bytes
+	Truncated bool
+
+	// number of bytes in the file after the end of the chunk,
+	// only set for the root chunk
+	TrailingSize int64
 }
 
 // ReadIFFFile reads an IFF file and returns the root chunk.
@@ -35,7 +49,10 @@ type IFFChunk struct {
 // In case of an error, the function returns nil and the error.
 func ReadIFFFile(reader io.Reader, fileLen int64) (*IFFChunk, error) {
 
-	chunk, err := readChunk(reader, nil, fileLen, 0)
+	chunk, err := readChunk(reader, nil, fileLen, 0, 0)
+	if chunk != nil {
+		chunk.TrailingSize = fileLen - chunk.SumSize
+	}
 
 	return chunk, err
 }
@@ -43,7 +60,7 @@ func ReadIFFFile(reader io.Reader, fileLen int64) (*IFFChunk, error) {
 // readChunkID reads the ID of a chunk from the reader.
 // In case of an error, the function returns an empty string and the error.
 func readChunkID(reader io.Reader) (string, error) {
-	//TODO: check for valid characters
+	// invalid characters are reported by Validate
 	var id [4]byte
 	_, err := reader.Read(id[:])
 	if err != nil {
@@ -66,8 +83,10 @@ func readChunkSize(reader io.Reader) (uint32, error) {
 }
 
 // readChunk recursively reads the chunks from the reader.
+// offset is the absolute file offset where the chunk starts.
 // In case of an error, the function returns nil and the error.
-func readChunk(reader io.Reader, parentChunk *IFFChunk, maxSize int64, level int) (*IFFChunk, error) {
+func readChunk(reader io.Reader, parentChunk *IFFChunk, maxSize int64, offset int64,
+	level int) (*IFFChunk, error) {
 	var chunk IFFChunk
 	var err error
 
@@ -78,6 +97,8 @@ func readChunk(reader io.Reader, parentChunk *IFFChunk, maxSize int64, level int
 		return nil, fmt.Errorf("maxSize is < 8")
 	}
 
+	chunk.Offset = offset
+
 	chunk.ID, err = readChunkID(reader)
 	if err != nil {
 		return nil, err
@@ -94,7 +115,7 @@ func readChunk(reader io.Reader, parentChunk *IFFChunk, maxSize int64, level int
 		return nil, fmt.Errorf("file doesn't start with FORM, CAT, or LIST")
 	}
 
-	if chunk.ID == "FORM" || chunk.ID == "CAT " || chunk.ID == "LIST" || chunk.ID == "PROP" {
+	if isGroup(chunk.ID) {
 		// we have a group chunk
 		if chunk.SumSize+4 > maxSize {
 			return nil, fmt.Errorf("SumSize+4 > maxSize")
@@ -104,17 +125,13 @@ func readChunk(reader io.Reader, parentChunk *IFFChunk, maxSize int64, level int
 			return nil, err
 		}
 		chunk.SumSize += 4
-
-		chunk.ChType = chunk.SubID
+		chunk.DataOffset = offset + chunk.SumSize
 	} else {
 		// we have a data chunk
 
-		// for some generic chunks we prefix with (any)
-		if isGeneric(chunk.ID) {
-			chunk.ChType = "(any)." + chunk.ID
-		} else {
-			chunk.ChType = parentChunk.SubID + "." + chunk.ID
-		}
+		chunk.ParentType = parentChunk.SubID
+
+		chunk.DataOffset = offset + chunk.SumSize
 
 		if chunk.SumSize+int64(chunk.Size) > maxSize {
 			return nil, fmt.Errorf("SumSize+Size > maxSize")
@@ -140,9 +157,10 @@ func readChunk(reader io.Reader, parentChunk *IFFChunk, maxSize int64, level int
 		}
 	}
 	//fmt.Printf("ID: %s, Size: %d, SubID: %s\n", chunk.ID, chunk.Size, chunk.SubID)
-	if chunk.ID == "FORM" || chunk.ID == "CAT " || chunk.ID == "LIST" || chunk.ID == "PROP" {
+	if isGroup(chunk.ID) {
 		for chunk.SumSize < int64(chunk.Size)+8 {
-			child, err := readChunk(reader, &chunk, maxSize-chunk.SumSize, level+1)
+			child, err := readChunk(reader, &chunk, maxSize-chunk.SumSize,
+				offset+chunk.SumSize, level+1)
 			if err != nil {
 				return nil, err
 			}
@@ -152,6 +170,8 @@ func readChunk(reader io.Reader, parentChunk *IFFChunk, maxSize int64, level int
 			}
 		}
 	}
+	chunk.EndOffset = offset + chunk.SumSize
+
 	return &chunk, nil
 }
 
@@ -167,10 +187,51 @@ func PrintIffChunk(chunk *IFFChunk, level int) {
 	}
 }
 
-// isGeneric returns true if the chunk ID is generic.
-func isGeneric(id string) bool {
+// isValidID returns true if the chunk ID consists of 4 printable
+// ASCII characters as required by EA IFF 85.
+func isValidID(id string) bool {
+	if len(id) != 4 {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x20 || id[i] > 0x7E {
+			return false
+		}
+	}
+	return true
+}
+
+// isGroup returns true if the chunk ID is one of the group IDs
+// FORM, CAT, LIST or PROP.
+func isGroup(id string) bool {
+	return id == "FORM" || id == "CAT " || id == "LIST" || id == "PROP"
+}
+
+// ChType returns the chunk type, i.e. the SubID of group chunks, e.g. ILBM,
+// and the parent's SubID and the ID of data chunks, e.g. ILBM.BMHD. The
+// type is resolved with the handlers registered at the time of the call,
+// see dataChunkType.
+func (chunk *IFFChunk) ChType() string {
+	if isGroup(chunk.ID) {
+		return chunk.SubID
+	}
+	if chunk.ParentType == "" {
+		return "(any)." + chunk.ID
+	}
+	return dataChunkType(chunk.ParentType, chunk.ID)
+}
 
-	return slices.Contains([]string{"ANNO", "AUTH", "CHRS",
-		"CSET", "FRED", "FVER", "HLID", "INFO", "JUNK", "UTF8",
-		"NAME", "TEXT", "(c) "}, id)
+// dataChunkType returns the chunk type of a data chunk, e.g. ILBM.BMHD.
+// Generic chunks, i.e. chunks with a type of (any) like (any).NAME, get
+// the prefix (any) unless the FORM type defines its own chunk with the
+// same ID, like the FVER chunk of AIFC.
+func dataChunkType(parentSubID string, id string) string {
+	chType := parentSubID + "." + id
+	if _, exists := lookupChunkData(chType); exists {
+		return chType
+	}
+	if _, exists := lookupChunkData("(any)." + id); exists {
+		return "(any)." + id
+	}
+	return chType
 }
@@ -3,7 +3,15 @@
 
 package chunks
 
-import "testing"
+import (
+	"bytes"
+	"image/color"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+)
 
 var data = []byte{0x01, 0xff, 0x07, 0x5B, 0xCD, 0x15, 0x02}
 
@@ -186,3 +194,128 @@ func TestGetByte(t *testing.T) {
 		})
 	}
 }
+
+func TestStructFields(t *testing.T) {
+	bmhd := []byte{0, 64, 0, 32, 0, 0, 0, 0, 4, 2, 1, 0, 0, 3, 10, 11, 1, 64, 0, 200}
+	_, result, err := GetStructData("ILBM.BMHD", bmhd)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		name       string
+		wantOffset uint32
+		wantLength uint32
+		byteIndex  uint32
+	}{
+		{"Width : Height", 0, 4, 3},
+		{"Number of planes", 8, 1, 8},
+		{"Compression", 10, 1, 10},
+		{"Transparent Color", 12, 2, 13}, // after the pad byte
+		{"Page Width : Height", 16, 4, 19},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := slices.IndexFunc(result, func(f StructField) bool { return f.Name == tt.name })
+			if i < 0 {
+				t.Fatalf("field not found in %v", result)
+			}
+			if result[i].Offset != tt.wantOffset || result[i].Length != tt.wantLength {
+				t.Errorf("Offset, Length: got %d, %d, want %d, %d",
+					result[i].Offset, result[i].Length, tt.wantOffset, tt.wantLength)
+			}
+			if got := result.FieldAt(tt.byteIndex); got != i {
+				t.Errorf("FieldAt: got %d, want %d", got, i)
+			}
+		})
+	}
+
+	if got := result.FieldAt(11); got != -1 {
+		t.Errorf("FieldAt pad byte: got %d, want -1", got)
+	}
+}
+
+func TestTypedFields(t *testing.T) {
+	bmhd := []byte{0, 64, 0, 32, 0, 0, 0, 0, 4, 2, 1, 0, 0, 3, 10, 11, 1, 64, 0, 200}
+	_, result, err := GetStructData("ILBM.BMHD", bmhd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tests = []struct {
+		name     string
+		wantType string
+		wantRaw  any
+		wantEnum string
+	}{
+		{"Number of planes", "uint8", uint8(4), ""},
+		{"Compression", "uint8", uint8(1), "Byte Run 1"},
+		{"Width : Height", "[]uint16", []uint16{64, 32}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := slices.IndexFunc(result, func(f StructField) bool { return f.Name == tt.name })
+			if i < 0 {
+				t.Fatalf("field not found in %v", result)
+			}
+			field := result[i]
+			if field.Type() != tt.wantType || !reflect.DeepEqual(field.Raw, tt.wantRaw) || field.Enum != tt.wantEnum {
+				t.Errorf("got %s %v %q, want %s %v %q",
+					field.Type(), field.Raw, field.Enum, tt.wantType, tt.wantRaw, tt.wantEnum)
+			}
+		})
+	}
+
+	_, result, err = GetStructData("ILBM.CMAP", []byte{1, 2, 3, 4, 5, 6})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result) != 1 || len(result[0].Children) != 2 ||
+		result[0].Children[1].Raw != (color.RGBA{4, 5, 6, 0xFF}) {
+		t.Errorf("CMAP: got %v", result)
+	}
+	want := []fieldRow{{"Colors", "2", 0, 6}, {"  Color 0", "1 : 2 : 3", 0, 3}, {"  Color 1", "4 : 5 : 6", 3, 3}}
+	if got := fieldRows(result); !slices.Equal(got, want) {
+		t.Errorf("CMAP rows: got %v, want %v", got, want)
+	}
+
+	_, result, err = GetStructData("AIFF.COMM", makeAIFFCommon(1, 22050, 8, 22050, ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	i := slices.IndexFunc(result, func(f StructField) bool { return f.Name == "Sample Rate" })
+	if i < 0 || result[i].Unit != "Hz" || result[i].Value != "22050 Hz" {
+		t.Errorf("Sample Rate: got %v", result)
+	}
+}
+
+// fieldRow is the text of a decoded field as shown by the GUI.
+type fieldRow struct {
+	Name   string
+	Value  string
+	Offset uint32
+	Length uint32
+}
+
+// fieldRows returns the rows of the decoded fields without raw values.
+func fieldRows(result StructResult) []fieldRow {
+	var rows []fieldRow
+	for _, field := range result.Rows() {
+		rows = append(rows, fieldRow{field.Name, field.Value, field.Offset, field.Length})
+	}
+	return rows
+}
+
+// readTestIFF parses a file from the tests directory of the repository.
+func readTestIFF(t *testing.T, name string) ([]byte, *IFFChunk) {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("..", "..", "tests", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := ReadIFFFile(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("%s: %s", name, err)
+	}
+	return data, root
+}
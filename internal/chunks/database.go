@@ -5,10 +5,125 @@ package chunks
 
 import (
 	"fmt"
+	"maps"
+	"strings"
+	"sync"
 )
 
-// StructResult is a list of key-value pairs.
-type StructResult [][2]string
+// StructField is a decoded field of a chunk. Raw is the decoded value,
+// e.g. an uint16, a string or a color, and Value its formatted text.
+// Unit and Enum are set for values with a unit like "Hz" or with a name
+// like "Byte Run 1". Offset and Length give the bytes of the chunk data
+// the value was decoded from. Length is 0 for fields which don't belong
+// to any bytes, e.g. error messages. Children contains nested fields,
+// e.g. the colors of a color map.
+type StructField struct {
+	Name     string
+	Value    string
+	Offset   uint32
+	Length   uint32
+	Raw      any
+	Unit     string
+	Enum     string
+	Children StructResult
+}
+
+// StructResult is a list of decoded fields.
+type StructResult []StructField
+
+// Type returns the Go type of the raw value, e.g. "uint16", or "" if the
+// field has no value.
+func (field StructField) Type() string {
+	if field.Raw == nil {
+		return ""
+	}
+	return fmt.Sprintf("%T", field.Raw)
+}
+
+// Rows returns the fields and their children depth-first as a flat list
+// without children. The names of nested fields are indented by two
+// spaces per level.
+func (result StructResult) Rows() StructResult {
+	var rows StructResult
+
+	var flatten func(fields StructResult, indent string)
+	flatten = func(fields StructResult, indent string) {
+		for _, field := range fields {
+			children := field.Children
+			field.Name = indent + field.Name
+			field.Children = nil
+			rows = append(rows, field)
+			flatten(children, indent+"  ")
+		}
+	}
+
+	flatten(result, "")
+	return rows
+}
+
+// fieldValue is a raw value with a unit, an enum name or a custom format.
+// Handlers pass it to add or addField instead of the raw value.
+type fieldValue struct {
+	raw  any
+	text string // formatted value, derived from raw, unit and enum if empty
+	unit string
+	enum string
+}
+
+// withUnit returns a value with a unit, e.g. "Hz".
+func withUnit(raw any, unit string) fieldValue {
+	return fieldValue{raw: raw, unit: unit}
+}
+
+// withEnum returns a value with the name of its meaning, e.g. the name
+// of a compression type. The name is used as formatted value.
+func withEnum(raw any, enum string) fieldValue {
+	return fieldValue{raw: raw, enum: enum}
+}
+
+// withFormat returns a value which is formatted with a format of
+// fmt.Sprintf, e.g. "0x%08X".
+func withFormat(raw any, format string) fieldValue {
+	return fieldValue{raw: raw, text: fmt.Sprintf(format, raw)}
+}
+
+// withText returns a value with a custom formatted text.
+func withText(raw any, text string) fieldValue {
+	return fieldValue{raw: raw, text: text}
+}
+
+// withTuple returns several values of the same type as a slice, e.g.
+// width and height, formatted with a format like "%d : %d".
+func withTuple[T any](format string, values ...T) fieldValue {
+	args := make([]any, len(values))
+	for i, value := range values {
+		args[i] = value
+	}
+	return fieldValue{raw: values, text: fmt.Sprintf(format, args...)}
+}
+
+// newField creates a field from a raw value or a fieldValue. Raw values
+// are formatted with fmt.Sprint.
+func newField(name string, value any) StructField {
+	v, ok := value.(fieldValue)
+	if !ok {
+		v = fieldValue{raw: value}
+	}
+
+	field := StructField{Name: name, Value: v.text, Raw: v.raw, Unit: v.unit, Enum: v.enum}
+	if v.text == "" {
+		switch {
+		case v.enum != "":
+			field.Value = v.enum
+		case v.raw != nil:
+			field.Value = fmt.Sprint(v.raw)
+			if v.unit != "" {
+				field.Value += " " + v.unit
+			}
+		}
+	}
+	return field
+}
 
 // ChunkHandler is a function that processes a chunk and returns the structured data.
 type ChunkHandler func(data []byte) (StructResult, error)
@@ -19,13 +134,17 @@ type ChunkData struct {
 	Description string
 }
 
+// structDataMutex protects structData, handlers can be registered while
+// files are parsed, e.g. by a GUI which loads schemas.
+var structDataMutex sync.RWMutex
+
 // structData contains the handler and the description for each chunk type.
 var structData = map[string]ChunkData{
 	// generic chunks
 	"(any).ANNO": {handleAnyIso8859, "Annotation"},
 	"(any).AUTH": {handleAnyIso8859, "Author"},
-	"(any).CHRS": {nil, "Character String"}, // can contain ANSI codes
-	"(any).CSET": {nil, "Character Set"},    // binary
+	"(any).CHRS": {handleAnyChrs, "Character String"}, // can contain ANSI codes
+	"(any).CSET": {nil, "Character Set"},              // binary
 	"(any).FRED": {nil, "ASDG Private"},
 	"(any).FVER": {handleAnyIso8859, "Version"},
 	"(any).HLID": {nil, "Hotlink"},
@@ -40,6 +159,7 @@ var structData = map[string]ChunkData{
 	"8SVX.VHDR": {handle8svxVhdr, "Voice Header"},
 	"8SVX.ATAK": {handle8svxAtakRlse, "Attack"},
 	"8SVX.RLSE": {handle8svxAtakRlse, "Release"},
+	"8SVX.BODY": {nil, "Sampled Sound Data"},
 
 	"ACBM":      {nil, "Amiga Continuous Bitmap"},
 	"ACBM.ABIT": {nil, "Bitmap Body"},
@@ -50,7 +170,27 @@ var structData = map[string]ChunkData{
 	"ACBM.SPRT": {handleIlbmSprt, "Sprite"},             // reusing ILBM
 	"ACBM.CAMG": {handleIlbmCamg, "Amiga Display Mode"}, // reusing ILBM
 
-	"AIFF": {nil, "Audio Samples"},
+	"AIFF":      {nil, "Audio Samples"},
+	"AIFF.COMM": {handleAiffComm, "Common"},
+	"AIFF.SSND": {handleAiffSsnd, "Sound Data"},
+	"AIFF.MARK": {handleAiffMark, "Markers"},
+	"AIFF.INST": {handleAiffInst, "Instrument"},
+	"AIFF.COMT": {handleAiffComt, "Comments"},
+	"AIFF.APPL": {handleAiffAppl, "Application Specific"},
+	"AIFF.MIDI": {nil, "MIDI Data"},
+	"AIFF.AESD": {nil, "Audio Recording"},
+
+	"AIFC":      {nil, "Compressed Audio Samples"},
+	"AIFC.FVER": {handleAifcFver, "Format Version"}, // not a text like (any).FVER
+	"AIFC.COMM": {handleAifcComm, "Common"},
+	"AIFC.SSND": {handleAiffSsnd, "Sound Data"},           // reusing AIFF
+	"AIFC.MARK": {handleAiffMark, "Markers"},              // reusing AIFF
+	"AIFC.INST": {handleAiffInst, "Instrument"},           // reusing AIFF
+	"AIFC.COMT": {handleAiffComt, "Comments"},             // reusing AIFF
+	"AIFC.APPL": {handleAiffAppl, "Application Specific"}, // reusing AIFF
+	"AIFC.MIDI": {nil, "MIDI Data"},
+	"AIFC.AESD": {nil, "Audio Recording"},
+
 	"ANBM": {nil, "Animated Bitmap"},
 
 	"ANIM":      {nil, "CEL Animations"},
@@ -58,12 +198,17 @@ var structData = map[string]ChunkData{
 	"ILBM.DLTA": {nil, "Delta Compression"},             // parent is ILBM!
 	"ILBM.DPAN": {handleAnimDpan, "Display Parameters"}, // parent is ILBM!
 
-	"CMUS": {nil, "Musical Score"},
+	"CMUS":      {nil, "Common Musical Score"},
+	"CMUS.SHDR": {handleSmusShdr, "Score Header"}, // same layout as SMUS.SHDR
+	"CMUS.INS1": {handleSmusIns1, "Instrument Reference"},
+	"CMUS.TRAK": {handleSmusTrak, "Track"},
+
 	"CSET": {nil, "Text Character Set"},
 
 	"CTLG":      {nil, "Catalog"},
+	"CTLG.CSET": {handleCtlgCset, "Character Set"}, // not binary like (any).CSET
 	"CTLG.LANG": {handleAnyIso8859, "Language"},
-	"CTLG.STRS": {nil, "Strings"},
+	"CTLG.STRS": {handleCtlgStrs, "Strings"},
 
 	"DEEP": {nil, "Chunky Pixel Image"},
 	"DTYP": {nil, "DataType Identification"},
@@ -71,11 +216,13 @@ var structData = map[string]ChunkData{
 	"EXEC": {nil, "Executable Code"},
 	"FANT": {nil, "Movie Format"},
 	"FAXX": {nil, "Facsimile Image"},
-	"FTXT": {nil, "Formatted Text"},
 	"FVER": {nil, "Version String"},
 	"HEAD": {nil, "Flow Idea Processor Format"},
 	"HLID": {nil, "Hotlink Identification"},
 
+	"FTXT":      {nil, "Formatted Text"},
+	"FTXT.FONS": {handleFtxtFons, "Font Specifier"},
+
 	"ILBM":      {nil, "InterLeaved BitMap"},
 	"ILBM.BMHD": {handleIlbmBmhd, "Bitmap Header"},
 	"ILBM.BODY": {nil, "Bitmap Body"},
@@ -85,7 +232,7 @@ var structData = map[string]ChunkData{
 	"ILBM.CLUT": {nil, "Color Look Up Table"},
 	"ILBM.CMYK": {nil, "Cyan Magenta Yellow Black"},
 	"ILBM.CNAM": {nil, "Color Naming"},
-	"ILBM.CTBL": {nil, "Dynamic Color Palette"},
+	"ILBM.CTBL": {handleIlbmCtbl, "Dynamic Color Palette"},
 	"ILBM.CRNG": {handleIlbmCrng, "Color Range"},
 	"ILBM.DPPS": {nil, "DPaint Page State"},
 	"ILBM.DRNG": {nil, "DPaint Range"},
@@ -95,8 +242,9 @@ var structData = map[string]ChunkData{
 	"ILBM.DEST": {handleIlbmDest, "Destination"},
 	"ILBM.EPSF": {nil, "Encapsulated Postscript"},
 	"ILBM.GRAB": {handleIlbmGrab, "Grab (Hotspot)"},
-	"ILBM.PCHG": {nil, "Line By line Palette"},
+	"ILBM.PCHG": {handleIlbmPchg, "Line By line Palette"},
 	"ILBM.PRVW": {nil, "Preview"},
+	"ILBM.SHAM": {handleIlbmSham, "Sliced HAM Palettes"},
 	"ILBM.SPRT": {handleIlbmSprt, "Sprite"},
 	"ILBM.TINY": {nil, "Thumbnail"},
 	"ILBM.XBMI": {nil, "Extended BitMap Information"},
@@ -109,19 +257,8 @@ var structData = map[string]ChunkData{
 	"PGTB": {nil, "Program Traceback"},
 	"PMBC": {nil, "High-color Image Format"},
 
-	"PREF":      {nil, "Preferences"},
-	"PREF.PRHD": {handlePrefPrhd, "Preferences Header"},
-	"PREF.ASL ": {handlePrefAsl, "ASL Preferences"},
-	"PREF.FONT": {handlePrefFont, "Font Preferences"},
-	"PREF.ICTL": {handlePrefIctl, "IControl Preferences"},
-	"PREF.INPT": {handlePrefInpt, "Input Preferences"},
-	"PREF.KMSW": {handlePrefKmsw, "Keyboard/Mouse Preferences"},
-	"PREF.LCLE": {handlePrefLcle, "Locale Preferences"},
-	"PREF.OSCN": {handlePrefOscn, "Overscan Preferences"},
-	"PREF.PALT": {handlePrefPalt, "Palette Preferences"},
+	"PREF":      {nil, "Preferences"}, // see schemas/prefs.yaml
 	"PREF.CMAP": {handleIlbmCmap, "Color Map"},
-	"PREF.PNTR": {handlePrefPntr, "Pointer Preferences"},
-	"PREF.NPTR": {handlePrefNptr, "New Pointer Preferences"}, // AROS specific
 	"PREF.PTXT": {nil, "Printer Preferences"},
 	"PREF.PUNT": {nil, "Printer Unit Preferences"},
 	"PREF.PDEV": {nil, "Printer Device Preferences"},
@@ -134,7 +271,6 @@ var structData = map[string]ChunkData{
 	"RGBN": {nil, "Image Data"},
 	"RGB8": {nil, "Image Data"},
 	"SAMP": {nil, "Sampled Sound"},
-	"SMUS": {nil, "Simple Musical Score"},
 	"SPLT": {nil, "File Splitting"},
 	"TDDD": {nil, "3-D Rendering Data"},
 	"TMUI": {nil, "Project File Format"},
@@ -143,37 +279,167 @@ var structData = map[string]ChunkData{
 	"UTF8": {nil, "UTF-8 Unicode Text"},
 	"WORD": {nil, "Document Storage"},
 	"YUVN": {nil, "YUV Image Data"},
+
+	"SMUS":      {nil, "Simple Musical Score"},
+	"SMUS.SHDR": {handleSmusShdr, "Score Header"},
+	"SMUS.INS1": {handleSmusIns1, "Instrument Reference"},
+	"SMUS.TRAK": {handleSmusTrak, "Track"},
 }
 
+// builtinStructData contains the built-in chunk types, UnregisterHandler
+// restores them.
+var builtinStructData = maps.Clone(structData)
+
 // GetStructData returns the description and the structured data of a chunk.
 // - chType is the chunk type, e.g. "ILBM", "ILBM.BMHD"
 // - data is the chunk data
-// It returns the description and the structured data as a list of key-value pairs.
+// It returns the description and the structured data as a tree of typed fields.
 // In case of an error the incomplete result is returned.
 func GetStructData(chType string, data []byte) (string, StructResult, error) {
 	var result StructResult
 	var description string
 	var err error
 
-	if chunkData, exists := structData[chType]; exists {
+	if chunkData, exists := resolveChunkData(chType); exists {
 		description = chunkData.Description
 		handler := chunkData.Handler
 		if handler != nil {
 			result, err = handler(data)
 			if err != nil {
-				result = append(result, [2]string{"", fmt.Sprintf("(error: %s)", err)})
+				result = append(result, StructField{Value: fmt.Sprintf("(error: %s)", err)})
 			}
 		} else {
-			result = append(result, [2]string{"", "(not available)"})
+			result = append(result, StructField{Value: "(not available)"})
 		}
 	} else {
 		description = "(unknown)"
-		result = append(result, [2]string{"", "(unknown)"})
+		result = append(result, StructField{Value: "(unknown)"})
 	}
 
 	return description, result, err
 }
 
+// lookupChunkData returns the handler and the description of a chunk type.
+func lookupChunkData(chType string) (ChunkData, bool) {
+	structDataMutex.RLock()
+	defer structDataMutex.RUnlock()
+	chunkData, exists := structData[chType]
+	return chunkData, exists
+}
+
+// resolveChunkData returns the handler and the description of a chunk
+// type like lookupChunkData. Data chunks of a FORM type without an own
+// entry fall back to the entry of (any).
+func resolveChunkData(chType string) (ChunkData, bool) {
+	if chunkData, exists := lookupChunkData(chType); exists {
+		return chunkData, true
+	}
+	form, id, found := strings.Cut(chType, ".")
+	if !found || form == "(any)" {
+		return ChunkData{}, false
+	}
+	return lookupChunkData("(any)." + id)
+}
+
+// RegisterHandler registers the handler and the description of a chunk
+// type, e.g. "ILBM.BMHD" or "(any).NAME" for a chunk of any FORM. It
+// replaces the handler of a known chunk type. An empty description keeps
+// the description of a known chunk type. Chunk types are resolved when
+// they are used, so the handler applies to files which were read before.
+func RegisterHandler(chType string, handler ChunkHandler, description string) error {
+	if err := checkChunkType(chType); err != nil {
+		return err
+	}
+
+	structDataMutex.Lock()
+	defer structDataMutex.Unlock()
+	chunkData := structData[chType]
+	chunkData.Handler = handler
+	if description != "" {
+		chunkData.Description = description
+	}
+	structData[chType] = chunkData
+	return nil
+}
+
+// UnregisterHandler removes a handler registered by RegisterHandler. The
+// built-in handler and description of a chunk type are restored.
+func UnregisterHandler(chType string) {
+	structDataMutex.Lock()
+	defer structDataMutex.Unlock()
+	if chunkData, exists := builtinStructData[chType]; exists {
+		structData[chType] = chunkData
+	} else {
+		delete(structData, chType)
+	}
+}
+
+// checkChunkType checks the type of a data chunk, e.g. "ILBM.BMHD".
+func checkChunkType(chType string) error {
+	form, id, found := strings.Cut(chType, ".")
+	if !found || (len(form) != 4 && form != "(any)") || len(id) != 4 {
+		return fmt.Errorf("invalid chunk type %q, want e.g. \"ILBM.BMHD\"", chType)
+	}
+	return nil
+}
+
+// add appends a field which was decoded from the bytes between the end of
+// the previous field and offset, the current read position of the handler.
+// If no bytes were read since the previous field, e.g. if a value is
+// described by several rows, the field gets the bytes of the previous field.
+// value is a raw value or a fieldValue.
+func (result *StructResult) add(name string, value any, offset uint32) {
+	var start uint32
+	field := newField(name, value)
+	if len(*result) > 0 {
+		last := (*result)[len(*result)-1]
+		start = last.Offset + last.Length
+		if offset <= start {
+			field.Offset, field.Length = last.Offset, last.Length
+			*result = append(*result, field)
+			return
+		}
+	}
+	field.Offset, field.Length = start, offset-start
+	*result = append(*result, field)
+}
+
+// addField appends a field which was decoded from length bytes at offset.
+// value is a raw value or a fieldValue.
+func (result *StructResult) addField(name string, value any, offset uint32, length uint32) {
+	field := newField(name, value)
+	field.Offset, field.Length = offset, length
+	*result = append(*result, field)
+}
+
+// addGroup appends a field with nested fields. It gets the bytes from
+// offset, e.g. the offset of a counter, to the end of the last child.
+func (result *StructResult) addGroup(name string, value any, offset uint32, children StructResult) {
+	field := newField(name, value)
+	field.Offset = offset
+	field.Children = children
+	for _, child := range children {
+		if end := child.Offset + child.Length; end > field.Offset+field.Length {
+			field.Length = end - field.Offset
+		}
+	}
+	*result = append(*result, field)
+}
+
+// FieldAt returns the index of the smallest field which contains the
+// byte at the given offset or -1 if no field contains it. It's used with
+// the flat list returned by Rows, where groups contain their children.
+func (result StructResult) FieldAt(offset uint32) int {
+	index := -1
+	for i, field := range result {
+		if offset >= field.Offset && offset < field.Offset+field.Length &&
+			(index < 0 || field.Length < result[index].Length) {
+			index = i
+		}
+	}
+	return index
+}
+
 // getBeUword reads a big-endian unsigned WORD from the data at the given offset.
 // The offset is incremented by 2.
 // In case of an error, it returns 0 and the error. The offset is unchanged.
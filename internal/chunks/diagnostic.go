@@ -0,0 +1,54 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import "fmt"
+
+// Severity is the severity of a diagnostic.
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityError
+)
+
+// String returns the name of the severity.
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	}
+	return fmt.Sprintf("severity(%d)", int(s))
+}
+
+// Diagnostic describes a problem found in an IFF file.
+type Diagnostic struct {
+	Severity Severity
+	Offset   int64  // absolute file offset of the problem
+	Path     string // path of the affected chunk, see ChunkPath
+	Message  string
+}
+
+// String formats the diagnostic as a single line.
+func (d Diagnostic) String() string {
+	if d.Path == "" {
+		return fmt.Sprintf("%s at 0x%08X: %s", d.Severity, d.Offset, d.Message)
+	}
+	return fmt.Sprintf("%s at 0x%08X (%s): %s", d.Severity, d.Offset, d.Path, d.Message)
+}
+
+// HasErrors returns true if one of the diagnostics has SeverityError.
+func HasErrors(diags []Diagnostic) bool {
+	for _, d := range diags {
+		if d.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
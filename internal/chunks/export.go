@@ -0,0 +1,175 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ExportSchemaVersion is the version of the export schema. It's increased
+// whenever fields are renamed or removed. Adding fields doesn't change it.
+const ExportSchemaVersion = 1
+
+// ExportDocument is the top level object of a JSON or YAML export.
+//
+// The schema is identical for JSON and YAML:
+//
+//	schema:  version of the schema (ExportSchemaVersion)
+//	root:    the root chunk
+//
+// Each chunk has the following keys:
+//
+//	id:          chunk ID, e.g. "FORM" or "BMHD"
+//	subId:       SubID of group chunks, e.g. "ILBM" (omitted for data chunks)
+//	type:        chunk type, e.g. "ILBM" or "ILBM.BMHD"
+//	size:        size as stored in the chunk header
+//	sumSize:     size of the chunk including header, padding and children
+//	offset:      file offset of the chunk header
+//	dataOffset:  file offset of the chunk payload
+//	endOffset:   file offset after the chunk including padding
+//	description: description of the chunk type
+//	fields:      list of fields of the decoded structure, see below
+//	error:       error message of the chunk handler (omitted if none)
+//	truncated:   true if the chunk is incomplete (omitted if false)
+//	data:        base64 encoded payload (only if requested)
+//	children:    child chunks of group chunks
+//
+// Each field has the following keys:
+//
+//	name:     name of the field, e.g. "Width : Height"
+//	value:    formatted value as shown by the GUI
+//	type:     Go type of the raw value, e.g. "uint16" (omitted if none)
+//	raw:      decoded value, e.g. a number, a string or a list
+//	unit:     unit of the value, e.g. "Hz" (omitted if none)
+//	enum:     name of the value, e.g. "Byte Run 1" (omitted if none)
+//	offset:   offset of the field in the chunk payload
+//	length:   number of bytes of the field
+//	children: nested fields, e.g. the colors of a color map
+type ExportDocument struct {
+	Schema int          `json:"schema" yaml:"schema"`
+	Root   *ExportChunk `json:"root" yaml:"root"`
+}
+
+// ExportChunk is the exported representation of an IFFChunk.
+type ExportChunk struct {
+	ID          string         `json:"id" yaml:"id"`
+	SubID       string         `json:"subId,omitempty" yaml:"subId,omitempty"`
+	Type        string         `json:"type" yaml:"type"`
+	Size        uint32         `json:"size" yaml:"size"`
+	SumSize     int64          `json:"sumSize" yaml:"sumSize"`
+	Offset      int64          `json:"offset" yaml:"offset"`
+	DataOffset  int64          `json:"dataOffset" yaml:"dataOffset"`
+	EndOffset   int64          `json:"endOffset" yaml:"endOffset"`
+	Description string         `json:"description" yaml:"description"`
+	Fields      []ExportField  `json:"fields,omitempty" yaml:"fields,omitempty"`
+	Error       string         `json:"error,omitempty" yaml:"error,omitempty"`
+	Truncated   bool           `json:"truncated,omitempty" yaml:"truncated,omitempty"`
+	Data        string         `json:"data,omitempty" yaml:"data,omitempty"`
+	Children    []*ExportChunk `json:"children,omitempty" yaml:"children,omitempty"`
+}
+
+// ExportField is a field of the decoded structure of a chunk.
+type ExportField struct {
+	Name     string        `json:"name" yaml:"name"`
+	Value    string        `json:"value" yaml:"value"`
+	Type     string        `json:"type,omitempty" yaml:"type,omitempty"`
+	Raw      any           `json:"raw,omitempty" yaml:"raw,omitempty"`
+	Unit     string        `json:"unit,omitempty" yaml:"unit,omitempty"`
+	Enum     string        `json:"enum,omitempty" yaml:"enum,omitempty"`
+	Offset   uint32        `json:"offset" yaml:"offset"`
+	Length   uint32        `json:"length" yaml:"length"`
+	Children []ExportField `json:"children,omitempty" yaml:"children,omitempty"`
+}
+
+// ExportOptions controls which data is exported.
+type ExportOptions struct {
+	// IncludeData adds the base64 encoded payload of every data chunk.
+	IncludeData bool
+}
+
+// NewExportDocument converts the chunk tree to its exported representation.
+func NewExportDocument(root *IFFChunk, opts ExportOptions) *ExportDocument {
+	return &ExportDocument{
+		Schema: ExportSchemaVersion,
+		Root:   newExportChunk(root, opts),
+	}
+}
+
+// newExportChunk recursively converts a chunk and its children.
+func newExportChunk(chunk *IFFChunk, opts ExportOptions) *ExportChunk {
+	exp := ExportChunk{
+		ID:         chunk.ID,
+		SubID:      chunk.SubID,
+		Type:       chunk.ChType(),
+		Size:       chunk.Size,
+		SumSize:    chunk.SumSize,
+		Offset:     chunk.Offset,
+		DataOffset: chunk.DataOffset,
+		EndOffset:  chunk.EndOffset,
+		Truncated:  chunk.Truncated,
+	}
+
+	if chunkData, exists := resolveChunkData(chunk.ChType()); exists {
+		exp.Description = chunkData.Description
+		if chunkData.Handler != nil {
+			result, err := chunkData.Handler(chunk.Data)
+			exp.Fields = newExportFields(result)
+			if err != nil {
+				exp.Error = err.Error()
+			}
+		}
+	} else {
+		exp.Description = "(unknown)"
+	}
+
+	if opts.IncludeData && len(chunk.Data) > 0 {
+		exp.Data = base64.StdEncoding.EncodeToString(chunk.Data)
+	}
+
+	for _, child := range chunk.Childs {
+		exp.Children = append(exp.Children, newExportChunk(child, opts))
+	}
+
+	return &exp
+}
+
+// newExportFields recursively converts decoded fields.
+func newExportFields(result StructResult) []ExportField {
+	var fields []ExportField
+	for _, field := range result {
+		fields = append(fields, ExportField{
+			Name:     field.Name,
+			Value:    field.Value,
+			Type:     field.Type(),
+			Raw:      field.Raw,
+			Unit:     field.Unit,
+			Enum:     field.Enum,
+			Offset:   field.Offset,
+			Length:   field.Length,
+			Children: newExportFields(field.Children),
+		})
+	}
+	return fields
+}
+
+// ExportJSON writes the chunk tree as indented JSON to w.
+func ExportJSON(w io.Writer, root *IFFChunk, opts ExportOptions) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(NewExportDocument(root, opts))
+}
+
+// ExportYAML writes the chunk tree as YAML to w.
+func ExportYAML(w io.Writer, root *IFFChunk, opts ExportOptions) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(NewExportDocument(root, opts)); err != nil {
+		return err
+	}
+	return enc.Close()
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestExport(t *testing.T) {
+	_, root := readTestIFF(t, "test2.bsh")
+
+	var tests = []struct {
+		name      string
+		export    func(*bytes.Buffer) error
+		unmarshal func([]byte, any) error
+	}{
+		{"JSON", func(b *bytes.Buffer) error {
+			return ExportJSON(b, root, ExportOptions{IncludeData: true})
+		}, json.Unmarshal},
+		{"YAML", func(b *bytes.Buffer) error {
+			return ExportYAML(b, root, ExportOptions{IncludeData: true})
+		}, yaml.Unmarshal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := tt.export(&buf); err != nil {
+				t.Fatal(err)
+			}
+			var doc ExportDocument
+			if err := tt.unmarshal(buf.Bytes(), &doc); err != nil {
+				t.Fatal(err)
+			}
+
+			if doc.Schema != ExportSchemaVersion {
+				t.Errorf("Schema: got %d, want %d", doc.Schema, ExportSchemaVersion)
+			}
+			if doc.Root.SubID != "ILBM" || len(doc.Root.Children) != 6 {
+				t.Fatalf("Root: got %s with %d children, want ILBM with 6",
+					doc.Root.SubID, len(doc.Root.Children))
+			}
+			body := doc.Root.Children[5]
+			if body.ID != "BODY" || body.Offset != 90 || body.DataOffset != 98 {
+				t.Errorf("BODY: got %s at %d/%d, want BODY at 90/98",
+					body.ID, body.Offset, body.DataOffset)
+			}
+			if body.Data != "AfgAAfgAAfgAAfgAAfgAAfgAAfgA" {
+				t.Errorf("Data: got %s", body.Data)
+			}
+			bmhd := doc.Root.Children[0]
+			if len(bmhd.Fields) == 0 || bmhd.Fields[0].Value != "5 : 7" {
+				t.Errorf("BMHD fields: got %v", bmhd.Fields)
+			}
+		})
+	}
+}
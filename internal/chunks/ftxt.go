@@ -0,0 +1,361 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"image/color"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Control characters of CHRS chunks. The Amiga console accepts the
+// 8-bit CSI as well as ESC [.
+const (
+	ftxtESC = 0x1B
+	ftxtCSI = 0x9B
+)
+
+// Values of the proportional and serif fields of a FONS chunk.
+const (
+	FontUnknown = 0
+	FontNo      = 1
+	FontYes     = 2
+)
+
+// TextPenColors are the colors of the pens 0 to 7 which can be selected
+// with the SGR sequences 30-37 and 40-47. They are the default colors of
+// the Workbench.
+var TextPenColors = [8]color.NRGBA{
+	{0x95, 0x95, 0x95, 0xFF}, {0x00, 0x00, 0x00, 0xFF},
+	{0xFF, 0xFF, 0xFF, 0xFF}, {0x3B, 0x67, 0xA2, 0xFF},
+	{0x7B, 0x7B, 0x7B, 0xFF}, {0xAF, 0xAF, 0xAF, 0xFF},
+	{0xAA, 0x90, 0x7C, 0xFF}, {0xFF, 0xA9, 0x97, 0xFF},
+}
+
+// TextStyle is the rendition of text selected by SGR sequences.
+type TextStyle struct {
+	Bold       bool
+	Italic     bool
+	Underline  bool
+	Inverse    bool
+	Foreground int // pen 0-7 or -1 for the default color
+	Background int // pen 0-7 or -1 for the default color
+	Font       int // ID of the FONS chunk, 0 is the default font
+}
+
+// DefaultTextStyle is the style at the start of a CHRS chunk and after
+// SGR 0.
+var DefaultTextStyle = TextStyle{Foreground: -1, Background: -1}
+
+// TextSpan is a text with the same style.
+type TextSpan struct {
+	Text  string
+	Style TextStyle
+}
+
+// FontSpec is the font specification of a FONS chunk.
+type FontSpec struct {
+	ID           uint8
+	Proportional uint8 // FontUnknown, FontNo or FontYes
+	Serif        uint8 // FontUnknown, FontNo or FontYes
+	Name         string
+}
+
+// FormattedText contains the decoded data of an FTXT FORM.
+type FormattedText struct {
+	Fonts []FontSpec
+	Spans []TextSpan
+}
+
+// ParseFONS decodes a FONS chunk.
+func ParseFONS(data []byte) (FontSpec, error) {
+	var font FontSpec
+	var offset uint32
+	var err error
+
+	//typedef struct {
+	//	UBYTE id;            // 0-9, selected by SGR 10-19
+	//	UBYTE pad1;
+	//	UBYTE proportional;  // 0 = unknown, 1 = no, 2 = yes
+	//	UBYTE serif;         // 0 = unknown, 1 = no, 2 = yes
+	//	char name[];         // zero terminated
+	//} FontSpecifier;
+
+	if font.ID, err = getUbyte(data, &offset); err != nil {
+		return font, err
+	}
+	offset++ // pad1
+	if font.Proportional, err = getUbyte(data, &offset); err != nil {
+		return font, err
+	}
+	if font.Serif, err = getUbyte(data, &offset); err != nil {
+		return font, err
+	}
+	font.Name = cString(data[offset:])
+
+	return font, nil
+}
+
+// ParseCHRS interprets the text of a CHRS chunk. The text is
+// ISO-8859-1, SGR sequences change the style and all other control
+// sequences of the Amiga console are ignored. Form feeds become line
+// feeds.
+func ParseCHRS(data []byte) []TextSpan {
+	spans, _, _ := parseCHRS(data, DefaultTextStyle)
+	return spans
+}
+
+// parseCHRS interprets the text of a CHRS chunk starting with the given
+// style. It returns the spans, the offsets in data where the spans end
+// and the style at the end of the text.
+func parseCHRS(data []byte, style TextStyle) ([]TextSpan, []int, TextStyle) {
+	var spans []TextSpan
+	var ends []int
+	var text strings.Builder
+
+	flush := func(end int) {
+		if text.Len() == 0 {
+			return
+		}
+		if len(spans) > 0 && spans[len(spans)-1].Style == style {
+			spans[len(spans)-1].Text += text.String()
+			ends[len(ends)-1] = end
+		} else {
+			spans = append(spans, TextSpan{text.String(), style})
+			ends = append(ends, end)
+		}
+		text.Reset()
+	}
+
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		seqStart := i
+		switch {
+		case c == ftxtCSI || (c == ftxtESC && i+1 < len(data) && data[i+1] == '['):
+			if c == ftxtESC {
+				i++
+			}
+			// parameters and intermediates up to the final byte
+			start := i + 1
+			for i++; i < len(data) && (data[i] < 0x40 || data[i] > 0x7E); i++ {
+			}
+			if i < len(data) && data[i] == 'm' {
+				flush(seqStart)
+				style = applySGR(style, string(data[start:i]))
+			}
+		case c == ftxtESC:
+			if i+1 < len(data) && data[i+1] == 'c' {
+				flush(seqStart)
+				style = DefaultTextStyle // reset to initial state
+			}
+			i++
+		case c == '\n' || c == '\t':
+			text.WriteByte(c)
+		case c == '\f':
+			text.WriteByte('\n')
+		case c < 0x20 || (c >= 0x7F && c < 0xA0):
+			// other control characters
+		default:
+			text.WriteRune(rune(c)) // ISO-8859-1
+		}
+	}
+	flush(len(data))
+
+	return spans, ends, style
+}
+
+// applySGR returns the style changed by the parameters of an SGR
+// sequence, e.g. "1;31".
+func applySGR(style TextStyle, params string) TextStyle {
+	for _, param := range strings.Split(params, ";") {
+		n, err := strconv.Atoi(param)
+		if param == "" {
+			n, err = 0, nil
+		}
+		if err != nil {
+			continue
+		}
+
+		switch {
+		case n == 0:
+			style = DefaultTextStyle
+		case n == 1:
+			style.Bold = true
+		case n == 3:
+			style.Italic = true
+		case n == 4:
+			style.Underline = true
+		case n == 7:
+			style.Inverse = true
+		case n >= 10 && n <= 19:
+			style.Font = n - 10
+		case n == 22:
+			style.Bold = false
+		case n == 23:
+			style.Italic = false
+		case n == 24:
+			style.Underline = false
+		case n == 27:
+			style.Inverse = false
+		case n >= 30 && n <= 37:
+			style.Foreground = n - 30
+		case n == 39:
+			style.Foreground = -1
+		case n >= 40 && n <= 47:
+			style.Background = n - 40
+		case n == 49:
+			style.Background = -1
+		}
+	}
+	return style
+}
+
+// IsFormattedTextForm returns true for FORMs which can be decoded by
+// DecodeFTXT.
+func IsFormattedTextForm(form *IFFChunk) bool {
+	return form != nil && form.ID == "FORM" && form.SubID == "FTXT"
+}
+
+// DecodeFTXT decodes the FONS and CHRS chunks of an FTXT FORM. The CHRS
+// chunks are concatenated, the style continues from one to the next.
+// In case of an error, the text decoded so far is returned together
+// with the error.
+func DecodeFTXT(form *IFFChunk) (*FormattedText, error) {
+	var ftxt FormattedText
+
+	if !IsFormattedTextForm(form) {
+		return nil, fmt.Errorf("not an FTXT FORM")
+	}
+
+	style := DefaultTextStyle
+	for _, child := range form.Childs {
+		switch child.ID {
+		case "FONS":
+			font, err := ParseFONS(child.Data)
+			if err != nil {
+				return &ftxt, fmt.Errorf("FONS: %w", err)
+			}
+			ftxt.Fonts = append(ftxt.Fonts, font)
+		case "CHRS":
+			var spans []TextSpan
+			spans, _, style = parseCHRS(child.Data, style)
+			for _, span := range spans {
+				last := len(ftxt.Spans) - 1
+				if last >= 0 && ftxt.Spans[last].Style == span.Style {
+					ftxt.Spans[last].Text += span.Text
+				} else {
+					ftxt.Spans = append(ftxt.Spans, span)
+				}
+			}
+		}
+	}
+
+	return &ftxt, nil
+}
+
+// Font returns the font specification with the given ID or nil.
+func (ftxt *FormattedText) Font(id int) *FontSpec {
+	for i := range ftxt.Fonts {
+		if int(ftxt.Fonts[i].ID) == id {
+			return &ftxt.Fonts[i]
+		}
+	}
+	return nil
+}
+
+// PlainText returns the text without styles.
+func (ftxt *FormattedText) PlainText() string {
+	var text strings.Builder
+	for _, span := range ftxt.Spans {
+		text.WriteString(span.Text)
+	}
+	return text.String()
+}
+
+// WriteHTML writes the text as HTML document. Pens are shown in the
+// colors of TextPenColors, fonts by their name with a generic family
+// as fallback.
+func (ftxt *FormattedText) WriteHTML(writer io.Writer, title string) error {
+	out := bufio.NewWriter(writer)
+
+	fmt.Fprintf(out, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(out, "<title>%s</title>\n</head>\n", html.EscapeString(title))
+	css := "white-space: pre-wrap"
+	if font := ftxt.Font(0); font != nil {
+		css += "; font-family: " + fontFamily(font)
+	}
+	fmt.Fprintf(out, "<body>\n<pre style=\"%s\">", html.EscapeString(css))
+	for _, span := range ftxt.Spans {
+		css := ftxt.css(span.Style)
+		if css == "" {
+			out.WriteString(html.EscapeString(span.Text))
+			continue
+		}
+		fmt.Fprintf(out, "<span style=\"%s\">%s</span>", html.EscapeString(css), html.EscapeString(span.Text))
+	}
+	fmt.Fprintf(out, "</pre>\n</body>\n</html>\n")
+
+	return out.Flush()
+}
+
+// css returns the CSS properties of the style.
+func (ftxt *FormattedText) css(style TextStyle) string {
+	var props []string
+
+	if style.Bold {
+		props = append(props, "font-weight: bold")
+	}
+	if style.Italic {
+		props = append(props, "font-style: italic")
+	}
+	if style.Underline {
+		props = append(props, "text-decoration: underline")
+	}
+
+	fg, bg := style.Foreground, style.Background
+	if style.Inverse {
+		// the default colors are pen 1 on pen 0
+		if fg < 0 {
+			fg = 1
+		}
+		if bg < 0 {
+			bg = 0
+		}
+		fg, bg = bg, fg
+	}
+	if fg >= 0 {
+		props = append(props, "color: "+cssColor(TextPenColors[fg]))
+	}
+	if bg >= 0 {
+		props = append(props, "background-color: "+cssColor(TextPenColors[bg]))
+	}
+
+	// the default font is set for the whole text
+	if font := ftxt.Font(style.Font); font != nil && style.Font != 0 {
+		props = append(props, "font-family: "+fontFamily(font))
+	}
+
+	return strings.Join(props, "; ")
+}
+
+// fontFamily returns the CSS font family of the font with a generic
+// family as fallback.
+func fontFamily(font *FontSpec) string {
+	family := "serif"
+	if font.Proportional == FontNo {
+		family = "monospace"
+	} else if font.Serif == FontNo {
+		family = "sans-serif"
+	}
+	return fmt.Sprintf("'%s', %s", strings.TrimSuffix(font.Name, ".font"), family)
+}
+
+// cssColor formats a color as #RRGGBB.
+func cssColor(c color.NRGBA) string {
+	return fmt.Sprintf("#%02X%02X%02X", c.R, c.G, c.B)
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseCHRS(t *testing.T) {
+	bold := DefaultTextStyle
+	bold.Bold = true
+	colored := DefaultTextStyle
+	colored.Foreground = 3
+	colored.Background = 2
+	italicFont := DefaultTextStyle
+	italicFont.Italic = true
+	italicFont.Font = 1
+
+	var tests = []struct {
+		name string
+		data string
+		want []TextSpan
+	}{
+		{"Plain", "Hello\n\tW\xe4lt", []TextSpan{{"Hello\n\tWält", DefaultTextStyle}}},
+		{"CSI", "a\x9b1mb\x9b0mc", []TextSpan{
+			{"a", DefaultTextStyle}, {"b", bold}, {"c", DefaultTextStyle}}},
+		{"ESC", "\x1b[1mb\x1b[22m\x1b[mc", []TextSpan{{"b", bold}, {"c", DefaultTextStyle}}},
+		{"Colors", "\x9b33;42mx", []TextSpan{{"x", colored}}},
+		{"Font", "\x9b3;11mi\x1bcn", []TextSpan{{"i", italicFont}, {"n", DefaultTextStyle}}},
+		{"Ignored", "a\x9b2Jb\x07\rc\fd\x9b", []TextSpan{{"abc\nd", DefaultTextStyle}}},
+		{"Merged", "a\x9b1m\x9b22mb", []TextSpan{{"ab", DefaultTextStyle}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseCHRS([]byte(tt.data))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFONS(t *testing.T) {
+	got, err := ParseFONS([]byte{1, 0, FontNo, FontYes, 't', 'o', 'p', 'a', 'z', '.', 'f', 'o', 'n', 't', 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := FontSpec{1, FontNo, FontYes, "topaz.font"}
+	if got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	if _, err := ParseFONS([]byte{1, 0, 2}); err == nil {
+		t.Errorf("Short: got no error, want error")
+	}
+}
+
+// makeTestFTXT returns an FTXT FORM with a FONS chunk and two CHRS chunks.
+func makeTestFTXT() *IFFChunk {
+	return &IFFChunk{ID: "FORM", SubID: "FTXT", Childs: []*IFFChunk{
+		{ID: "FONS", Data: []byte{1, 0, FontNo, FontNo, 'c', 'o', 'u', 'r', 'i', 'e', 'r', '.', 'f', 'o', 'n', 't', 0}},
+		{ID: "CHRS", Data: []byte("Title: \x9b1;31m<Bold>")},
+		{ID: "CHRS", Data: []byte(" & more\x9b0m\n\x9b7;11mcode")},
+	}}
+}
+
+func TestDecodeFTXT(t *testing.T) {
+	ftxt, err := DecodeFTXT(makeTestFTXT())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ftxt.Fonts) != 1 || ftxt.Font(1) == nil || ftxt.Font(1).Name != "courier.font" {
+		t.Errorf("Fonts: got %+v", ftxt.Fonts)
+	}
+	if got, want := ftxt.PlainText(), "Title: <Bold> & more\ncode"; got != want {
+		t.Errorf("PlainText: got %q, want %q", got, want)
+	}
+	if len(ftxt.Spans) != 4 || ftxt.Spans[1].Text != "<Bold> & more" {
+		t.Errorf("Spans: got %+v", ftxt.Spans)
+	}
+
+	var buf bytes.Buffer
+	if err := ftxt.WriteHTML(&buf, "test"); err != nil {
+		t.Fatal(err)
+	}
+	html := buf.String()
+	var want = []string{
+		"<title>test</title>",
+		`<span style="font-weight: bold; color: #000000">&lt;Bold&gt; &amp; more</span>`,
+		`<span style="color: #959595; background-color: #000000; font-family: &#39;courier&#39;, monospace">code</span>`,
+	}
+	for _, w := range want {
+		if !strings.Contains(html, w) {
+			t.Errorf("HTML doesn't contain %q:\n%s", w, html)
+		}
+	}
+
+	if _, err := DecodeFTXT(&IFFChunk{ID: "FORM", SubID: "ILBM"}); err == nil {
+		t.Errorf("ILBM: got no error, want error")
+	}
+}
+
+func TestHandleFtxt(t *testing.T) {
+	_, result, err := GetStructData("FTXT.FONS", makeTestFTXT().Childs[0].Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []fieldRow{{"ID", "1 (SGR 11)", 0, 1}, {"Proportional", "No", 2, 1}, {"Serif", "No", 3, 1},
+		{"Name", "courier.font", 4, 13}}
+	if got := fieldRows(result); !slices.Equal(got, want) {
+		t.Errorf("FONS: got %v, want %v", got, want)
+	}
+
+	_, result, err = GetStructData("(any).CHRS", []byte("a\x9b1;4mb"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = []fieldRow{{"Text", "a", 0, 1}, {"Bold, Underline", "b", 1, 6}}
+	if got := fieldRows(result); !slices.Equal(got, want) {
+		t.Errorf("CHRS: got %v, want %v", got, want)
+	}
+}
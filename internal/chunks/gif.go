@@ -0,0 +1,38 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"fmt"
+	"image"
+	"image/gif"
+	"io"
+	"time"
+)
+
+// EncodeGIF writes the frames of the animation as animated GIF which
+// loops forever. Frames with more than 256 colors, e.g. HAM, are reduced
+// with QuantizeImage.
+func EncodeGIF(writer io.Writer, anim *Animation) error {
+	var g gif.GIF
+
+	if len(anim.Frames) == 0 {
+		return fmt.Errorf("animation has no frames")
+	}
+
+	for _, frame := range anim.Frames {
+		img := frame.Picture.Image()
+		paletted, ok := img.(*image.Paletted)
+		if !ok || len(paletted.Palette) > 256 {
+			paletted = QuantizeImage(img, 256)
+		}
+		g.Image = append(g.Image, paletted)
+
+		// GIF delays are given in 1/100 seconds
+		g.Delay = append(g.Delay, max(int((frame.Delay+5*time.Millisecond)/(10*time.Millisecond)), 1))
+		g.Disposal = append(g.Disposal, gif.DisposalNone)
+	}
+
+	return gif.EncodeAll(writer, &g)
+}
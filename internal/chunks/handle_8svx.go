@@ -4,7 +4,6 @@
 package chunks
 
 import (
-	"fmt"
 	"log"
 )
 
@@ -30,35 +29,35 @@ func handle8svxVhdr(data []byte) (StructResult, error) {
 	if err != nil {
 		return result, err
 	}
-	result = append(result, [2]string{"One Shot Hi Samples", fmt.Sprintf("%d", oneShotHiSamples)})
+	result.add("One Shot Hi Samples", oneShotHiSamples, offset)
 
 	// handle repeatHiSamples
 	repeatHiSamples, err := getBeUlong(data, &offset)
 	if err != nil {
 		return result, err
 	}
-	result = append(result, [2]string{"Repeat Hi Samples", fmt.Sprintf("%d", repeatHiSamples)})
+	result.add("Repeat Hi Samples", repeatHiSamples, offset)
 
 	// handle samplesPerHiCycle
 	samplesPerHiCycle, err := getBeUlong(data, &offset)
 	if err != nil {
 		return result, err
 	}
-	result = append(result, [2]string{"Samples Per Hi Cycle", fmt.Sprintf("%d", samplesPerHiCycle)})
+	result.add("Samples Per Hi Cycle", samplesPerHiCycle, offset)
 
 	// handle samplesPerSec
 	samplesPerSec, err := getBeUword(data, &offset)
 	if err != nil {
 		return result, err
 	}
-	result = append(result, [2]string{"Samples Per Sec", fmt.Sprintf("%d", samplesPerSec)})
+	result.add("Samples Per Sec", samplesPerSec, offset)
 
 	// handle ctOctave
 	ctOctave, err := getUbyte(data, &offset)
 	if err != nil {
 		return result, err
 	}
-	result = append(result, [2]string{"Octave", fmt.Sprintf("%d", ctOctave)})
+	result.add("Octave", ctOctave, offset)
 
 	// handle sCompression
 	sCompression, err := getUbyte(data, &offset)
@@ -67,17 +66,19 @@ func handle8svxVhdr(data []byte) (StructResult, error) {
 	}
 	switch sCompression {
 	case 0:
-		result = append(result, [2]string{"Compression", "None"})
+		result.add("Compression", withEnum(sCompression, "None"), offset)
 	case 1:
-		result = append(result, [2]string{"Compression", "Fibonacci-Delta-Encoded"})
+		result.add("Compression", withEnum(sCompression, "Fibonacci-Delta-Encoded"), offset)
+	default:
+		result.add("Compression", withFormat(sCompression, "Unknown (%d)"), offset)
 	}
 
 	// handle volume
-	volume, err := getBeLong(data, &offset) // TODO: handle Fixed type (16 bit left, 16 bit right)
+	volume, err := getBeLong(data, &offset)
 	if err != nil {
 		return result, err
 	}
-	result = append(result, [2]string{"Volume", fmt.Sprintf("%d", volume)})
+	result.add("Volume", fixedValue(volume), offset)
 
 	return result, nil
 }
@@ -99,14 +100,21 @@ func handle8svxAtakRlse(data []byte) (StructResult, error) {
 	if err != nil {
 		return result, err
 	}
-	result = append(result, [2]string{"Duration", fmt.Sprintf("%d", duration)})
+	result.add("Duration", withUnit(duration, "ms"), offset)
 
 	// handle dest
-	dest, err := getBeLong(data, &offset) // TODO: handle Fixed type (16 bit left, 16 bit right)
+	dest, err := getBeLong(data, &offset)
 	if err != nil {
 		return result, err
 	}
-	result = append(result, [2]string{"Dest", fmt.Sprintf("%d", dest)})
+	result.add("Dest", fixedValue(dest), offset)
 
 	return result, nil
 }
+
+// fixedValue converts a Fixed value with 16 bit integer and 16 bit
+// fractional part into a float, e.g. the volume 0x10000 into 1.0. It's
+// formatted with four decimals.
+func fixedValue(value int32) fieldValue {
+	return withFormat(float64(value)/SoundVolumeUnity, "%.4f")
+}
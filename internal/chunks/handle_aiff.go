@@ -0,0 +1,418 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+// aifcVersion1 is the timestamp of the FVER chunk for version 1 of AIFC.
+const aifcVersion1 = 0xA2805140
+
+// aiffEpoch is the start of the timestamps of AIFF, which count the
+// seconds since 1 January 1904.
+var aiffEpoch = time.Date(1904, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// aifcCompressions contains the descriptions of well known compression
+// types of AIFC.
+var aifcCompressions = map[string]string{
+	"NONE": "Not Compressed",
+	"twos": "Not Compressed (Signed)",
+	"sowt": "Not Compressed (Little Endian)",
+	"raw ": "Not Compressed (Unsigned)",
+	"fl32": "32 Bit Floating Point",
+	"FL32": "32 Bit Floating Point",
+	"fl64": "64 Bit Floating Point",
+	"FL64": "64 Bit Floating Point",
+	"ulaw": "µ-Law 2:1",
+	"ULAW": "µ-Law 2:1",
+	"alaw": "A-Law 2:1",
+	"ALAW": "A-Law 2:1",
+	"ima4": "IMA ADPCM 4:1",
+	"MAC3": "MACE 3:1",
+	"MAC6": "MACE 6:1",
+	"ACE2": "ACE 2:1",
+	"ACE8": "ACE 8:3",
+	"GSM ": "GSM",
+	"Qclp": "Qualcomm PureVoice",
+}
+
+// aiffPlayModes contains the names of the play modes of a loop.
+var aiffPlayModes = []string{"No Looping", "Forward Looping", "Forward Backward Looping"}
+
+// handleAiffComm processes the AIFF.COMM chunk.
+func handleAiffComm(data []byte) (StructResult, error) {
+	log.Println("Handling AIFF.COMM chunk")
+
+	return handleComm(data, false)
+}
+
+// handleAifcComm processes the AIFC.COMM chunk.
+func handleAifcComm(data []byte) (StructResult, error) {
+	log.Println("Handling AIFC.COMM chunk")
+
+	return handleComm(data, true)
+}
+
+// handleComm processes the COMM chunk of AIFF and AIFC.
+func handleComm(data []byte, isAIFC bool) (StructResult, error) {
+	//typedef struct {
+	//	short         numChannels;
+	//	unsigned long numSampleFrames;
+	//	short         sampleSize;
+	//	extended      sampleRate;
+	//	ID            compressionType;  // only AIFC
+	//	pstring       compressionName;  // only AIFC
+	//} CommonChunk;
+
+	var offset uint32
+	var result StructResult
+
+	// handle numChannels
+	numChannels, err := getBeWord(data, &offset)
+	if err != nil {
+		return result, err
+	}
+	result.add("Channels", numChannels, offset)
+
+	// handle numSampleFrames
+	numSampleFrames, err := getBeUlong(data, &offset)
+	if err != nil {
+		return result, err
+	}
+	result.add("Sample Frames", numSampleFrames, offset)
+
+	// handle sampleSize
+	sampleSize, err := getBeWord(data, &offset)
+	if err != nil {
+		return result, err
+	}
+	result.add("Sample Size", withUnit(sampleSize, "bit"), offset)
+
+	// handle sampleRate
+	rateData, err := getByteBuffer(data, &offset, 10)
+	if err != nil {
+		return result, err
+	}
+	sampleRate, err := DecodeExtended(rateData)
+	if err != nil {
+		return result, err
+	}
+	result.add("Sample Rate", withUnit(sampleRate, "Hz"), offset)
+	if sampleRate > 0 {
+		duration := time.Duration(float64(numSampleFrames) / sampleRate * float64(time.Second))
+		result.add("Duration", duration.Round(time.Millisecond), offset)
+	}
+
+	if !isAIFC {
+		return result, nil
+	}
+
+	// handle compressionType
+	compressionType, err := getStringBuffer(data, &offset, 4)
+	if err != nil {
+		return result, err
+	}
+	description, exists := aifcCompressions[compressionType]
+	if !exists {
+		description = "Unknown"
+	}
+	result.add("Compression Type", fieldValue{raw: compressionType, enum: description,
+		text: fmt.Sprintf("%q (%s)", compressionType, description)}, offset)
+
+	// handle compressionName
+	compressionName, err := getPString(data, &offset)
+	if err != nil {
+		return result, err
+	}
+	result.add("Compression Name", compressionName, offset)
+
+	return result, nil
+}
+
+// handleAiffSsnd processes the AIFF.SSND or AIFC.SSND chunk.
+func handleAiffSsnd(data []byte) (StructResult, error) {
+	log.Println("Handling AIFF.SSND or AIFC.SSND chunk")
+
+	//typedef struct {
+	//	unsigned long offset;
+	//	unsigned long blockSize;
+	//	unsigned char soundData[];
+	//} SoundDataChunk;
+
+	var offset uint32
+	var result StructResult
+
+	// handle offset
+	dataOffset, err := getBeUlong(data, &offset)
+	if err != nil {
+		return result, err
+	}
+	result.add("Offset", dataOffset, offset)
+
+	// handle blockSize
+	blockSize, err := getBeUlong(data, &offset)
+	if err != nil {
+		return result, err
+	}
+	result.add("Block Size", blockSize, offset)
+
+	// handle soundData
+	if uint64(offset)+uint64(dataOffset) > uint64(len(data)) {
+		return result, fmt.Errorf("offset is beyond the end of the chunk")
+	}
+	size := uint32(len(data)) - offset - dataOffset
+	result.addField("Sound Data", withUnit(size, "bytes"), offset+dataOffset, size)
+
+	return result, nil
+}
+
+// handleAiffMark processes the AIFF.MARK or AIFC.MARK chunk.
+func handleAiffMark(data []byte) (StructResult, error) {
+	log.Println("Handling AIFF.MARK or AIFC.MARK chunk")
+
+	//typedef struct {
+	//	MarkerId      id;
+	//	unsigned long position;
+	//	pstring       markerName;
+	//} Marker;
+	//
+	//typedef struct {
+	//	unsigned short numMarkers;
+	//	Marker         markers[];
+	//} MarkerChunk;
+
+	var offset uint32
+	var result StructResult
+
+	// handle numMarkers
+	numMarkers, err := getBeUword(data, &offset)
+	if err != nil {
+		return result, err
+	}
+
+	// handle markers, the pstring of the name is padded to an even size
+	var fields StructResult
+	markers, err := ParseAIFFMarkers(data)
+	for _, marker := range markers {
+		length := min(6+uint32(len(marker.Name)+2)&^1, uint32(len(data))-offset)
+		fields.addField(fmt.Sprintf("Marker %d", marker.ID),
+			withText(marker, fmt.Sprintf("%q at %d", marker.Name, marker.Position)), offset, length)
+		offset += length
+	}
+	result.addGroup("Markers", numMarkers, 0, fields)
+
+	return result, err
+}
+
+// handleAiffInst processes the AIFF.INST or AIFC.INST chunk.
+func handleAiffInst(data []byte) (StructResult, error) {
+	log.Println("Handling AIFF.INST or AIFC.INST chunk")
+
+	//typedef struct {
+	//	short    playMode;
+	//	MarkerId beginLoop;
+	//	MarkerId endLoop;
+	//} Loop;
+	//
+	//typedef struct {
+	//	char  baseNote;
+	//	char  detune;
+	//	char  lowNote;
+	//	char  highNote;
+	//	char  lowVelocity;
+	//	char  highVelocity;
+	//	short gain;
+	//	Loop  sustainLoop;
+	//	Loop  releaseLoop;
+	//} InstrumentChunk;
+
+	var offset uint32
+	var result StructResult
+
+	for _, name := range []string{"Base Note", "Detune", "Low Note", "High Note",
+		"Low Velocity", "High Velocity"} {
+
+		value, err := getByte(data, &offset)
+		if err != nil {
+			return result, err
+		}
+		result.add(name, value, offset)
+	}
+
+	// handle gain
+	gain, err := getBeWord(data, &offset)
+	if err != nil {
+		return result, err
+	}
+	result.add("Gain", withUnit(gain, "dB"), offset)
+
+	// handle sustainLoop and releaseLoop
+	for _, loop := range []string{"Sustain Loop", "Release Loop"} {
+		playMode, err := getBeWord(data, &offset)
+		if err != nil {
+			return result, err
+		}
+		mode := withFormat(playMode, "Unknown (%d)")
+		if playMode >= 0 && int(playMode) < len(aiffPlayModes) {
+			mode = withEnum(playMode, aiffPlayModes[playMode])
+		}
+		result.add(loop+" Play Mode", mode, offset)
+
+		beginLoop, err := getBeWord(data, &offset)
+		if err != nil {
+			return result, err
+		}
+		result.add(loop+" Begin Marker", beginLoop, offset)
+
+		endLoop, err := getBeWord(data, &offset)
+		if err != nil {
+			return result, err
+		}
+		result.add(loop+" End Marker", endLoop, offset)
+	}
+
+	return result, nil
+}
+
+// handleAiffComt processes the AIFF.COMT or AIFC.COMT chunk.
+func handleAiffComt(data []byte) (StructResult, error) {
+	log.Println("Handling AIFF.COMT or AIFC.COMT chunk")
+
+	//typedef struct {
+	//	unsigned long  timeStamp;
+	//	MarkerId       marker;
+	//	unsigned short count;
+	//	char           text[];
+	//} Comment;
+	//
+	//typedef struct {
+	//	unsigned short numComments;
+	//	Comment        comments[];
+	//} CommentsChunk;
+
+	var offset uint32
+	var result StructResult
+
+	// handle numComments
+	numComments, err := getBeUword(data, &offset)
+	if err != nil {
+		return result, err
+	}
+
+	// handle comments, each comment is a group of its fields
+	var comments StructResult
+	readComment := func(i int) error {
+		start := offset
+		timeStamp, err := getBeUlong(data, &offset)
+		if err != nil {
+			return err
+		}
+		marker, err := getBeWord(data, &offset)
+		if err != nil {
+			return err
+		}
+		count, err := getBeUword(data, &offset)
+		if err != nil {
+			return err
+		}
+		text, err := getStringBuffer(data, &offset, uint32(count))
+		if err != nil {
+			return err
+		}
+		offset += uint32(count % 2) // pad byte
+
+		var fields StructResult
+		fields.addField("Time", withText(aiffTime(timeStamp), formatAiffTime(timeStamp)), start, 4)
+		if marker != 0 {
+			fields.addField("Marker", marker, start+4, 2)
+		}
+		fields.addField("Text", text, start+6, 2+uint32(count))
+		comments.addGroup(fmt.Sprintf("Comment %d", i), nil, start, fields)
+		return nil
+	}
+	for i := 1; i <= int(numComments) && err == nil; i++ {
+		err = readComment(i)
+	}
+	result.addGroup("Comments", numComments, 0, comments)
+
+	return result, err
+}
+
+// handleAiffAppl processes the AIFF.APPL or AIFC.APPL chunk.
+func handleAiffAppl(data []byte) (StructResult, error) {
+	log.Println("Handling AIFF.APPL or AIFC.APPL chunk")
+
+	//typedef struct {
+	//	OSType applicationSignature;
+	//	char   data[];
+	//} ApplicationSpecificChunk;
+
+	var offset uint32
+	var result StructResult
+
+	// handle applicationSignature
+	signature, err := getStringBuffer(data, &offset, 4)
+	if err != nil {
+		return result, err
+	}
+	result.add("Application Signature", withFormat(signature, "%q"), offset)
+
+	// Apple II applications start the data with their name
+	if signature == "pdos" || signature == "stoc" {
+		name, err := getPString(data, &offset)
+		if err != nil {
+			return result, err
+		}
+		result.add("Application Name", name, offset)
+	}
+
+	// handle data
+	remaining := 0
+	if int(offset) < len(data) {
+		remaining = len(data) - int(offset)
+	}
+	result.addField("Data", withUnit(remaining, "bytes"), offset, uint32(remaining))
+
+	return result, nil
+}
+
+// handleAifcFver processes the AIFC.FVER chunk.
+func handleAifcFver(data []byte) (StructResult, error) {
+	log.Println("Handling AIFC.FVER chunk")
+
+	//typedef struct {
+	//	unsigned long timestamp;
+	//} FormatVersionChunk;
+
+	var offset uint32
+	var result StructResult
+
+	// handle timestamp
+	timestamp, err := getBeUlong(data, &offset)
+	if err != nil {
+		return result, err
+	}
+	version := "Unknown"
+	if timestamp == aifcVersion1 {
+		version = "AIFC Version 1"
+	}
+	result.add("Timestamp", withText(timestamp, fmt.Sprintf("0x%08X (%s)", timestamp, formatAiffTime(timestamp))), offset)
+	result.add("Version", version, offset)
+
+	return result, nil
+}
+
+// aiffTime converts a timestamp of AIFF, which counts the seconds since
+// 1 January 1904.
+func aiffTime(timestamp uint32) time.Time {
+	return aiffEpoch.Add(time.Duration(timestamp) * time.Second)
+}
+
+// formatAiffTime formats a timestamp of AIFF.
+func formatAiffTime(timestamp uint32) string {
+	return aiffTime(timestamp).Format(time.DateTime)
+}
@@ -4,7 +4,6 @@
 package chunks
 
 import (
-	"fmt"
 	"log"
 )
 
@@ -32,33 +31,37 @@ func handleAnimAnhd(data []byte) (StructResult, error) {
 	// handle operation
 	operation, err := getUbyte(data, &offset)
 	if err != nil {
-		return result, nil
+		return result, err
 	}
 	switch operation {
-	case 0:
-		result = append(result, [2]string{"Operation", "Direct"})
-	case 1:
-		result = append(result, [2]string{"Operation", "XOR"})
-	case 2:
-		result = append(result, [2]string{"Operation", "Long Delta"})
-	case 3:
-		result = append(result, [2]string{"Operation", "Short Delta"})
-	case 4:
-		result = append(result, [2]string{"Operation", "Short/Long Delta"})
-	case 5:
-		result = append(result, [2]string{"Operation", "Byte Vertical Delta"})
-	case 6:
-		result = append(result, [2]string{"Operation", "Stereo Op 5"})
-	case 7:
-		result = append(result, [2]string{"Operation", "Short/Long Vertical Delta"})
-	case 74:
-		result = append(result, [2]string{"Operation", "Graham"})
+	case AnimOpDirect:
+		result.add("Operation", withEnum(operation, "Direct"), offset)
+	case AnimOpXOR:
+		result.add("Operation", withEnum(operation, "XOR"), offset)
+	case AnimOpLongDelta:
+		result.add("Operation", withEnum(operation, "Long Delta"), offset)
+	case AnimOpShortDelta:
+		result.add("Operation", withEnum(operation, "Short Delta"), offset)
+	case AnimOpShortLongDelta:
+		result.add("Operation", withEnum(operation, "Short/Long Delta"), offset)
+	case AnimOpByteVertical:
+		result.add("Operation", withEnum(operation, "Byte Vertical Delta"), offset)
+	case AnimOpStereo:
+		result.add("Operation", withEnum(operation, "Stereo Op 5"), offset)
+	case AnimOpShortLongVertical:
+		result.add("Operation", withEnum(operation, "Short/Long Vertical Delta"), offset)
+	case AnimOpShortLongVerticalOps:
+		result.add("Operation", withEnum(operation, "Short/Long Vertical Delta (Word/Long Opcodes)"), offset)
+	case AnimOpGraham:
+		result.add("Operation", withEnum(operation, "Graham (J)"), offset)
+	default:
+		result.add("Operation", withFormat(operation, "Unknown (%d)"), offset)
 	}
 	mask, err := getUbyte(data, &offset)
 	if err != nil {
 		return result, err
 	}
-	result = append(result, [2]string{"Mask", fmt.Sprintf("%d", mask)})
+	result.add("Mask", mask, offset)
 
 	// handle w,h
 	w, err := getBeUword(data, &offset)
@@ -69,7 +72,7 @@ func handleAnimAnhd(data []byte) (StructResult, error) {
 	if err != nil {
 		return result, err
 	}
-	result = append(result, [2]string{"Width : Height", fmt.Sprintf("%d : %d", w, h)})
+	result.add("Width : Height", withTuple("%d : %d", w, h), offset)
 
 	// handle x,y
 	x, err := getBeWord(data, &offset)
@@ -80,28 +83,28 @@ func handleAnimAnhd(data []byte) (StructResult, error) {
 	if err != nil {
 		return result, err
 	}
-	result = append(result, [2]string{"Position x : y", fmt.Sprintf("%d : %d", x, y)})
+	result.add("Position x : y", withTuple("%d : %d", x, y), offset)
 
 	// handle abstime
 	abstime, err := getBeUlong(data, &offset)
 	if err != nil {
 		return result, err
 	}
-	result = append(result, [2]string{"Absolute Time", fmt.Sprintf("%d", abstime)})
+	result.add("Absolute Time", abstime, offset)
 
 	// handle reltime
 	reltime, err := getBeUlong(data, &offset)
 	if err != nil {
 		return result, err
 	}
-	result = append(result, [2]string{"Relative Time", fmt.Sprintf("%d", reltime)})
+	result.add("Relative Time", reltime, offset)
 
 	// handle interleave
 	interleave, err := getUbyte(data, &offset)
 	if err != nil {
 		return result, err
 	}
-	result = append(result, [2]string{"Interleave", fmt.Sprintf("%d", interleave)})
+	result.add("Interleave", interleave, offset)
 
 	offset++ // ignore pad0
 
@@ -121,39 +124,39 @@ func handleAnimAnhd(data []byte) (StructResult, error) {
 	   5      short info offsets  long info offsets
 	*/
 	if bits&(1<<0) == 0 { // bit 0 not set
-		result = append(result, [2]string{"Bit 0", "Short Data"})
+		result.addField("Bit 0", withEnum(bits&(1<<0) != 0, "Short Data"), offset-4, 4)
 	} else {
-		result = append(result, [2]string{"Bit 0", "Long Data"})
+		result.addField("Bit 0", withEnum(bits&(1<<0) != 0, "Long Data"), offset-4, 4)
 	}
 
 	if bits&(1<<1) == 0 {
-		result = append(result, [2]string{"Bit 1", "Store"})
+		result.add("Bit 1", withEnum(bits&(1<<1) != 0, "Store"), offset)
 	} else {
-		result = append(result, [2]string{"Bit 1", "XOR"})
+		result.add("Bit 1", withEnum(bits&(1<<1) != 0, "XOR"), offset)
 	}
 
 	if bits&(1<<2) == 0 {
-		result = append(result, [2]string{"Bit 2", "Separate Info"})
+		result.add("Bit 2", withEnum(bits&(1<<2) != 0, "Separate Info"), offset)
 	} else {
-		result = append(result, [2]string{"Bit 2", "One Info for All"})
+		result.add("Bit 2", withEnum(bits&(1<<2) != 0, "One Info for All"), offset)
 	}
 
 	if bits&(1<<3) == 0 {
-		result = append(result, [2]string{"Bit 3", "Not RLC"})
+		result.add("Bit 3", withEnum(bits&(1<<3) != 0, "Not RLC"), offset)
 	} else {
-		result = append(result, [2]string{"Bit 3", "RLC"})
+		result.add("Bit 3", withEnum(bits&(1<<3) != 0, "RLC"), offset)
 	}
 
 	if bits&(1<<4) == 0 {
-		result = append(result, [2]string{"Bit 4", "Horizontal"})
+		result.add("Bit 4", withEnum(bits&(1<<4) != 0, "Horizontal"), offset)
 	} else {
-		result = append(result, [2]string{"Bit 4", "Vertical"})
+		result.add("Bit 4", withEnum(bits&(1<<4) != 0, "Vertical"), offset)
 	}
 
 	if bits&(1<<5) == 0 {
-		result = append(result, [2]string{"Bit 5", "Short Info Offsets"})
+		result.add("Bit 5", withEnum(bits&(1<<5) != 0, "Short Info Offsets"), offset)
 	} else {
-		result = append(result, [2]string{"Bit 5", "Long Info Offsets"})
+		result.add("Bit 5", withEnum(bits&(1<<5) != 0, "Long Info Offsets"), offset)
 	}
 
 	return result, nil
@@ -177,21 +180,21 @@ func handleAnimDpan(data []byte) (StructResult, error) {
 	if err != nil {
 		return result, err
 	}
-	result = append(result, [2]string{"Version", fmt.Sprintf("%d", version)})
+	result.add("Version", version, offset)
 
 	// handle nframes
 	nframes, err := getBeUword(data, &offset)
 	if err != nil {
 		return result, err
 	}
-	result = append(result, [2]string{"Number of Frames", fmt.Sprintf("%d", nframes)})
+	result.add("Number of Frames", nframes, offset)
 
 	// handle flags
 	flags, err := getBeUlong(data, &offset)
 	if err != nil {
 		return result, err
 	}
-	result = append(result, [2]string{"Flags", fmt.Sprintf("%032b", flags)})
+	result.add("Flags", withFormat(flags, "%032b"), offset)
 
 	return result, nil
 }
@@ -15,7 +15,7 @@ func handleAnyIso8859(data []byte) (StructResult, error) {
 	if err != nil {
 		return result, err
 	}
-	result = append(result, [2]string{"String", string(decoded)})
+	result.addField("String", string(decoded), 0, uint32(len(data)))
 
 	return result, nil
 }
@@ -24,7 +24,7 @@ func handleAnyIso8859(data []byte) (StructResult, error) {
 func handleAnyUtf8(data []byte) (StructResult, error) {
 	var result StructResult
 
-	result = append(result, [2]string{"String", string(data)})
+	result.addField("String", string(data), 0, uint32(len(data)))
 
 	return result, nil
 }
@@ -0,0 +1,58 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"fmt"
+	"log"
+)
+
+// handleCtlgCset processes the CTLG.CSET chunk.
+func handleCtlgCset(data []byte) (StructResult, error) {
+	log.Println("Handling CTLG.CSET chunk")
+
+	//typedef struct {
+	//	ULONG CodeSet;
+	//	ULONG Reserved[7];
+	//} CSETChunk;
+
+	var result StructResult
+
+	// handle CodeSet
+	codeSet, err := ParseCatalogCodeSet(data)
+	if err != nil {
+		return result, err
+	}
+	result.addField("Code Set", fieldValue{raw: codeSet, enum: CodeSetName(codeSet),
+		text: fmt.Sprintf("%d (%s)", codeSet, CodeSetName(codeSet))}, 0, 4)
+
+	return result, nil
+}
+
+// handleCtlgStrs processes the CTLG.STRS chunk. The texts are decoded as
+// ISO-8859-1 because the handler doesn't know the CSET chunk.
+func handleCtlgStrs(data []byte) (StructResult, error) {
+	log.Println("Handling CTLG.STRS chunk")
+
+	//typedef struct {
+	//	ULONG ID;
+	//	ULONG Length;
+	//	UBYTE Text[];    // padded to a multiple of 4 bytes
+	//} StringEntry;
+
+	var offset uint32
+	var result StructResult
+	var strs StructResult
+
+	entries, err := ParseCatalogStrings(data, 0)
+	for _, entry := range entries {
+		length := 8 + (entry.Length+3)&^3
+		strs.addField(fmt.Sprintf("ID %d (%d bytes)", entry.ID, entry.Length),
+			withText(entry, entry.Text), offset, min(length, uint32(len(data))-offset))
+		offset += length
+	}
+	result.addGroup("Strings", len(entries), 0, strs)
+
+	return result, err
+}
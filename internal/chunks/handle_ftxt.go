@@ -0,0 +1,103 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// fontFlags contains the descriptions of the proportional and serif
+// fields of a FONS chunk.
+var fontFlags = map[uint8]string{
+	FontUnknown: "Unknown",
+	FontNo:      "No",
+	FontYes:     "Yes",
+}
+
+// handleFtxtFons processes the FTXT.FONS chunk.
+func handleFtxtFons(data []byte) (StructResult, error) {
+	log.Println("Handling FTXT.FONS chunk")
+
+	//typedef struct {
+	//	UBYTE id;            // 0-9, selected by SGR 10-19
+	//	UBYTE pad1;
+	//	UBYTE proportional;  // 0 = unknown, 1 = no, 2 = yes
+	//	UBYTE serif;         // 0 = unknown, 1 = no, 2 = yes
+	//	char name[];         // zero terminated
+	//} FontSpecifier;
+
+	var result StructResult
+
+	font, err := ParseFONS(data)
+	if err != nil {
+		return result, err
+	}
+	result.addField("ID", withText(font.ID, fmt.Sprintf("%d (SGR %d)", font.ID, 10+int(font.ID))), 0, 1)
+	result.addField("Proportional", withEnum(font.Proportional, describeFontFlag(font.Proportional)), 2, 1)
+	result.addField("Serif", withEnum(font.Serif, describeFontFlag(font.Serif)), 3, 1)
+	result.addField("Name", font.Name, 4, uint32(len(data)-4))
+
+	return result, nil
+}
+
+// describeFontFlag returns the description of the proportional or serif
+// field of a FONS chunk.
+func describeFontFlag(flag uint8) string {
+	if text, exists := fontFlags[flag]; exists {
+		return text
+	}
+	return fmt.Sprintf("Reserved (%d)", flag)
+}
+
+// handleAnyChrs processes CHRS chunks. The text is shown without the
+// control sequences, one row per span with the style of the span.
+func handleAnyChrs(data []byte) (StructResult, error) {
+	log.Println("Handling (any).CHRS chunk")
+
+	var result StructResult
+
+	// each span gets the bytes of its text and the preceding control
+	// sequences
+	spans, ends, _ := parseCHRS(data, DefaultTextStyle)
+	for i, span := range spans {
+		result.add(describeTextStyle(span.Style), withText(span, span.Text), uint32(ends[i]))
+	}
+
+	return result, nil
+}
+
+// describeTextStyle returns a short description of a text style, e.g.
+// "Bold, Pen 3".
+func describeTextStyle(style TextStyle) string {
+	var parts []string
+
+	if style.Bold {
+		parts = append(parts, "Bold")
+	}
+	if style.Italic {
+		parts = append(parts, "Italic")
+	}
+	if style.Underline {
+		parts = append(parts, "Underline")
+	}
+	if style.Inverse {
+		parts = append(parts, "Inverse")
+	}
+	if style.Foreground >= 0 {
+		parts = append(parts, fmt.Sprintf("Pen %d", style.Foreground))
+	}
+	if style.Background >= 0 {
+		parts = append(parts, fmt.Sprintf("Background %d", style.Background))
+	}
+	if style.Font != 0 {
+		parts = append(parts, fmt.Sprintf("Font %d", style.Font))
+	}
+
+	if len(parts) == 0 {
+		return "Text"
+	}
+	return strings.Join(parts, ", ")
+}
@@ -5,6 +5,7 @@ package chunks
 
 import (
 	"fmt"
+	"image/color"
 	"log"
 )
 
@@ -36,7 +37,7 @@ func handleIlbmBmhd(data []byte) (StructResult, error) {
 	if err != nil {
 		return result, err
 	}
-	result = append(result, [2]string{"Width : Height", fmt.Sprintf("%d : %d", w, h)})
+	result.add("Width : Height", withTuple("%d : %d", w, h), offset)
 
 	// handle x, y
 	x, err := getBeWord(data, &offset)
@@ -47,14 +48,14 @@ func handleIlbmBmhd(data []byte) (StructResult, error) {
 	if err != nil {
 		return result, err
 	}
-	result = append(result, [2]string{"Position x : y", fmt.Sprintf("%d : %d", x, y)})
+	result.add("Position x : y", withTuple("%d : %d", x, y), offset)
 
 	// handle nPlanes
 	nPlanes, err := getUbyte(data, &offset)
 	if err != nil {
 		return result, err
 	}
-	result = append(result, [2]string{"Number of planes", fmt.Sprintf("%d", nPlanes)})
+	result.add("Number of planes", nPlanes, offset)
 
 	// handle masking
 	masking, err := getUbyte(data, &offset)
@@ -63,13 +64,13 @@ func handleIlbmBmhd(data []byte) (StructResult, error) {
 	}
 	switch masking {
 	case 0:
-		result = append(result, [2]string{"Masking", "None"})
+		result.add("Masking", withEnum(masking, "None"), offset)
 	case 1:
-		result = append(result, [2]string{"Masking", "Has Mask"})
+		result.add("Masking", withEnum(masking, "Has Mask"), offset)
 	case 2:
-		result = append(result, [2]string{"Masking", "Has Transparent Color"})
+		result.add("Masking", withEnum(masking, "Has Transparent Color"), offset)
 	case 3:
-		result = append(result, [2]string{"Masking", "Lasso"})
+		result.add("Masking", withEnum(masking, "Lasso"), offset)
 	}
 
 	// handle compression
@@ -79,9 +80,9 @@ func handleIlbmBmhd(data []byte) (StructResult, error) {
 	}
 	switch compression {
 	case 0:
-		result = append(result, [2]string{"Compression", "None"})
+		result.addField("Compression", withEnum(compression, "None"), offset-1, 1)
 	case 1:
-		result = append(result, [2]string{"Compression", "Byte Run 1"})
+		result.addField("Compression", withEnum(compression, "Byte Run 1"), offset-1, 1)
 	}
 
 	offset++ // ignore pad1
@@ -91,7 +92,7 @@ func handleIlbmBmhd(data []byte) (StructResult, error) {
 	if err != nil {
 		return result, err
 	}
-	result = append(result, [2]string{"Transparent Color", fmt.Sprintf("%d", transparentColor)})
+	result.addField("Transparent Color", transparentColor, offset-2, 2)
 
 	// handle xAspect, yAspect
 	xAspect, err := getUbyte(data, &offset)
@@ -102,7 +103,7 @@ func handleIlbmBmhd(data []byte) (StructResult, error) {
 	if err != nil {
 		return result, err
 	}
-	result = append(result, [2]string{"Aspect Ratio x : y", fmt.Sprintf("%d : %d", xAspect, yAspect)})
+	result.add("Aspect Ratio x : y", withTuple("%d : %d", xAspect, yAspect), offset)
 
 	// handle pageWidth, pageHeight
 	pageWidth, err := getBeWord(data, &offset)
@@ -113,7 +114,7 @@ func handleIlbmBmhd(data []byte) (StructResult, error) {
 	if err != nil {
 		return result, err
 	}
-	result = append(result, [2]string{"Page Width : Height", fmt.Sprintf("%d : %d", pageWidth, pageHeight)})
+	result.add("Page Width : Height", withTuple("%d : %d", pageWidth, pageHeight), offset)
 
 	return result, nil
 }
@@ -130,16 +131,19 @@ func handleIlbmCmap(data []byte) (StructResult, error) {
 	var offset uint32
 	var result StructResult
 
+	var colors StructResult
 	n := len(data) / 3
 
 	for i := 0; i < n; i++ {
 		red := data[offset]
 		green := data[offset+1]
 		blue := data[offset+2]
-		result = append(result, [2]string{fmt.Sprintf("Color %d", i),
-			fmt.Sprintf("%d : %d : %d", red, green, blue)})
+		colors.addField(fmt.Sprintf("Color %d", i),
+			withText(color.RGBA{red, green, blue, 0xFF}, fmt.Sprintf("%d : %d : %d", red, green, blue)),
+			offset, 3)
 		offset += 3
 	}
+	result.addGroup("Colors", n, 0, colors)
 	return result, nil
 }
 
@@ -162,7 +166,7 @@ func handleIlbmGrab(data []byte) (StructResult, error) {
 	if err != nil {
 		return result, err
 	}
-	result = append(result, [2]string{"Position x : y", fmt.Sprintf("%d : %d", x, y)})
+	result.add("Position x : y", withTuple("%d : %d", x, y), offset)
 
 	return result, nil
 }
@@ -178,9 +182,17 @@ func handleIlbmCamg(data []byte) (StructResult, error) {
 	if err != nil {
 		return result, err
 	}
+	result.add("View Mode", withFormat(viewMode, "0x%08X"), offset)
 
-	// TODO: decode viewMode
-	result = append(result, [2]string{"View Mode", fmt.Sprintf("%032b", viewMode)})
+	mode := SanitizeViewMode(viewMode)
+	if uint32(mode) != viewMode {
+		result.add("Sanitized View Mode", withFormat(uint32(mode), "0x%08X"), offset)
+	}
+	result.add("Monitor", mode.MonitorName(), offset)
+	result.add("Monitor ID", withFormat(mode.MonitorID(), "0x%08X"), offset)
+	for _, name := range mode.FlagNames() {
+		result.add("Flag", name, offset)
+	}
 
 	return result, nil
 }
@@ -201,13 +213,13 @@ func handleIlbmDpi(data []byte) (StructResult, error) {
 	if err != nil {
 		return result, err
 	}
-	result = append(result, [2]string{"Horizontal DPI", fmt.Sprintf("%d", hDPI)})
+	result.add("Horizontal DPI", hDPI, offset)
 
 	vDPI, err := getBeUword(data, &offset)
 	if err != nil {
 		return result, err
 	}
-	result = append(result, [2]string{"Vertical DPI", fmt.Sprintf("%d", vDPI)})
+	result.add("Vertical DPI", vDPI, offset)
 
 	return result, err
 }
@@ -231,7 +243,7 @@ func handleIlbmDest(data []byte) (StructResult, error) {
 	if err != nil {
 		return result, err
 	}
-	result = append(result, [2]string{"Depth", fmt.Sprintf("%d", depth)})
+	result.add("Depth", depth, offset)
 
 	offset++ // ignore pad1
 
@@ -240,21 +252,21 @@ func handleIlbmDest(data []byte) (StructResult, error) {
 	if err != nil {
 		return result, err
 	}
-	result = append(result, [2]string{"Plane Pick", fmt.Sprintf("%032b", planePick)})
+	result.addField("Plane Pick", withFormat(planePick, "%032b"), offset-2, 2)
 
 	// handle planeOnOff
 	planeOnOff, err := getBeUword(data, &offset)
 	if err != nil {
 		return result, err
 	}
-	result = append(result, [2]string{"Plane On/Off", fmt.Sprintf("%032b", planeOnOff)})
+	result.add("Plane On/Off", withFormat(planeOnOff, "%032b"), offset)
 
 	// handle planeMask
 	planeMask, err := getBeUword(data, &offset)
 	if err != nil {
 		return result, err
 	}
-	result = append(result, [2]string{"Plane Mask", fmt.Sprintf("%032b", planeMask)})
+	result.add("Plane Mask", withFormat(planeMask, "%032b"), offset)
 
 	return result, nil
 }
@@ -272,7 +284,7 @@ func handleIlbmSprt(data []byte) (StructResult, error) {
 	if err != nil {
 		return result, err
 	}
-	result = append(result, [2]string{"Sprite Precedence", fmt.Sprintf("%d", spritePrecedence)})
+	result.add("Sprite Precedence", spritePrecedence, offset)
 
 	return result, nil
 }
@@ -298,7 +310,7 @@ func handleIlbmCrng(data []byte) (StructResult, error) {
 	if err != nil {
 		return result, err
 	}
-	result = append(result, [2]string{"Rate", fmt.Sprintf("%d", rate)})
+	result.addField("Rate", rate, offset-2, 2)
 
 	// handle flags
 	flags, err := getBeWord(data, &offset)
@@ -306,10 +318,10 @@ func handleIlbmCrng(data []byte) (StructResult, error) {
 		return result, err
 	}
 	if flags&1 == 1 {
-		result = append(result, [2]string{"Flags", "Active"})
+		result.add("Flags", withEnum(flags&1, "Active"), offset)
 	}
 	if flags&2 == 2 {
-		result = append(result, [2]string{"Flags", "Reverse"})
+		result.add("Flags", withEnum(flags&2, "Reverse"), offset)
 	}
 
 	// handle low
@@ -317,14 +329,104 @@ func handleIlbmCrng(data []byte) (StructResult, error) {
 	if err != nil {
 		return result, err
 	}
-	result = append(result, [2]string{"Low", fmt.Sprintf("%d", low)})
+	result.addField("Low", low, offset-1, 1)
 
 	// handle high
 	high, err := getUbyte(data, &offset)
 	if err != nil {
 		return result, err
 	}
-	result = append(result, [2]string{"High", fmt.Sprintf("%d", high)})
+	result.add("High", high, offset)
+
+	return result, nil
+}
+
+// handleIlbmSham processes the ILBM.SHAM chunk.
+func handleIlbmSham(data []byte) (StructResult, error) {
+	log.Println("Handling ILBM.SHAM chunk")
+
+	// typedef struct {
+	//	UWORD version;
+	//	UWORD colors[][16]; // 0x0RGB
+	// } SHAM;
+
+	var offset uint32
+	var result StructResult
+
+	version, err := getBeUword(data, &offset)
+	if err != nil {
+		return result, err
+	}
+	result.add("Version", version, offset)
+	result.addField("Palettes", (len(data)-2)/32, 2, uint32(len(data)-2))
+
+	return result, nil
+}
+
+// handleIlbmCtbl processes the ILBM.CTBL chunk.
+func handleIlbmCtbl(data []byte) (StructResult, error) {
+	log.Println("Handling ILBM.CTBL chunk")
+
+	// typedef struct {
+	//	UWORD colors[][16]; // 0x0RGB
+	// } CTBL;
+
+	var result StructResult
+
+	if len(data) < 32 {
+		return result, fmt.Errorf("CTBL is too short")
+	}
+	result.addField("Palettes", len(data)/32, 0, uint32(len(data)))
+
+	return result, nil
+}
+
+// handleIlbmPchg processes the ILBM.PCHG chunk.
+func handleIlbmPchg(data []byte) (StructResult, error) {
+	log.Println("Handling ILBM.PCHG chunk")
+
+	// typedef struct {
+	//	UWORD Compression;
+	//	UWORD Flags;
+	//	WORD  StartLine;
+	//	UWORD LineCount;
+	//	UWORD ChangedLines;
+	//	UWORD MinReg;
+	//	UWORD MaxReg;
+	//	UWORD MaxChanges;
+	//	ULONG TotalChanges;
+	// } PCHGHeader;
+
+	var result StructResult
+
+	hdr, err := ParsePchgHeader(data)
+	if err != nil {
+		return result, err
+	}
+
+	switch hdr.Compression {
+	case PchgCompressionNone:
+		result.addField("Compression", withEnum(hdr.Compression, "None"), 0, 2)
+	case PchgCompressionHuffman:
+		result.addField("Compression", withEnum(hdr.Compression, "Huffman"), 0, 2)
+	default:
+		result.addField("Compression", withFormat(hdr.Compression, "Unknown (%d)"), 0, 2)
+	}
+	if hdr.Flags&PchgSmallLines != 0 {
+		result.addField("Flags", withEnum(hdr.Flags&PchgSmallLines, "Small Lines (12 bit)"), 2, 2)
+	}
+	if hdr.Flags&PchgBigLines != 0 {
+		result.addField("Flags", withEnum(hdr.Flags&PchgBigLines, "Big Lines (32 bit)"), 2, 2)
+	}
+	if hdr.Flags&PchgUseAlpha != 0 {
+		result.addField("Flags", withEnum(hdr.Flags&PchgUseAlpha, "Use Alpha"), 2, 2)
+	}
+	result.addField("Start Line", hdr.StartLine, 4, 2)
+	result.addField("Line Count", hdr.LineCount, 6, 2)
+	result.addField("Changed Lines", hdr.ChangedLines, 8, 2)
+	result.addField("Registers", withTuple("%d - %d", hdr.MinReg, hdr.MaxReg), 10, 4)
+	result.addField("Max Changes", hdr.MaxChanges, 14, 2)
+	result.addField("Total Changes", hdr.TotalChanges, 16, 4)
 
 	return result, nil
 }
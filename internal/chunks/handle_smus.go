@@ -0,0 +1,96 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"fmt"
+	"log"
+)
+
+// handleSmusShdr processes the SMUS.SHDR and CMUS.SHDR chunks.
+func handleSmusShdr(data []byte) (StructResult, error) {
+	log.Println("Handling SMUS.SHDR or CMUS.SHDR chunk")
+
+	//typedef struct {
+	//	UWORD tempo;    // 128ths of quarter notes per minute
+	//	UBYTE volume;   // 0-127
+	//	UBYTE ctTrack;  // number of tracks
+	//} SScoreHeader;
+
+	var result StructResult
+
+	shdr, err := ParseSMUSHeader(data)
+	if err != nil {
+		return result, err
+	}
+	result.addField("Tempo", withText(shdr.Tempo,
+		fmt.Sprintf("%d (%g quarter notes/minute)", shdr.Tempo, shdr.BPM())), 0, 2)
+	result.addField("Volume", shdr.Volume, 2, 1)
+	result.addField("Tracks", shdr.Tracks, 3, 1)
+
+	return result, nil
+}
+
+// handleSmusIns1 processes the SMUS.INS1 and CMUS.INS1 chunks.
+func handleSmusIns1(data []byte) (StructResult, error) {
+	log.Println("Handling SMUS.INS1 or CMUS.INS1 chunk")
+
+	//typedef struct {
+	//	UBYTE register;  // instrument register, selected by SID_Instrument
+	//	UBYTE type;      // 0 = name, 1 = MIDI
+	//	UBYTE data1;     // MIDI channel
+	//	UBYTE data2;     // MIDI preset
+	//	char name[];     // not zero terminated
+	//} RefInstrument;
+
+	var result StructResult
+
+	ins, err := ParseSMUSInstrument(data)
+	if err != nil {
+		return result, err
+	}
+	result.addField("Register", ins.Register, 0, 1)
+	switch ins.Type {
+	case INS1Name:
+		result.addField("Type", fieldValue{raw: ins.Type, enum: "Name", text: "0 (Name)"}, 1, 1)
+	case INS1MIDI:
+		result.addField("Type", fieldValue{raw: ins.Type, enum: "MIDI", text: "1 (MIDI)"}, 1, 1)
+		result.addField("MIDI Channel", ins.Data1, 2, 1)
+		result.addField("MIDI Preset", ins.Data2, 3, 1)
+	default:
+		result.addField("Type", withFormat(ins.Type, "Unknown (%d)"), 1, 1)
+	}
+	result.addField("Name", ins.Name, 4, uint32(len(data)-4))
+
+	return result, nil
+}
+
+// handleSmusTrak processes the SMUS.TRAK and CMUS.TRAK chunks. Each event is shown with
+// its start time in quarter notes.
+func handleSmusTrak(data []byte) (StructResult, error) {
+	log.Println("Handling SMUS.TRAK or CMUS.TRAK chunk")
+
+	//typedef struct {
+	//	UBYTE sID;   // 0-127 note, 128 rest, 129... other events
+	//	UBYTE data;  // duration of notes and rests, else parameter
+	//} SEvent;
+
+	var result StructResult
+	var fields StructResult
+
+	events := ParseSMUSTrack(data)
+	i := 0
+	smusTiming(events, func(event SMUSEvent, ticks int) {
+		fields.addField(fmt.Sprintf("Event %d", i),
+			withText(event, fmt.Sprintf("%s (beat %g)", event, float64(ticks)/SMUSTicksPerQuarter)),
+			uint32(2*i), 2)
+		i++
+	})
+	result.addGroup("Events", len(events), 0, fields)
+
+	if len(data)%2 != 0 {
+		return result, fmt.Errorf("odd size of TRAK chunk")
+	}
+	return result, nil
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Flags of the Amiga display mode as stored in the CAMG chunk.
+const (
+	ModeGenlockVideo   = 0x0002
+	ModeLace           = 0x0004
+	ModeDoubleScan     = 0x0008
+	ModeSuperHires     = 0x0020
+	ModePFBA           = 0x0040
+	ModeExtraHalfbrite = 0x0080
+	ModeGenlockAudio   = 0x0100
+	ModeDualPF         = 0x0400
+	ModeHAM            = 0x0800
+	ModeExtendedMode   = 0x1000
+	ModeVPHide         = 0x2000
+	ModeSprites        = 0x4000
+	ModeHires          = 0x8000
+
+	MonitorIDMask = 0xFFFF1000
+)
+
+// modeFlagNames contains the names of the display mode flags.
+var modeFlagNames = []struct {
+	flag uint32
+	name string
+}{
+	{ModeHires, "HIRES"},
+	{ModeSuperHires, "SUPERHIRES"},
+	{ModeLace, "LACE"},
+	{ModeDoubleScan, "DOUBLESCAN"},
+	{ModeHAM, "HAM"},
+	{ModeExtraHalfbrite, "EHB"},
+	{ModeDualPF, "DUALPF"},
+	{ModePFBA, "PFBA"},
+	{ModeGenlockVideo, "GENLOCK_VIDEO"},
+	{ModeGenlockAudio, "GENLOCK_AUDIO"},
+	{ModeExtendedMode, "EXTENDED_MODE"},
+	{ModeVPHide, "VP_HIDE"},
+	{ModeSprites, "SPRITES"},
+}
+
+// monitorNames contains the names of the native Amiga monitors.
+var monitorNames = map[uint32]string{
+	0x00000000: "Default",
+	0x00011000: "NTSC",
+	0x00021000: "PAL",
+	0x00031000: "VGA (Multiscan)",
+	0x00041000: "A2024",
+	0x00051000: "Proto",
+	0x00061000: "Euro72",
+	0x00071000: "Euro36",
+	0x00081000: "Super72",
+	0x00091000: "DblNTSC",
+	0x000A1000: "DblPAL",
+}
+
+// ViewMode is the display mode ID of a CAMG chunk.
+type ViewMode uint32
+
+// SanitizeViewMode removes the bits which are set by old programs in
+// CAMG chunks by mistake, as recommended by the ILBM specification.
+func SanitizeViewMode(camg uint32) ViewMode {
+	// knock bad bits out of old-style 16-bit view mode CAMGs
+	if camg&MonitorIDMask == 0 || (camg&ModeExtendedMode != 0 && camg&0xFFFF0000 == 0) {
+		camg &^= ModeExtendedMode | ModeSprites | ModeGenlockAudio | ModeGenlockVideo | ModeVPHide
+	}
+	// junk in the upper word without the extended bit, e.g. DPaint II brushes
+	if camg&0xFFFF0000 != 0 && camg&ModeExtendedMode == 0 {
+		camg = 0
+	}
+	return ViewMode(camg)
+}
+
+// MonitorID returns the monitor part of the display mode.
+func (vm ViewMode) MonitorID() uint32 {
+	return uint32(vm) & MonitorIDMask
+}
+
+// IsNative returns true if the display mode belongs to a native Amiga
+// chipset monitor, false for modes of graphics cards.
+func (vm ViewMode) IsNative() bool {
+	_, exists := monitorNames[vm.MonitorID()]
+	return exists
+}
+
+// IsHAM returns true for Hold-And-Modify modes.
+func (vm ViewMode) IsHAM() bool {
+	return vm.IsNative() && uint32(vm)&ModeHAM != 0
+}
+
+// IsEHB returns true for Extra-Halfbrite modes.
+func (vm ViewMode) IsEHB() bool {
+	return vm.IsNative() && uint32(vm)&ModeExtraHalfbrite != 0
+}
+
+// MonitorName returns the name of the monitor.
+func (vm ViewMode) MonitorName() string {
+	if name, exists := monitorNames[vm.MonitorID()]; exists {
+		return name
+	}
+	return fmt.Sprintf("Graphics Card (0x%04X)", uint32(vm)>>16)
+}
+
+// FlagNames returns the names of all flags which are set. Flags of
+// graphics card modes have no defined meaning and are not decoded.
+func (vm ViewMode) FlagNames() []string {
+	var names []string
+
+	if !vm.IsNative() {
+		return names
+	}
+	for _, f := range modeFlagNames {
+		if uint32(vm)&f.flag != 0 {
+			names = append(names, f.name)
+		}
+	}
+	return names
+}
+
+// String returns the monitor name and the flags.
+func (vm ViewMode) String() string {
+	names := vm.FlagNames()
+	if len(names) == 0 {
+		return vm.MonitorName()
+	}
+	return vm.MonitorName() + ": " + strings.Join(names, " ")
+}
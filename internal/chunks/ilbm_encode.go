@@ -0,0 +1,227 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"math/bits"
+)
+
+// ILBMEncodeOptions controls how EncodeILBM converts an image.
+type ILBMEncodeOptions struct {
+	// number of planes from 1 to 8, 0 selects the smallest number
+	// which holds all colors
+	Planes int
+
+	// compress the BODY with ByteRun1
+	Compress bool
+
+	// MaskNone, MaskHasMask or MaskHasTransparentColor; transparent
+	// pixels are those with an alpha value below 128
+	Masking uint8
+
+	// pixel aspect ratio, 0 is written as 1
+	XAspect, YAspect uint8
+
+	// display mode of the CAMG chunk, which is omitted if 0
+	ViewMode uint32
+}
+
+// EncodeByteRun1 compresses data with the ByteRun1 algorithm.
+// Runs of 3 or more equal bytes are replicated, everything else is copied
+// literally.
+func EncodeByteRun1(data []byte) []byte {
+	var result []byte
+
+	pos := 0
+	for pos < len(data) {
+		// length of the run starting at pos
+		run := 1
+		for pos+run < len(data) && run < 128 && data[pos+run] == data[pos] {
+			run++
+		}
+		if run >= 3 {
+			result = append(result, byte(int8(1-run)), data[pos])
+			pos += run
+			continue
+		}
+
+		// collect literal bytes until the next run of 3 bytes
+		start := pos
+		for pos < len(data) && pos-start < 128 {
+			if pos+2 < len(data) && data[pos] == data[pos+1] && data[pos] == data[pos+2] {
+				break
+			}
+			pos++
+		}
+		result = append(result, byte(pos-start-1))
+		result = append(result, data[start:pos]...)
+	}
+
+	return result
+}
+
+// EncodeILBM converts an image into an ILBM FORM with BMHD, CMAP, CAMG and
+// BODY chunk, which can be saved with WriteIFFFile. Paletted images with
+// few enough colors keep their palette, all other images are quantized
+// to the number of colors the planes can hold.
+func EncodeILBM(img image.Image, opts ILBMEncodeOptions) (*IFFChunk, error) {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= 0 || height <= 0 || width > 0xFFFF || height > 0xFFFF {
+		return nil, fmt.Errorf("unsupported image size %dx%d", width, height)
+	}
+	if opts.Planes < 0 || opts.Planes > 8 {
+		return nil, fmt.Errorf("unsupported number of planes: %d", opts.Planes)
+	}
+	if opts.Masking != MaskNone && opts.Masking != MaskHasMask &&
+		opts.Masking != MaskHasTransparentColor {
+		return nil, fmt.Errorf("unsupported masking: %d", opts.Masking)
+	}
+
+	maxColors := 256
+	if opts.Planes > 0 {
+		maxColors = 1 << opts.Planes
+	}
+	paletted, ok := img.(*image.Paletted)
+	if !ok || len(paletted.Palette) > maxColors {
+		paletted = QuantizeImage(img, maxColors)
+	}
+	bounds = paletted.Bounds()
+
+	depth := opts.Planes
+	if depth == 0 {
+		depth = max(bits.Len(uint(len(paletted.Palette)-1)), 1)
+	}
+
+	// find the transparent pixels and color
+	transparentColor := -1
+	isTransparent := make([]bool, len(paletted.Palette))
+	for i, c := range paletted.Palette {
+		if _, _, _, a := c.RGBA(); a < 0x8000 {
+			isTransparent[i] = true
+			if transparentColor < 0 {
+				transparentColor = i
+			}
+		}
+	}
+
+	bm := bitmapFromPaletted(paletted, depth)
+	mask := make([]byte, bm.BytesPerRow*height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			index := paletted.ColorIndexAt(bounds.Min.X+x, bounds.Min.Y+y)
+			if int(index) >= len(isTransparent) || !isTransparent[index] {
+				mask[y*bm.BytesPerRow+x/8] |= byte(0x80) >> (x % 8)
+			}
+		}
+	}
+
+	bmhd := BitmapHeader{
+		Width:      uint16(width),
+		Height:     uint16(height),
+		NPlanes:    uint8(depth),
+		Masking:    opts.Masking,
+		XAspect:    max(opts.XAspect, 1),
+		YAspect:    max(opts.YAspect, 1),
+		PageWidth:  int16(min(width, 0x7FFF)),
+		PageHeight: int16(min(height, 0x7FFF)),
+	}
+	if opts.Compress {
+		bmhd.Compression = CompressionByteRun1
+	}
+	if opts.Masking == MaskHasTransparentColor {
+		bmhd.TransparentColor = uint16(max(transparentColor, 0))
+	}
+
+	form := &IFFChunk{ID: "FORM", SubID: "ILBM"}
+	form.Childs = append(form.Childs, &IFFChunk{ID: "BMHD", Data: encodeBitmapHeader(&bmhd)})
+	form.Childs = append(form.Childs, &IFFChunk{ID: "CMAP", Data: encodeColorMap(paletted.Palette)})
+	if opts.ViewMode != 0 {
+		camg := binary.BigEndian.AppendUint32(nil, opts.ViewMode)
+		form.Childs = append(form.Childs, &IFFChunk{ID: "CAMG", Data: camg})
+	}
+	if opts.Masking != MaskHasMask {
+		mask = nil
+	}
+	form.Childs = append(form.Childs, &IFFChunk{ID: "BODY",
+		Data: encodeILBMBody(bm, mask, opts.Compress)})
+
+	return form, nil
+}
+
+// bitmapFromPaletted distributes the color indices of the image to the
+// given number of planes.
+func bitmapFromPaletted(img *image.Paletted, depth int) *Bitmap {
+	bounds := img.Bounds()
+	bm := NewBitmap(bounds.Dx(), bounds.Dy(), depth)
+	for y := 0; y < bm.Height; y++ {
+		for x := 0; x < bm.Width; x++ {
+			index := img.ColorIndexAt(bounds.Min.X+x, bounds.Min.Y+y)
+			offset := y*bm.BytesPerRow + x/8
+			bit := byte(0x80) >> (x % 8)
+			for p := 0; p < depth; p++ {
+				if index&(1<<p) != 0 {
+					bm.Planes[p][offset] |= bit
+				}
+			}
+		}
+	}
+	return bm
+}
+
+// encodeBitmapHeader returns the data of a BMHD chunk.
+func encodeBitmapHeader(bmhd *BitmapHeader) []byte {
+	data := make([]byte, 0, 20)
+	data = binary.BigEndian.AppendUint16(data, bmhd.Width)
+	data = binary.BigEndian.AppendUint16(data, bmhd.Height)
+	data = binary.BigEndian.AppendUint16(data, uint16(bmhd.X))
+	data = binary.BigEndian.AppendUint16(data, uint16(bmhd.Y))
+	data = append(data, bmhd.NPlanes, bmhd.Masking, bmhd.Compression, 0)
+	data = binary.BigEndian.AppendUint16(data, bmhd.TransparentColor)
+	data = append(data, bmhd.XAspect, bmhd.YAspect)
+	data = binary.BigEndian.AppendUint16(data, uint16(bmhd.PageWidth))
+	data = binary.BigEndian.AppendUint16(data, uint16(bmhd.PageHeight))
+	return data
+}
+
+// encodeColorMap returns the data of a CMAP chunk with 8 bit per component.
+func encodeColorMap(palette color.Palette) []byte {
+	data := make([]byte, 0, len(palette)*3)
+	for _, c := range palette {
+		nc := color.NRGBAModel.Convert(c).(color.NRGBA)
+		data = append(data, nc.R, nc.G, nc.B)
+	}
+	return data
+}
+
+// encodeILBMBody interleaves the rows of the planes and the mask plane
+// and compresses each row if requested.
+func encodeILBMBody(bm *Bitmap, mask []byte, compress bool) []byte {
+	var data []byte
+
+	for y := 0; y < bm.Height; y++ {
+		rowStart := y * bm.BytesPerRow
+		rows := make([][]byte, 0, len(bm.Planes)+1)
+		for _, plane := range bm.Planes {
+			rows = append(rows, plane[rowStart:rowStart+bm.BytesPerRow])
+		}
+		if mask != nil {
+			rows = append(rows, mask[rowStart:rowStart+bm.BytesPerRow])
+		}
+
+		for _, row := range rows {
+			if compress {
+				data = append(data, EncodeByteRun1(row)...)
+			} else {
+				data = append(data, row...)
+			}
+		}
+	}
+
+	return data
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestEncodeByteRun1(t *testing.T) {
+	var tests = []struct {
+		name string
+		data []byte
+		want []byte
+	}{
+		{"Literal", []byte{1, 2, 3}, []byte{0x02, 1, 2, 3}},
+		{"Replicate", []byte{7, 7, 7, 7}, []byte{0xFD, 7}},
+		{"Mixed", []byte{1, 2, 2, 5, 5, 5}, []byte{0x02, 1, 2, 2, 0xFE, 5}},
+		{"Empty", []byte{}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EncodeByteRun1(tt.data)
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("Data: got %v, want %v", got, tt.want)
+			}
+			decoded, err := DecodeByteRun1(got, len(tt.data))
+			if err != nil || !bytes.Equal(decoded, tt.data) {
+				t.Errorf("Round trip: got %v, %v, want %v", decoded, err, tt.data)
+			}
+		})
+	}
+
+	// long runs and literals are split into blocks of 128 bytes
+	long := make([]byte, 300)
+	for i := 200; i < len(long); i++ {
+		long[i] = byte(i)
+	}
+	decoded, err := DecodeByteRun1(EncodeByteRun1(long), len(long))
+	if err != nil || !bytes.Equal(decoded, long) {
+		t.Errorf("Long: got %v, want %v", err, long)
+	}
+}
+
+// encodeAndDecode encodes the image as ILBM, writes and reads the file
+// and decodes the picture again.
+func encodeAndDecode(t *testing.T, img image.Image, opts ILBMEncodeOptions) *ILBMPicture {
+	form, err := EncodeILBM(img, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := WriteIFFFile(&buf, form); err != nil {
+		t.Fatal(err)
+	}
+	root, err := ReadIFFFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diags := Validate(root); len(diags) > 0 {
+		t.Errorf("Validate: got %v, want no problems", diags)
+	}
+	pic, err := DecodeILBMPicture(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pic
+}
+
+func TestEncodeILBMRoundTrip(t *testing.T) {
+	palette := color.Palette{
+		color.NRGBA{0, 0, 0, 0},
+		color.NRGBA{255, 0, 0, 255},
+		color.NRGBA{0, 255, 0, 255},
+		color.NRGBA{0, 0, 255, 255},
+		color.NRGBA{255, 255, 255, 255},
+	}
+	img := image.NewPaletted(image.Rect(0, 0, 21, 5), palette)
+	for y := 0; y < 5; y++ {
+		for x := 0; x < 21; x++ {
+			img.SetColorIndex(x, y, uint8((x/3+y)%len(palette)))
+		}
+	}
+
+	var tests = []struct {
+		name      string
+		opts      ILBMEncodeOptions
+		wantDepth int
+	}{
+		{"Uncompressed", ILBMEncodeOptions{}, 3},
+		{"Compressed", ILBMEncodeOptions{Compress: true}, 3},
+		{"Planes", ILBMEncodeOptions{Planes: 5, Compress: true}, 5},
+		{"Mask", ILBMEncodeOptions{Masking: MaskHasMask, Compress: true}, 3},
+		{"TransparentColor", ILBMEncodeOptions{Masking: MaskHasTransparentColor}, 3},
+		{"CAMG", ILBMEncodeOptions{ViewMode: ModeHires | ModeLace}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pic := encodeAndDecode(t, img, tt.opts)
+
+			if len(pic.Bitmap.Planes) != tt.wantDepth {
+				t.Errorf("Planes: got %d, want %d", len(pic.Bitmap.Planes), tt.wantDepth)
+			}
+			if pic.ViewMode != ViewMode(tt.opts.ViewMode) {
+				t.Errorf("ViewMode: got 0x%08X, want 0x%08X", uint32(pic.ViewMode),
+					tt.opts.ViewMode)
+			}
+			for y := 0; y < 5; y++ {
+				for x := 0; x < 21; x++ {
+					want := uint32(img.ColorIndexAt(x, y))
+					if got := pic.Bitmap.ColorIndex(x, y); got != want {
+						t.Fatalf("Pixel %d,%d: got %d, want %d", x, y, got, want)
+					}
+				}
+			}
+
+			decoded := pic.Image()
+			for y := 0; y < 5; y++ {
+				for x := 0; x < 21; x++ {
+					_, _, _, a := decoded.At(x, y).RGBA()
+					wantTransparent := tt.opts.Masking != MaskNone && img.ColorIndexAt(x, y) == 0
+					if (a == 0) != wantTransparent {
+						t.Fatalf("Alpha %d,%d: got %d, want transparent %t", x, y, a,
+							wantTransparent)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestEncodeILBMQuantize(t *testing.T) {
+	colors := []color.NRGBA{
+		{200, 10, 10, 255}, {10, 200, 10, 255}, {10, 10, 200, 255}, {250, 250, 250, 255},
+	}
+	img := image.NewNRGBA(image.Rect(0, 0, 8, 8))
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			img.SetNRGBA(x, y, colors[(x+y)%len(colors)])
+		}
+	}
+
+	// 4 colors fit exactly into 2 planes
+	pic := encodeAndDecode(t, img, ILBMEncodeOptions{Compress: true, Planes: 2})
+	decoded := pic.Image()
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			got := color.NRGBAModel.Convert(decoded.At(x, y)).(color.NRGBA)
+			if got != img.NRGBAAt(x, y) {
+				t.Fatalf("Pixel %d,%d: got %v, want %v", x, y, got, img.NRGBAAt(x, y))
+			}
+		}
+	}
+
+	// with 1 plane similar colors are merged
+	pic = encodeAndDecode(t, img, ILBMEncodeOptions{Planes: 1})
+	if len(pic.Palette) != 2 {
+		t.Errorf("Colors: got %d, want 2", len(pic.Palette))
+	}
+}
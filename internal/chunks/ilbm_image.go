@@ -0,0 +1,563 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+)
+
+// Masking techniques of the BMHD chunk.
+const (
+	MaskNone                = 0
+	MaskHasMask             = 1
+	MaskHasTransparentColor = 2
+	MaskLasso               = 3
+)
+
+// Compression algorithms of the BMHD chunk.
+const (
+	CompressionNone     = 0
+	CompressionByteRun1 = 1
+)
+
+// BitmapHeader is the content of a BMHD chunk.
+type BitmapHeader struct {
+	Width, Height         uint16
+	X, Y                  int16
+	NPlanes               uint8
+	Masking               uint8
+	Compression           uint8
+	TransparentColor      uint16
+	XAspect, YAspect      uint8
+	PageWidth, PageHeight int16
+}
+
+// Bitmap is a planar bitmap as used by the Amiga hardware.
+// Each plane contains Height rows of BytesPerRow bytes.
+type Bitmap struct {
+	Width       int
+	Height      int
+	BytesPerRow int
+	Planes      [][]byte
+}
+
+// ILBMPicture contains the decoded data of an ILBM or ACBM FORM.
+type ILBMPicture struct {
+	Header       BitmapHeader
+	Palette      color.Palette   // colors of the CMAP chunk
+	ViewMode     ViewMode        // sanitized display mode of the CAMG chunk
+	HasViewMode  bool            // true if the FORM contains a CAMG chunk
+	LinePalettes []color.Palette // palette of each line from SHAM, CTBL or PCHG, else nil
+	Hotspot      *image.Point    // position of the GRAB chunk, else nil
+	XDPI, YDPI   uint16          // resolution of the DPI chunk, else 0
+	Bitmap       *Bitmap
+	Mask         []byte // mask plane if Masking is MaskHasMask, else nil
+}
+
+// ParseBitmapHeader decodes the data of a BMHD chunk.
+func ParseBitmapHeader(data []byte) (BitmapHeader, error) {
+	var bmhd BitmapHeader
+	var offset uint32
+	var err error
+
+	if len(data) < 20 {
+		return bmhd, fmt.Errorf("BMHD is too short")
+	}
+
+	// the length has been checked, so no errors are expected below
+	bmhd.Width, _ = getBeUword(data, &offset)
+	bmhd.Height, _ = getBeUword(data, &offset)
+	bmhd.X, _ = getBeWord(data, &offset)
+	bmhd.Y, _ = getBeWord(data, &offset)
+	bmhd.NPlanes, _ = getUbyte(data, &offset)
+	bmhd.Masking, _ = getUbyte(data, &offset)
+	bmhd.Compression, _ = getUbyte(data, &offset)
+	offset++ // ignore pad1
+	bmhd.TransparentColor, _ = getBeUword(data, &offset)
+	bmhd.XAspect, _ = getUbyte(data, &offset)
+	bmhd.YAspect, _ = getUbyte(data, &offset)
+	bmhd.PageWidth, _ = getBeWord(data, &offset)
+	bmhd.PageHeight, err = getBeWord(data, &offset)
+
+	return bmhd, err
+}
+
+// ParseColorMap decodes the data of a CMAP chunk.
+// Color maps of old programs which only set the upper 4 bits of each
+// component are scaled to the full range.
+func ParseColorMap(data []byte) color.Palette {
+	n := len(data) / 3
+	palette := make(color.Palette, n)
+
+	is4Bit := n > 0
+	for i := 0; i < n*3; i++ {
+		if data[i]&0x0F != 0 {
+			is4Bit = false
+			break
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		r, g, b := data[i*3], data[i*3+1], data[i*3+2]
+		if is4Bit {
+			r, g, b = r|r>>4, g|g>>4, b|b>>4
+		}
+		palette[i] = color.NRGBA{r, g, b, 0xFF}
+	}
+
+	return palette
+}
+
+// DecodeByteRun1 decompresses data with the ByteRun1 algorithm until size
+// bytes are produced.
+// In case of an error, the function returns the bytes decompressed so far
+// and the error.
+func DecodeByteRun1(data []byte, size int) ([]byte, error) {
+	result := make([]byte, 0, size)
+
+	pos := 0
+	for len(result) < size {
+		if pos >= len(data) {
+			return result, fmt.Errorf("compressed data is too short")
+		}
+		n := int8(data[pos])
+		pos++
+
+		if n >= 0 {
+			// copy the next n+1 bytes literally
+			count := int(n) + 1
+			if pos+count > len(data) {
+				return result, fmt.Errorf("compressed data is too short")
+			}
+			result = append(result, data[pos:pos+count]...)
+			pos += count
+		} else if n != -128 {
+			// replicate the next byte -n+1 times
+			if pos >= len(data) {
+				return result, fmt.Errorf("compressed data is too short")
+			}
+			for i := 0; i < int(-n)+1; i++ {
+				result = append(result, data[pos])
+			}
+			pos++
+		}
+		// -128 is a no-op
+	}
+
+	if len(result) > size {
+		result = result[:size]
+	}
+	return result, nil
+}
+
+// NewBitmap creates an empty bitmap with the given size and depth.
+func NewBitmap(width int, height int, depth int) *Bitmap {
+	bm := Bitmap{
+		Width:       width,
+		Height:      height,
+		BytesPerRow: (width + 15) / 16 * 2,
+	}
+	bm.Planes = make([][]byte, depth)
+	for i := range bm.Planes {
+		bm.Planes[i] = make([]byte, bm.BytesPerRow*height)
+	}
+	return &bm
+}
+
+// ColorIndex returns the color register of the pixel, i.e. the bits of
+// all planes combined with plane 0 as least significant bit.
+func (bm *Bitmap) ColorIndex(x int, y int) uint32 {
+	var index uint32
+
+	offset := y*bm.BytesPerRow + x/8
+	bit := byte(0x80) >> (x % 8)
+	for p := len(bm.Planes) - 1; p >= 0; p-- {
+		index <<= 1
+		if bm.Planes[p][offset]&bit != 0 {
+			index |= 1
+		}
+	}
+	return index
+}
+
+// findChild returns the first child of the chunk with the given ID
+// or nil if there is none.
+func findChild(chunk *IFFChunk, id string) *IFFChunk {
+	for _, child := range chunk.Childs {
+		if child.ID == id {
+			return child
+		}
+	}
+	return nil
+}
+
+// IsPictureForm returns true for FORMs which can be decoded by
+// DecodeILBMPicture.
+func IsPictureForm(form *IFFChunk) bool {
+	return form != nil && form.ID == "FORM" && (form.SubID == "ILBM" || form.SubID == "ACBM")
+}
+
+// FindPictureForm returns the first FORM in the chunk tree which can be
+// decoded by DecodeILBMPicture or nil if there is none.
+func FindPictureForm(root *IFFChunk) *IFFChunk {
+	return FindForm(root, "ILBM", "ACBM")
+}
+
+// DecodeILBMPicture decodes BMHD, CMAP, CAMG, GRAB, DPI, the line palettes
+// and BODY of an ILBM FORM or ABIT of an ACBM FORM.
+// In case of an error, the function returns nil and the error. If only
+// the BODY or the line palettes are broken, the picture is returned
+// together with the error.
+func DecodeILBMPicture(form *IFFChunk) (*ILBMPicture, error) {
+	var pic ILBMPicture
+	var err error
+
+	if !IsPictureForm(form) {
+		return nil, fmt.Errorf("not an ILBM or ACBM FORM")
+	}
+
+	bmhdChunk := findChild(form, "BMHD")
+	if bmhdChunk == nil {
+		return nil, fmt.Errorf("BMHD chunk is missing")
+	}
+	pic.Header, err = ParseBitmapHeader(bmhdChunk.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	if cmap := findChild(form, "CMAP"); cmap != nil {
+		pic.Palette = ParseColorMap(cmap.Data)
+	}
+	if camg := findChild(form, "CAMG"); camg != nil && len(camg.Data) >= 4 {
+		pic.ViewMode = SanitizeViewMode(binary.BigEndian.Uint32(camg.Data))
+		pic.HasViewMode = true
+	}
+	if grab := findChild(form, "GRAB"); grab != nil && len(grab.Data) >= 4 {
+		pic.Hotspot = &image.Point{
+			X: int(int16(binary.BigEndian.Uint16(grab.Data))),
+			Y: int(int16(binary.BigEndian.Uint16(grab.Data[2:]))),
+		}
+	}
+	if dpi := findChild(form, "DPI "); dpi != nil && len(dpi.Data) >= 4 {
+		pic.XDPI = binary.BigEndian.Uint16(dpi.Data)
+		pic.YDPI = binary.BigEndian.Uint16(dpi.Data[2:])
+	}
+
+	if form.SubID == "ACBM" {
+		abit := findChild(form, "ABIT")
+		if abit == nil {
+			return nil, fmt.Errorf("ABIT chunk is missing")
+		}
+		pic.Bitmap, err = decodeACBMBody(&pic.Header, abit.Data)
+	} else {
+		body := findChild(form, "BODY")
+		if body == nil {
+			return nil, fmt.Errorf("BODY chunk is missing")
+		}
+		pic.Bitmap, pic.Mask, err = decodeILBMBody(&pic.Header, body.Data)
+	}
+	if pic.Bitmap == nil {
+		return nil, err
+	}
+
+	palErr := pic.decodeLinePalettes(form)
+	if err == nil {
+		err = palErr
+	}
+
+	return &pic, err
+}
+
+// decodeLinePalettes reads the palette of each line from a PCHG, SHAM
+// or CTBL chunk, in this order of preference.
+func (pic *ILBMPicture) decodeLinePalettes(form *IFFChunk) error {
+	var err error
+
+	height := int(pic.Header.Height)
+	if chunk := findChild(form, "PCHG"); chunk != nil {
+		pic.LinePalettes, err = ParsePchg(pic.Palette, chunk.Data, height)
+	} else if chunk := findChild(form, "SHAM"); chunk != nil {
+		pic.LinePalettes, err = ParseSham(pic.Palette, chunk.Data, height)
+	} else if chunk := findChild(form, "CTBL"); chunk != nil {
+		pic.LinePalettes, err = ParseCtbl(pic.Palette, chunk.Data, height)
+	}
+	return err
+}
+
+// IsHAM returns true if the picture uses Hold-And-Modify with 6 or 8 planes.
+func (pic *ILBMPicture) IsHAM() bool {
+	depth := len(pic.Bitmap.Planes)
+	return pic.ViewMode.IsHAM() && (depth == 6 || depth == 8)
+}
+
+// IsEHB returns true if the picture uses Extra-Halfbrite. Without a CAMG
+// chunk, pictures with 6 planes and at most 32 colors are treated as
+// Extra-Halfbrite.
+func (pic *ILBMPicture) IsEHB() bool {
+	if len(pic.Bitmap.Planes) != 6 || pic.ViewMode.IsHAM() {
+		return false
+	}
+	if pic.HasViewMode {
+		return pic.ViewMode.IsEHB()
+	}
+	return len(pic.Palette) > 0 && len(pic.Palette) <= 32
+}
+
+// maxPictureSize is the maximum width and height of a picture.
+const maxPictureSize = 16384
+
+// newBitmapFromHeader creates an empty bitmap with the size and depth
+// of the bitmap header. extraPlanes is the number of additional planes
+// per row, e.g. the mask plane, and maxSize the number of bytes which
+// the picture data can provide. Headers which need more data are
+// rejected before the bitmap is allocated.
+func newBitmapFromHeader(bmhd *BitmapHeader, extraPlanes int, maxSize int) (*Bitmap, error) {
+	width, height := int(bmhd.Width), int(bmhd.Height)
+	depth := int(bmhd.NPlanes)
+	if width == 0 || height == 0 {
+		return nil, fmt.Errorf("picture has no pixels")
+	}
+	if width > maxPictureSize || height > maxPictureSize {
+		return nil, fmt.Errorf("picture is too large: %dx%d", width, height)
+	}
+	if depth == 0 || depth > 32 {
+		return nil, fmt.Errorf("unsupported number of planes: %d", depth)
+	}
+	size := (width + 15) / 16 * 2 * height * (depth + extraPlanes)
+	if size > maxSize {
+		return nil, fmt.Errorf("picture data is too short: %d bytes, %d required", maxSize, size)
+	}
+	return NewBitmap(width, height, depth), nil
+}
+
+// decodeACBMBody copies the contiguous, uncompressed planes of an ABIT
+// chunk into a bitmap.
+func decodeACBMBody(bmhd *BitmapHeader, data []byte) (*Bitmap, error) {
+	bm, err := newBitmapFromHeader(bmhd, 0, len(data))
+	if err != nil {
+		return nil, err
+	}
+
+	pos := 0
+	for _, plane := range bm.Planes {
+		pos += copy(plane, data[pos:])
+	}
+
+	return bm, nil
+}
+
+// decodeILBMBody de-interleaves the (compressed) rows of a BODY chunk
+// into the planes of a bitmap and the mask plane.
+func decodeILBMBody(bmhd *BitmapHeader, data []byte) (*Bitmap, []byte, error) {
+	extraPlanes := 0
+	if bmhd.Masking == MaskHasMask {
+		extraPlanes = 1
+	}
+
+	var maxSize int
+	switch bmhd.Compression {
+	case CompressionNone:
+		maxSize = len(data)
+	case CompressionByteRun1:
+		// each pair of bytes expands to at most 128 bytes
+		maxSize = (len(data) + 1) / 2 * 128
+	default:
+		return nil, nil, fmt.Errorf("unsupported compression: %d", bmhd.Compression)
+	}
+
+	bm, err := newBitmapFromHeader(bmhd, extraPlanes, maxSize)
+	if err != nil {
+		return nil, nil, err
+	}
+	height, depth := bm.Height, len(bm.Planes)
+	var mask []byte
+
+	planesPerRow := depth + extraPlanes
+	if extraPlanes > 0 {
+		mask = make([]byte, bm.BytesPerRow*height)
+	}
+	if bmhd.Compression == CompressionByteRun1 {
+		data, err = DecodeByteRun1(data, bm.BytesPerRow*planesPerRow*height)
+	}
+
+	// copy the rows to the planes
+	pos := 0
+	for y := 0; y < height; y++ {
+		for p := 0; p < planesPerRow; p++ {
+			dest := mask
+			if p < depth {
+				dest = bm.Planes[p]
+			}
+			rowStart := y * bm.BytesPerRow
+			pos += copy(dest[rowStart:rowStart+bm.BytesPerRow], data[min(pos, len(data)):])
+		}
+	}
+
+	return bm, mask, err
+}
+
+// Image converts the picture to an image.
+// Pictures with up to 8 planes, without mask plane, HAM and line palettes
+// are returned as *image.Paletted, all other pictures as *image.NRGBA.
+func (pic *ILBMPicture) Image() image.Image {
+	bm := pic.Bitmap
+	rect := image.Rect(0, 0, bm.Width, bm.Height)
+	depth := len(bm.Planes)
+
+	if depth == 24 || depth == 32 {
+		img := image.NewNRGBA(rect)
+		for y := 0; y < bm.Height; y++ {
+			for x := 0; x < bm.Width; x++ {
+				// the planes contain red, green, blue and alpha
+				// from the least to the most significant bit
+				c := bm.ColorIndex(x, y)
+				alpha := uint8(0xFF)
+				if depth == 32 {
+					alpha = uint8(c >> 24)
+				}
+				img.SetNRGBA(x, y, color.NRGBA{uint8(c), uint8(c >> 8), uint8(c >> 16), alpha})
+			}
+		}
+		pic.applyMask(img)
+		return img
+	}
+
+	palette := pic.fullPalette()
+	if pic.Mask == nil && depth <= 8 && !pic.IsHAM() && pic.LinePalettes == nil {
+		img := image.NewPaletted(rect, palette)
+		for y := 0; y < bm.Height; y++ {
+			for x := 0; x < bm.Width; x++ {
+				img.SetColorIndex(x, y, uint8(bm.ColorIndex(x, y)))
+			}
+		}
+		return img
+	}
+
+	img := image.NewNRGBA(rect)
+	for y := 0; y < bm.Height; y++ {
+		if pic.LinePalettes != nil && y < len(pic.LinePalettes) {
+			palette = pic.expandPalette(pic.LinePalettes[y])
+		}
+		if pic.IsHAM() {
+			pic.hamLine(img, y, palette)
+			continue
+		}
+		for x := 0; x < bm.Width; x++ {
+			index := bm.ColorIndex(x, y)
+			if int(index) < len(palette) {
+				img.Set(x, y, palette[index])
+			}
+		}
+	}
+	pic.applyMask(img)
+	return img
+}
+
+// hamLine decodes a line of a Hold-And-Modify picture. The upper two bits
+// of each pixel select whether the remaining bits are a color register or
+// replace the blue, red or green component of the pixel to the left.
+func (pic *ILBMPicture) hamLine(img *image.NRGBA, y int, palette color.Palette) {
+	bm := pic.Bitmap
+	bits := len(bm.Planes) - 2
+	valueMask := uint32(1)<<bits - 1
+
+	// each line starts with the background color
+	c := color.NRGBAModel.Convert(palette[0]).(color.NRGBA)
+	c.A = 0xFF
+	for x := 0; x < bm.Width; x++ {
+		index := bm.ColorIndex(x, y)
+		value := uint8(index & valueMask)
+
+		var component uint8
+		if bits == 4 {
+			component = value * 0x11
+		} else {
+			component = value<<2 | value>>4
+		}
+
+		switch index >> bits {
+		case 0:
+			c = color.NRGBAModel.Convert(palette[value]).(color.NRGBA)
+			c.A = 0xFF
+		case 1:
+			c.B = component
+		case 2:
+			c.R = component
+		case 3:
+			c.G = component
+		}
+		img.SetNRGBA(x, y, c)
+	}
+}
+
+// fullPalette returns a palette with an entry for every possible color
+// index of the bitmap, see expandPalette.
+func (pic *ILBMPicture) fullPalette() color.Palette {
+	return pic.expandPalette(pic.Palette)
+}
+
+// expandPalette returns a palette with an entry for every possible color
+// index of the bitmap. Colors missing in the CMAP are black, without CMAP
+// a grey scale is used. Extra-Halfbrite pictures get the halved colors
+// in the upper half. The transparent color gets an alpha value of 0.
+func (pic *ILBMPicture) expandPalette(base color.Palette) color.Palette {
+	n := 1 << min(len(pic.Bitmap.Planes), 8)
+	palette := make(color.Palette, n)
+
+	isEHB := pic.IsEHB()
+	for i := range palette {
+		switch {
+		case isEHB && i >= 32 && i-32 < len(base):
+			c := color.NRGBAModel.Convert(base[i-32]).(color.NRGBA)
+			palette[i] = color.NRGBA{c.R >> 1, c.G >> 1, c.B >> 1, c.A}
+		case i < len(base):
+			palette[i] = base[i]
+		case len(base) == 0:
+			grey := uint8(i * 255 / max(n-1, 1))
+			palette[i] = color.NRGBA{grey, grey, grey, 0xFF}
+		default:
+			palette[i] = color.NRGBA{0, 0, 0, 0xFF}
+		}
+	}
+
+	if pic.Header.Masking == MaskHasTransparentColor && int(pic.Header.TransparentColor) < n {
+		c := color.NRGBAModel.Convert(palette[pic.Header.TransparentColor]).(color.NRGBA)
+		c.A = 0
+		palette[pic.Header.TransparentColor] = c
+	}
+
+	return palette
+}
+
+// applyMask makes all pixels transparent which aren't set in the
+// mask plane.
+func (pic *ILBMPicture) applyMask(img *image.NRGBA) {
+	if pic.Mask == nil {
+		return
+	}
+
+	bm := pic.Bitmap
+	for y := 0; y < bm.Height; y++ {
+		for x := 0; x < bm.Width; x++ {
+			if pic.Mask[y*bm.BytesPerRow+x/8]&(0x80>>(x%8)) == 0 {
+				img.Pix[img.PixOffset(x, y)+3] = 0
+			}
+		}
+	}
+}
+
+// DecodeILBM decodes the picture of an ILBM or ACBM FORM into an image.
+// In case of an error, the function returns nil and the error. If only
+// the BODY is incomplete, the partial image is returned together with
+// the error.
+func DecodeILBM(form *IFFChunk) (image.Image, error) {
+	pic, err := DecodeILBMPicture(form)
+	if pic == nil {
+		return nil, err
+	}
+	return pic.Image(), err
+}
@@ -0,0 +1,355 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+)
+
+func TestDecodeByteRun1(t *testing.T) {
+	var tests = []struct {
+		name      string
+		data      []byte
+		size      int
+		want      []byte
+		wantIsErr bool
+	}{
+		{"Literal", []byte{0x02, 1, 2, 3}, 3, []byte{1, 2, 3}, false},
+		{"Replicate", []byte{0xFE, 7}, 3, []byte{7, 7, 7}, false},
+		{"NoOp", []byte{0x80, 0x00, 5, 0xFF, 6}, 3, []byte{5, 6, 6}, false},
+		{"TooShort", []byte{0x03, 1, 2}, 4, []byte{}, true},
+		{"Overrun", []byte{0xFC, 9}, 2, []byte{9, 9}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeByteRun1(tt.data, tt.size)
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("Data: got %v, want %v", got, tt.want)
+			}
+			if (err != nil) != tt.wantIsErr {
+				t.Errorf("Error: got %v, want %t", err, tt.wantIsErr)
+			}
+		})
+	}
+}
+
+func TestDecodeILBM(t *testing.T) {
+	var tests = []struct {
+		name   string
+		width  int
+		height int
+		pixels map[image.Point]uint8
+	}{
+		{"test.bsh", 16, 16, map[image.Point]uint8{{0, 0}: 4, {15, 15}: 4}},
+		{"test2.bsh", 5, 7, map[image.Point]uint8{{0, 0}: 1, {4, 6}: 1, {5, 0}: 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, root := readTestIFF(t, tt.name)
+
+			img, err := DecodeILBM(root)
+			if err != nil {
+				t.Fatal(err)
+			}
+			paletted, ok := img.(*image.Paletted)
+			if !ok {
+				t.Fatalf("Image: got %T, want *image.Paletted", img)
+			}
+			if paletted.Rect.Dx() != tt.width || paletted.Rect.Dy() != tt.height {
+				t.Errorf("Size: got %v, want %dx%d", paletted.Rect, tt.width, tt.height)
+			}
+			for pt, want := range tt.pixels {
+				if got := paletted.ColorIndexAt(pt.X, pt.Y); got != want {
+					t.Errorf("Pixel %v: got %d, want %d", pt, got, want)
+				}
+			}
+			// BMHD of both files uses color 0 as transparent color
+			if _, _, _, a := paletted.Palette[0].RGBA(); a != 0 {
+				t.Errorf("Transparent color: got alpha %d, want 0", a)
+			}
+		})
+	}
+}
+
+func TestDecodeILBMMask(t *testing.T) {
+	// 8x2 pixels, 1 plane with mask plane, uncompressed
+	bmhd := []byte{0, 8, 0, 2, 0, 0, 0, 0, 1, MaskHasMask, CompressionNone, 0,
+		0, 0, 1, 1, 0, 8, 0, 2}
+	body := []byte{
+		0xF0, 0x00, 0xFF, 0x00, // row 0: plane 0, mask
+		0x0F, 0x00, 0x0F, 0x00, // row 1: plane 0, mask
+	}
+	form := &IFFChunk{ID: "FORM", SubID: "ILBM", Childs: []*IFFChunk{
+		{ID: "BMHD", Data: bmhd},
+		{ID: "CMAP", Data: []byte{0, 0, 0, 255, 255, 255}},
+		{ID: "BODY", Data: body},
+	}}
+
+	img, err := DecodeILBM(form)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tests = []struct {
+		x, y int
+		want color.NRGBA
+	}{
+		{0, 0, color.NRGBA{255, 255, 255, 255}},
+		{4, 0, color.NRGBA{0, 0, 0, 255}},
+		{0, 1, color.NRGBA{0, 0, 0, 0}},
+		{4, 1, color.NRGBA{255, 255, 255, 255}},
+	}
+	for _, tt := range tests {
+		if got := img.At(tt.x, tt.y).(color.NRGBA); got != tt.want {
+			t.Errorf("Pixel %d,%d: got %v, want %v", tt.x, tt.y, got, tt.want)
+		}
+	}
+}
+
+func TestDecodeILBMBodySize(t *testing.T) {
+	var tests = []struct {
+		name        string
+		width       uint16
+		height      uint16
+		planes      byte
+		compression byte
+		body        []byte
+		wantIsErr   bool
+	}{
+		{"Uncompressed", 16, 2, 1, CompressionNone, make([]byte, 4), false},
+		{"UncompressedShort", 16, 2, 1, CompressionNone, make([]byte, 3), true},
+		{"ByteRun1", 16, 2, 1, CompressionByteRun1, []byte{0xFD, 0}, false},
+		{"ByteRun1Huge", 65535, 65535, 32, CompressionByteRun1, []byte{0x81, 0}, true},
+		{"TooLarge", 65535, 1, 1, CompressionNone, make([]byte, 8192), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bmhd := []byte{byte(tt.width >> 8), byte(tt.width), byte(tt.height >> 8), byte(tt.height),
+				0, 0, 0, 0, tt.planes, MaskNone, tt.compression, 0, 0, 0, 1, 1, 0, 0, 0, 0}
+			form := &IFFChunk{ID: "FORM", SubID: "ILBM", Childs: []*IFFChunk{
+				{ID: "BMHD", Data: bmhd},
+				{ID: "BODY", Data: tt.body},
+			}}
+			pic, err := DecodeILBMPicture(form)
+			if (err != nil) != tt.wantIsErr {
+				t.Errorf("Error: got %v, want %t", err, tt.wantIsErr)
+			}
+			if (pic == nil) != tt.wantIsErr {
+				t.Errorf("Picture: got %v, want nil %t", pic, tt.wantIsErr)
+			}
+		})
+	}
+}
+
+// makeTestILBM builds an uncompressed ILBM FORM from the color indices of
+// each pixel and the additional chunks.
+func makeTestILBM(pixels [][]uint32, depth int, chunks ...*IFFChunk) *IFFChunk {
+	height, width := len(pixels), len(pixels[0])
+	bm := NewBitmap(width, height, depth)
+	for y, row := range pixels {
+		for x, index := range row {
+			for p := 0; p < depth; p++ {
+				if index&(1<<p) != 0 {
+					bm.Planes[p][y*bm.BytesPerRow+x/8] |= 0x80 >> (x % 8)
+				}
+			}
+		}
+	}
+	var body []byte
+	for y := 0; y < height; y++ {
+		for p := 0; p < depth; p++ {
+			body = append(body, bm.Planes[p][y*bm.BytesPerRow:(y+1)*bm.BytesPerRow]...)
+		}
+	}
+
+	bmhd := []byte{0, byte(width), 0, byte(height), 0, 0, 0, 0, byte(depth), MaskNone,
+		CompressionNone, 0, 0, 0, 1, 1, 0, byte(width), 0, byte(height)}
+	form := &IFFChunk{ID: "FORM", SubID: "ILBM", Childs: []*IFFChunk{{ID: "BMHD", Data: bmhd}}}
+	form.Childs = append(form.Childs, chunks...)
+	form.Childs = append(form.Childs, &IFFChunk{ID: "BODY", Data: body})
+	return form
+}
+
+func TestViewMode(t *testing.T) {
+	var tests = []struct {
+		name    string
+		camg    uint32
+		want    ViewMode
+		isHAM   bool
+		isEHB   bool
+		monitor string
+		flags   string
+	}{
+		{"OldHAM", 0x00004804, 0x00000804, true, false, "Default", "LACE HAM"},
+		{"PALHires", 0x00029004, 0x00029004, false, false, "PAL", "HIRES LACE EXTENDED_MODE"},
+		{"EHB", 0x00000080, 0x00000080, false, true, "Default", "EHB"},
+		{"Junk", 0x12340800, 0, false, false, "Default", ""},
+		{"GraphicsCard", 0x50051000, 0x50051000, false, false, "Graphics Card (0x5005)", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vm := SanitizeViewMode(tt.camg)
+			if vm != tt.want {
+				t.Errorf("ViewMode: got 0x%08X, want 0x%08X", uint32(vm), uint32(tt.want))
+			}
+			if vm.IsHAM() != tt.isHAM {
+				t.Errorf("IsHAM: got %t, want %t", vm.IsHAM(), tt.isHAM)
+			}
+			if vm.IsEHB() != tt.isEHB {
+				t.Errorf("IsEHB: got %t, want %t", vm.IsEHB(), tt.isEHB)
+			}
+			if vm.MonitorName() != tt.monitor {
+				t.Errorf("Monitor: got %q, want %q", vm.MonitorName(), tt.monitor)
+			}
+			if flags := strings.Join(vm.FlagNames(), " "); flags != tt.flags {
+				t.Errorf("Flags: got %q, want %q", flags, tt.flags)
+			}
+		})
+	}
+}
+
+func TestDecodeILBMHAM(t *testing.T) {
+	var tests = []struct {
+		name   string
+		depth  int
+		pixels []uint32
+		want   []color.NRGBA
+	}{
+		// register 1, modify blue, modify red, modify green
+		{"HAM6", 6, []uint32{0x01, 0x1F, 0x28, 0x33},
+			[]color.NRGBA{{0x12, 0x20, 0x30, 255}, {0x12, 0x20, 0xFF, 255},
+				{0x88, 0x20, 0xFF, 255}, {0x88, 0x33, 0xFF, 255}}},
+		{"HAM8", 8, []uint32{0x01, 0x7F, 0x80, 0xC1},
+			[]color.NRGBA{{0x12, 0x20, 0x30, 255}, {0x12, 0x20, 0xFF, 255},
+				{0x00, 0x20, 0xFF, 255}, {0x00, 0x04, 0xFF, 255}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form := makeTestILBM([][]uint32{tt.pixels}, tt.depth,
+				&IFFChunk{ID: "CMAP", Data: []byte{0, 0, 0, 0x12, 0x20, 0x30}},
+				&IFFChunk{ID: "CAMG", Data: []byte{0, 0, 0x08, 0x00}})
+
+			img, err := DecodeILBM(form)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for x, want := range tt.want {
+				if got := img.At(x, 0).(color.NRGBA); got != want {
+					t.Errorf("Pixel %d: got %v, want %v", x, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeILBMEHB(t *testing.T) {
+	cmap := make([]byte, 32*3)
+	cmap[3], cmap[4], cmap[5] = 0xFE, 0x80, 0x40
+
+	var tests = []struct {
+		name string
+		camg []byte
+		want color.NRGBA
+	}{
+		{"NoCAMG", nil, color.NRGBA{0x7F, 0x40, 0x20, 255}},
+		{"CAMGEHB", []byte{0, 0, 0, 0x80}, color.NRGBA{0x7F, 0x40, 0x20, 255}},
+		{"CAMGLores", []byte{0, 0, 0, 0}, color.NRGBA{0, 0, 0, 255}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chunks := []*IFFChunk{{ID: "CMAP", Data: cmap}}
+			if tt.camg != nil {
+				chunks = append(chunks, &IFFChunk{ID: "CAMG", Data: tt.camg})
+			}
+			form := makeTestILBM([][]uint32{{1, 33}}, 6, chunks...)
+
+			img, err := DecodeILBM(form)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := color.NRGBAModel.Convert(img.At(1, 0)).(color.NRGBA); got != tt.want {
+				t.Errorf("Pixel 1: got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeILBMLinePalettes(t *testing.T) {
+	sham := []byte{0, 0}
+	for y := 0; y < 2; y++ {
+		colors := make([]byte, 32)
+		colors[2], colors[3] = 0x0F, byte(y*0x0F) // register 1: 0xF00 or 0xF0F
+		sham = append(sham, colors...)
+	}
+
+	pchgSmall := []byte{0, 0, 0, PchgSmallLines, 0, 0, 0, 2, 0, 1, 0, 1, 0, 1, 0, 1, 0, 0, 0, 1,
+		0x40, 0, 0, 0, // line mask: line 1 changes
+		1, 0, 0x10, 0xF0} // register 1 = 0x0F0
+	pchgBig := []byte{0, 0, 0, PchgBigLines, 0, 0, 0, 2, 0, 1, 0, 1, 0, 1, 0, 1, 0, 0, 0, 1,
+		0x40, 0, 0, 0, // line mask: line 1 changes
+		0, 1, 0, 1, 0, 0x11, 0x33, 0x22} // register 1 = alpha, red, blue, green
+
+	var tests = []struct {
+		name  string
+		chunk *IFFChunk
+		want  [2]color.NRGBA
+	}{
+		{"SHAM", &IFFChunk{ID: "SHAM", Data: sham},
+			[2]color.NRGBA{{255, 0, 0, 255}, {255, 0, 255, 255}}},
+		{"CTBL", &IFFChunk{ID: "CTBL", Data: sham[2:]},
+			[2]color.NRGBA{{255, 0, 0, 255}, {255, 0, 255, 255}}},
+		{"PCHGSmall", &IFFChunk{ID: "PCHG", Data: pchgSmall},
+			[2]color.NRGBA{{1, 1, 1, 255}, {0, 255, 0, 255}}},
+		{"PCHGBig", &IFFChunk{ID: "PCHG", Data: pchgBig},
+			[2]color.NRGBA{{1, 1, 1, 255}, {0x11, 0x22, 0x33, 255}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form := makeTestILBM([][]uint32{{1}, {1}}, 4,
+				&IFFChunk{ID: "CMAP", Data: []byte{0, 0, 0, 1, 1, 1}}, tt.chunk)
+
+			img, err := DecodeILBM(form)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for y, want := range tt.want {
+				if got := img.At(0, y).(color.NRGBA); got != want {
+					t.Errorf("Line %d: got %v, want %v", y, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestDecodePchgHuffman(t *testing.T) {
+	// the root is a leaf for bit 1, its predecessor a leaf for bit 0;
+	// the second tree jumps back for bit 1 to a node with two more leaves
+	var tests = []struct {
+		name      string
+		tree      []byte
+		data      []byte
+		size      int
+		want      []byte
+		wantIsErr bool
+	}{
+		{"TwoLeaves", []byte{0x01, 'B', 0x00, 'A'}, []byte{0xA0}, 4, []byte("ABAB"), false},
+		{"Jump", []byte{0x01, 'C', 0x00, 'B', 0x01, 'A', 0xFF, 0xFC}, []byte{0xD0}, 3,
+			[]byte("BAC"), false},
+		{"TooShort", []byte{0x01, 'B', 0x00, 'A'}, []byte{0xFF}, 9, []byte("AAAAAAAA"), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodePchgHuffman(tt.data, tt.tree, tt.size)
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("Data: got %q, want %q", got, tt.want)
+			}
+			if (err != nil) != tt.wantIsErr {
+				t.Errorf("Error: got %v, want %t", err, tt.wantIsErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,299 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"encoding/binary"
+	"fmt"
+	"image/color"
+)
+
+// Flags of the PCHG header.
+const (
+	PchgSmallLines = 0x0001
+	PchgBigLines   = 0x0002
+	PchgUseAlpha   = 0x0004
+)
+
+// Compression algorithms of the PCHG header.
+const (
+	PchgCompressionNone    = 0
+	PchgCompressionHuffman = 1
+)
+
+// PchgHeader is the header of a PCHG chunk.
+type PchgHeader struct {
+	Compression  uint16
+	Flags        uint16
+	StartLine    int16
+	LineCount    uint16
+	ChangedLines uint16
+	MinReg       uint16
+	MaxReg       uint16
+	MaxChanges   uint16
+	TotalChanges uint32
+}
+
+// ParsePchgHeader decodes the header at the start of a PCHG chunk.
+func ParsePchgHeader(data []byte) (PchgHeader, error) {
+	var hdr PchgHeader
+	var offset uint32
+	var err error
+
+	if len(data) < 20 {
+		return hdr, fmt.Errorf("PCHG is too short")
+	}
+
+	// the length has been checked, so no errors are expected below
+	hdr.Compression, _ = getBeUword(data, &offset)
+	hdr.Flags, _ = getBeUword(data, &offset)
+	hdr.StartLine, _ = getBeWord(data, &offset)
+	hdr.LineCount, _ = getBeUword(data, &offset)
+	hdr.ChangedLines, _ = getBeUword(data, &offset)
+	hdr.MinReg, _ = getBeUword(data, &offset)
+	hdr.MaxReg, _ = getBeUword(data, &offset)
+	hdr.MaxChanges, _ = getBeUword(data, &offset)
+	hdr.TotalChanges, err = getBeUlong(data, &offset)
+
+	return hdr, err
+}
+
+// rgb4 converts a 12 bit color of the form 0x0RGB.
+func rgb4(value uint16) color.NRGBA {
+	r, g, b := uint8(value>>8&0x0F), uint8(value>>4&0x0F), uint8(value&0x0F)
+	return color.NRGBA{r * 0x11, g * 0x11, b * 0x11, 0xFF}
+}
+
+// parseLinePalettes12 decodes consecutive palettes of 16 colors with
+// 12 bit per color as used by SHAM and CTBL. The palettes are distributed
+// evenly over the lines, e.g. for interlaced pictures which only have
+// one palette for each pair of lines.
+func parseLinePalettes12(base color.Palette, data []byte, height int) []color.Palette {
+	count := len(data) / 32
+	if count == 0 || height == 0 {
+		return nil
+	}
+
+	palettes := make([]color.Palette, count)
+	for i := range palettes {
+		pal := make(color.Palette, max(len(base), 16))
+		copy(pal, base)
+		for c := 0; c < 16; c++ {
+			pal[c] = rgb4(binary.BigEndian.Uint16(data[i*32+c*2:]))
+		}
+		palettes[i] = pal
+	}
+
+	lines := make([]color.Palette, height)
+	for y := range lines {
+		lines[y] = palettes[min(y*count/height, count-1)]
+	}
+	return lines
+}
+
+// ParseSham decodes the data of a SHAM chunk into one palette per line.
+// base is the palette of the CMAP chunk.
+func ParseSham(base color.Palette, data []byte, height int) ([]color.Palette, error) {
+	if len(data) < 2 {
+		return nil, fmt.Errorf("SHAM is too short")
+	}
+	if version := binary.BigEndian.Uint16(data); version != 0 {
+		return nil, fmt.Errorf("unsupported SHAM version: %d", version)
+	}
+	return parseLinePalettes12(base, data[2:], height), nil
+}
+
+// ParseCtbl decodes the data of a CTBL chunk into one palette per line.
+// base is the palette of the CMAP chunk.
+func ParseCtbl(base color.Palette, data []byte, height int) ([]color.Palette, error) {
+	if len(data) < 32 {
+		return nil, fmt.Errorf("CTBL is too short")
+	}
+	return parseLinePalettes12(base, data, height), nil
+}
+
+// DecodePchgHuffman decompresses the data of a PCHG chunk until size bytes
+// are produced. tree is the Huffman tree as stored in the chunk, its last
+// entry is the root node.
+// In case of an error, the function returns the bytes decompressed so far
+// and the error.
+func DecodePchgHuffman(data []byte, tree []byte, size int) ([]byte, error) {
+	result := make([]byte, 0, size)
+
+	nodes := make([]int16, len(tree)/2)
+	for i := range nodes {
+		nodes[i] = int16(binary.BigEndian.Uint16(tree[i*2:]))
+	}
+	if len(nodes) == 0 {
+		return result, fmt.Errorf("Huffman tree is empty")
+	}
+
+	root := len(nodes) - 1
+	node := root
+	for pos := 0; len(result) < size; pos++ {
+		if pos >= len(data)*8 {
+			return result, fmt.Errorf("compressed data is too short")
+		}
+
+		if data[pos/8]&(0x80>>(pos%8)) != 0 {
+			if nodes[node] >= 0 {
+				// leaf
+				result = append(result, byte(nodes[node]))
+				node = root
+				continue
+			}
+			// relative byte offset to the next node
+			node += int(nodes[node]) / 2
+		} else {
+			node--
+			if node >= 0 && nodes[node] > 0 && nodes[node]&0x100 != 0 {
+				// leaf
+				result = append(result, byte(nodes[node]))
+				node = root
+				continue
+			}
+		}
+
+		if node < 0 || node >= len(nodes) {
+			return result, fmt.Errorf("invalid Huffman tree")
+		}
+	}
+
+	return result, nil
+}
+
+// ParsePchg decodes the data of a PCHG chunk into one palette per line.
+// base is the palette of the CMAP chunk. Changes of a line persist in all
+// following lines.
+func ParsePchg(base color.Palette, data []byte, height int) ([]color.Palette, error) {
+	hdr, err := ParsePchgHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	data = data[20:]
+
+	switch hdr.Compression {
+	case PchgCompressionNone:
+	case PchgCompressionHuffman:
+		if len(data) < 8 {
+			return nil, fmt.Errorf("PCHG compression header is too short")
+		}
+		treeSize := int(binary.BigEndian.Uint32(data))
+		originalSize := int(binary.BigEndian.Uint32(data[4:]))
+		if treeSize > len(data)-8 {
+			return nil, fmt.Errorf("PCHG Huffman tree is too short")
+		}
+		data, err = DecodePchgHuffman(data[8+treeSize:], data[8:8+treeSize], originalSize)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported PCHG compression: %d", hdr.Compression)
+	}
+
+	// the line mask contains one bit per line with changes
+	maskSize := (int(hdr.LineCount) + 31) / 32 * 4
+	if len(data) < maskSize {
+		return nil, fmt.Errorf("PCHG line mask is too short")
+	}
+	lineMask := data[:maskSize]
+	pos := maskSize
+
+	registers := 256
+	if hdr.Flags&PchgSmallLines != 0 {
+		registers = 32
+	}
+	current := make(color.Palette, max(len(base), registers, int(hdr.MaxReg)+1))
+	for i := range current {
+		if i < len(base) {
+			current[i] = base[i]
+		} else {
+			current[i] = color.NRGBA{0, 0, 0, 0xFF}
+		}
+	}
+
+	lines := make([]color.Palette, height)
+	line := int(hdr.StartLine)
+	for y := 0; y < min(line, height); y++ {
+		lines[y] = current
+	}
+
+	for i := 0; i < int(hdr.LineCount); i++ {
+		if lineMask[i/8]&(0x80>>(i%8)) != 0 {
+			current, pos, err = applyPchgLine(hdr.Flags, current, data, pos)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if y := line + i; y >= 0 && y < height {
+			lines[y] = current
+		}
+	}
+	for y := max(line+int(hdr.LineCount), 0); y < height; y++ {
+		lines[y] = current
+	}
+
+	return lines, nil
+}
+
+// applyPchgLine applies the changes of a single line, starting at pos, to
+// a copy of the palette. It returns the new palette and the position
+// after the changes.
+func applyPchgLine(flags uint16, palette color.Palette, data []byte, pos int) (
+	color.Palette, int, error) {
+
+	palette = append(color.Palette(nil), palette...)
+	set := func(reg int, c color.NRGBA) {
+		if reg < len(palette) {
+			palette[reg] = c
+		}
+	}
+
+	switch {
+	case flags&PchgSmallLines != 0:
+		// changes of registers 0-15 and 16-31 with 12 bit colors
+		if pos+2 > len(data) {
+			return nil, pos, fmt.Errorf("PCHG line data is too short")
+		}
+		count16, count32 := int(data[pos]), int(data[pos+1])
+		pos += 2
+		if pos+(count16+count32)*2 > len(data) {
+			return nil, pos, fmt.Errorf("PCHG line data is too short")
+		}
+		for i := 0; i < count16+count32; i++ {
+			value := binary.BigEndian.Uint16(data[pos:])
+			pos += 2
+			reg := int(value >> 12)
+			if i >= count16 {
+				reg += 16
+			}
+			set(reg, rgb4(value))
+		}
+
+	case flags&PchgBigLines != 0:
+		// register, alpha, red, blue and green with 8 bit per component
+		if pos+2 > len(data) {
+			return nil, pos, fmt.Errorf("PCHG line data is too short")
+		}
+		count := int(binary.BigEndian.Uint16(data[pos:]))
+		pos += 2
+		if pos+count*6 > len(data) {
+			return nil, pos, fmt.Errorf("PCHG line data is too short")
+		}
+		for i := 0; i < count; i++ {
+			reg := int(binary.BigEndian.Uint16(data[pos:]))
+			alpha := uint8(0xFF)
+			if flags&PchgUseAlpha != 0 {
+				alpha = data[pos+2]
+			}
+			set(reg, color.NRGBA{data[pos+3], data[pos+5], data[pos+4], alpha})
+			pos += 6
+		}
+
+	default:
+		return nil, pos, fmt.Errorf("PCHG has neither small nor big lines")
+	}
+
+	return palette, pos, nil
+}
@@ -0,0 +1,243 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// lenientParser contains the state of ReadIFFFileLenient.
+type lenientParser struct {
+	data  []byte
+	diags []Diagnostic
+}
+
+// ReadIFFFileLenient reads an IFF file like ReadIFFFile, but doesn't stop
+// at the first problem. Chunks which extend beyond the end of the file or
+// their parent are marked as Truncated and contain the available bytes.
+// After an invalid chunk ID the parser resynchronises on the next
+// plausible chunk header.
+// fileLen is the length of the file in bytes.
+// It returns the (partial) root chunk and a list of diagnostics. An error
+// is only returned if the file couldn't be read or no root chunk was found.
+func ReadIFFFileLenient(reader io.Reader, fileLen int64) (*IFFChunk, []Diagnostic, error) {
+	data := make([]byte, fileLen)
+	n, err := io.ReadFull(reader, data)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return nil, nil, err
+	}
+	if n == 0 {
+		return nil, nil, nil
+	}
+
+	p := lenientParser{data: data[:n]}
+	if int64(n) < fileLen {
+		p.addDiag(SeverityError, int64(n), "",
+			fmt.Sprintf("file is truncated, %d of %d bytes available", n, fileLen))
+	}
+
+	root := p.parseRoot()
+	if root == nil {
+		return nil, p.diags, fmt.Errorf("no FORM, CAT, or LIST chunk found")
+	}
+
+	return root, p.diags, nil
+}
+
+// addDiag appends a diagnostic.
+func (p *lenientParser) addDiag(severity Severity, offset int64, path string, message string) {
+	p.diags = append(p.diags, Diagnostic{
+		Severity: severity,
+		Offset:   offset,
+		Path:     path,
+		Message:  message,
+	})
+}
+
+// parseRoot parses the root chunk. If the file doesn't start with
+// FORM, CAT or LIST, the first group chunk in the file is used.
+func (p *lenientParser) parseRoot() *IFFChunk {
+	end := int64(len(p.data))
+
+	pos := int64(0)
+	for ; pos+12 <= end; pos++ {
+		id := string(p.data[pos : pos+4])
+		if (id == "FORM" || id == "CAT " || id == "LIST") &&
+			isValidID(string(p.data[pos+8:pos+12])) {
+			break
+		}
+	}
+	if pos+12 > end {
+		p.addDiag(SeverityError, 0, "", "file doesn't contain a FORM, CAT, or LIST chunk")
+		return nil
+	}
+	if pos > 0 {
+		p.addDiag(SeverityError, 0, "",
+			fmt.Sprintf("file doesn't start with FORM, CAT, or LIST, skipped %d bytes", pos))
+	}
+
+	root, _ := p.parseChunk(nil, "", -1, pos, end)
+	if root != nil {
+		root.TrailingSize = end - root.EndOffset
+	}
+	return root
+}
+
+// parseChunk recursively parses the chunk at pos. end is the end of the
+// parent chunk or the file. parentPath and index are used to build the
+// chunk path for diagnostics.
+// It returns the chunk, or nil if no chunk could be parsed, and the
+// position where parsing continues.
+func (p *lenientParser) parseChunk(parent *IFFChunk, parentPath string, index int,
+	pos int64, end int64) (*IFFChunk, int64) {
+
+	if end-pos < 8 {
+		p.addDiag(SeverityWarning, pos, parentPath,
+			fmt.Sprintf("%d stray bytes at the end of the chunk", end-pos))
+		return nil, end
+	}
+
+	id := string(p.data[pos : pos+4])
+	if !isValidID(id) {
+		next := p.resync(pos+1, end)
+		p.addDiag(SeverityError, pos, parentPath,
+			fmt.Sprintf("invalid chunk ID %q, skipped %d bytes", id, next-pos))
+		return nil, next
+	}
+
+	chunk := IFFChunk{
+		ID:     id,
+		Size:   binary.BigEndian.Uint32(p.data[pos+4 : pos+8]),
+		Offset: pos,
+	}
+	claimedEnd := pos + 8 + int64(chunk.Size)
+
+	if isGroup(chunk.ID) {
+		if end-pos < 12 {
+			p.addDiag(SeverityError, pos, parentPath,
+				fmt.Sprintf("%s chunk is truncated before its SubID", chunk.ID))
+			return nil, end
+		}
+		chunk.SubID = string(p.data[pos+8 : pos+12])
+		chunk.DataOffset = pos + 12
+		path := ChunkPath(parentPath, &chunk, index)
+
+		if !isValidID(chunk.SubID) {
+			p.addDiag(SeverityError, pos+8, path,
+				fmt.Sprintf("invalid SubID %q", chunk.SubID))
+		}
+
+		groupEnd := claimedEnd
+		if chunk.Size < 4 {
+			p.addDiag(SeverityError, pos+4, path,
+				fmt.Sprintf("size %d is too small for a group chunk", chunk.Size))
+			groupEnd = pos + 12
+		} else if claimedEnd > end {
+			chunk.Truncated = true
+			p.addDiag(SeverityError, pos, path, truncationMessage(parent,
+				fmt.Sprintf("%d of %d bytes available", end-pos-8, chunk.Size)))
+			groupEnd = end
+		}
+
+		childPos := chunk.DataOffset
+		for childPos < groupEnd {
+			child, next := p.parseChunk(&chunk, path, len(chunk.Childs), childPos, groupEnd)
+			if child != nil {
+				chunk.Childs = append(chunk.Childs, child)
+			}
+			if next <= childPos {
+				break
+			}
+			childPos = next
+		}
+
+		chunk.EndOffset = groupEnd
+		chunk.SumSize = chunk.EndOffset - chunk.Offset
+		return &chunk, groupEnd
+	}
+
+	// we have a data chunk
+	if parent != nil {
+		chunk.ParentType = parent.SubID
+	}
+	chunk.DataOffset = pos + 8
+	path := ChunkPath(parentPath, &chunk, index)
+
+	dataEnd := claimedEnd
+	if claimedEnd > end {
+		chunk.Truncated = true
+		dataEnd = end
+		if parent != nil && !parent.Truncated {
+			// the parent is complete, so the size is probably corrupt
+			dataEnd = p.resync(chunk.DataOffset, end)
+		}
+		p.addDiag(SeverityError, pos, path, truncationMessage(parent,
+			fmt.Sprintf("%d of %d bytes available", dataEnd-chunk.DataOffset, chunk.Size)))
+	}
+	chunk.Data = make([]byte, dataEnd-chunk.DataOffset)
+	copy(chunk.Data, p.data[chunk.DataOffset:dataEnd])
+
+	next := dataEnd
+	if chunk.Size%2 != 0 && !chunk.Truncated {
+		// a pad byte is never the start of a chunk ID, so if the next
+		// chunk seems to start right here, the pad byte is missing
+		if next < end && (p.data[next] == 0 || !p.isPlausibleChunk(next, end)) {
+			next++
+		} else {
+			p.addDiag(SeverityWarning, next, path, "missing pad byte after odd sized chunk")
+		}
+	}
+
+	chunk.EndOffset = next
+	chunk.SumSize = chunk.EndOffset - chunk.Offset
+	return &chunk, next
+}
+
+// truncationMessage returns the message for a chunk which extends beyond
+// its parent or the file. details is appended to the message.
+func truncationMessage(parent *IFFChunk, details string) string {
+	if parent != nil && !parent.Truncated {
+		return "chunk extends beyond its parent, " + details
+	}
+	return "chunk is truncated, " + details
+}
+
+// resync searches the next plausible chunk header between from and end.
+// It returns its position or end if there is none.
+func (p *lenientParser) resync(from int64, end int64) int64 {
+	for pos := from; pos+8 <= end; pos++ {
+		if p.isPlausibleChunk(pos, end) {
+			return pos
+		}
+	}
+	return end
+}
+
+// isPlausibleChunk returns true if a chunk header which looks like a
+// typical chunk ID is located at pos and the chunk fits before end.
+// Typical chunk IDs consist of upper case letters, digits and trailing
+// spaces, with "(c) " as the only exception.
+func (p *lenientParser) isPlausibleChunk(pos int64, end int64) bool {
+	if pos+8 > end {
+		return false
+	}
+
+	id := string(p.data[pos : pos+4])
+	if id != "(c) " {
+		if id[0] == ' ' {
+			return false
+		}
+		for i := 0; i < 4; i++ {
+			c := id[i]
+			if !(c >= 'A' && c <= 'Z') && !(c >= '0' && c <= '9') && c != ' ' {
+				return false
+			}
+		}
+	}
+
+	size := int64(binary.BigEndian.Uint32(p.data[pos+4 : pos+8]))
+	return pos+8+size <= end
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestReadIFFFileLenient(t *testing.T) {
+	orig, _ := readTestIFF(t, "test.bsh")
+
+	var tests = []struct {
+		name       string
+		modify     func(data []byte) []byte
+		wantIDs    string
+		wantDiags  int
+		wantTrunc  string
+		wantErrors bool
+	}{
+		{"WellFormed", func(data []byte) []byte {
+			return data
+		}, "BMHD CMAP GRAB CAMG DPI  BODY ", 0, "", false},
+		{"Truncated", func(data []byte) []byte {
+			return data[:150]
+		}, "BMHD CMAP GRAB CAMG DPI  BODY ", 2, "BODY", true},
+		{"InvalidID", func(data []byte) []byte {
+			copy(data[0x48:], "\x01\x02\x03\x04")
+			return data
+		}, "BMHD CMAP CAMG DPI  BODY ", 1, "", true},
+		{"CorruptSize", func(data []byte) []byte {
+			copy(data[0x2C:], "\x00\x10\x00\x00")
+			return data
+		}, "BMHD CMAP GRAB CAMG DPI  BODY ", 1, "CMAP", true},
+		{"MissingPad", func(data []byte) []byte {
+			// BMHD with size 19 and without pad byte
+			data[0x07]--
+			data[0x13] = 19
+			return append(data[:0x27], data[0x28:]...)
+		}, "BMHD CMAP GRAB CAMG DPI  BODY ", 1, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := tt.modify(bytes.Clone(orig))
+
+			root, diags, err := ReadIFFFileLenient(bytes.NewReader(data), int64(len(data)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var ids, truncated string
+			for _, child := range root.Childs {
+				ids += child.ID + " "
+				if child.Truncated {
+					truncated += child.ID
+				}
+			}
+			if ids != tt.wantIDs {
+				t.Errorf("IDs: got %q, want %q", ids, tt.wantIDs)
+			}
+			if truncated != tt.wantTrunc {
+				t.Errorf("Truncated: got %q, want %q", truncated, tt.wantTrunc)
+			}
+			if len(diags) != tt.wantDiags {
+				t.Errorf("Diagnostics: got %v, want %d", diags, tt.wantDiags)
+			}
+			if HasErrors(diags) != tt.wantErrors {
+				t.Errorf("HasErrors: got %t, want %t", HasErrors(diags), tt.wantErrors)
+			}
+		})
+	}
+}
+
+func TestReadIFFFileLenientNoRoot(t *testing.T) {
+	data := []byte("This is not an IFF file")
+
+	root, diags, err := ReadIFFFileLenient(bytes.NewReader(data), int64(len(data)))
+	if root != nil || err == nil || len(diags) != 1 {
+		t.Errorf("got %v, %v, %v, want nil, 1 diagnostic and an error", root, diags, err)
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"maps"
+	"slices"
+)
+
+// Status bytes and meta event types of Standard MIDI Files.
+const (
+	midiNoteOff       = 0x80
+	midiNoteOn        = 0x90
+	midiControlChange = 0xB0
+	midiProgramChange = 0xC0
+	midiMeta          = 0xFF
+
+	midiMetaTrackName  = 0x03
+	midiMetaInstrument = 0x04
+	midiMetaEndOfTrack = 0x2F
+	midiMetaTempo      = 0x51
+	midiMetaTimeSig    = 0x58
+	midiMetaKeySig     = 0x59
+
+	midiControlVolume = 7
+)
+
+// smusDefaultVelocity is the velocity of notes before the first dynamic
+// mark, it's mezzo forte.
+const smusDefaultVelocity = 80
+
+// smusDefaultBPM is used if the SHDR chunk contains no tempo.
+const smusDefaultBPM = 120
+
+// midiEvent is an event of a MIDI track at an absolute time.
+type midiEvent struct {
+	ticks int
+	order int // note off before other events before note on
+	data  []byte
+}
+
+// midiTrack collects the events of a MIDI track.
+type midiTrack struct {
+	events []midiEvent
+}
+
+// add appends an event to the track.
+func (t *midiTrack) add(ticks int, order int, data ...byte) {
+	t.events = append(t.events, midiEvent{ticks, order, data})
+}
+
+// meta appends a meta event to the track.
+func (t *midiTrack) meta(ticks int, metaType byte, data []byte) {
+	event := append([]byte{midiMeta, metaType}, appendVarLen(nil, uint32(len(data)))...)
+	t.add(ticks, 1, append(event, data...)...)
+}
+
+// tempo appends a tempo event for the given quarter notes per minute.
+func (t *midiTrack) tempo(ticks int, bpm float64) {
+	usec := uint32(60000000 / bpm)
+	t.meta(ticks, midiMetaTempo, []byte{byte(usec >> 16), byte(usec >> 8), byte(usec)})
+}
+
+// bytes returns the MTrk chunk with the events sorted by time.
+func (t *midiTrack) bytes() []byte {
+	slices.SortStableFunc(t.events, func(a, b midiEvent) int {
+		if a.ticks != b.ticks {
+			return a.ticks - b.ticks
+		}
+		return a.order - b.order
+	})
+
+	var data []byte
+	last := 0
+	for _, event := range t.events {
+		data = appendVarLen(data, uint32(event.ticks-last))
+		data = append(data, event.data...)
+		last = event.ticks
+	}
+	data = append(data, 0, midiMeta, midiMetaEndOfTrack, 0)
+
+	chunk := binary.BigEndian.AppendUint32([]byte("MTrk"), uint32(len(data)))
+	return append(chunk, data...)
+}
+
+// appendVarLen appends a variable-length quantity of MIDI files.
+func appendVarLen(data []byte, value uint32) []byte {
+	var buf [5]byte
+	i := len(buf) - 1
+	buf[i] = byte(value & 0x7F)
+	for value >>= 7; value > 0; value >>= 7 {
+		i--
+		buf[i] = byte(value&0x7F) | 0x80
+	}
+	return append(data, buf[i:]...)
+}
+
+// WriteMIDI writes the score as Standard MIDI File of format 1. The first
+// MIDI track contains the name and the tempo of the score, each TRAK
+// becomes a MIDI track which starts on the channel with its number.
+// Instruments with a MIDI reference select their channel and preset,
+// the names of all instruments are added as meta events.
+func WriteMIDI(writer io.Writer, score *SMUSScore) error {
+	var tracks [][]byte
+
+	var conductor midiTrack
+	if score.Name != "" {
+		conductor.meta(0, midiMetaTrackName, []byte(score.Name))
+	}
+	bpm := score.Header.BPM()
+	if bpm == 0 {
+		bpm = smusDefaultBPM
+	}
+	conductor.tempo(0, bpm)
+	tracks = append(tracks, conductor.bytes())
+
+	for i, events := range score.Tracks {
+		tracks = append(tracks, score.midiTrack(events, byte(i%16)).bytes())
+	}
+
+	var out bytes.Buffer
+	out.WriteString("MThd")
+	binary.Write(&out, binary.BigEndian, []uint16{0, 6, 1, uint16(len(tracks)), SMUSTicksPerQuarter})
+	for _, track := range tracks {
+		out.Write(track)
+	}
+
+	_, err := writer.Write(out.Bytes())
+	return err
+}
+
+// midiTrack converts the events of a TRAK chunk into a MIDI track.
+func (score *SMUSScore) midiTrack(events []SMUSEvent, channel byte) *midiTrack {
+	var track midiTrack
+
+	velocity := byte(smusDefaultVelocity)
+	track.add(0, 1, midiControlChange|channel, midiControlVolume, score.Header.Volume&0x7F)
+
+	// notes which are tied to the next note of the same pitch with the
+	// channel of their note on
+	tied := make(map[byte]byte)
+	end := 0
+
+	smusTiming(events, func(event SMUSEvent, ticks int) {
+		switch {
+		case event.IsNote():
+			note := event.SID
+			noteChannel, isTied := tied[note]
+			if !isTied {
+				noteChannel = channel
+				track.add(ticks, 2, midiNoteOn|noteChannel, note, velocity)
+			}
+			if event.Data&noteTieOut != 0 {
+				tied[note] = noteChannel
+			} else {
+				delete(tied, note)
+				track.add(ticks+event.Duration(), 0, midiNoteOff|noteChannel, note, 0)
+			}
+			end = max(end, ticks+event.Duration())
+		case event.SID == SIDRest:
+			end = max(end, ticks+event.Duration())
+		case event.SID == SIDInstrument:
+			ins := score.Instrument(event.Data)
+			if ins == nil {
+				break
+			}
+			if ins.Type == INS1MIDI {
+				channel = ins.Data1 & 0x0F
+				track.add(ticks, 1, midiProgramChange|channel, ins.Data2&0x7F)
+			}
+			if ins.Name != "" {
+				track.meta(ticks, midiMetaInstrument, []byte(ins.Name))
+			}
+		case event.SID == SIDTimeSig:
+			num, _ := event.TimeSignature()
+			track.meta(ticks, midiMetaTimeSig, []byte{byte(num), event.Data & 0x07, 24, 8})
+		case event.SID == SIDKeySig:
+			if event.Data < byte(len(majorKeys)) {
+				track.meta(ticks, midiMetaKeySig, []byte{byte(int8(event.Sharps())), 0})
+			}
+		case event.SID == SIDDynamic:
+			velocity = max(event.Data&0x7F, 1)
+		case event.SID == SIDMIDIChnl:
+			channel = event.Data & 0x0F
+		case event.SID == SIDMIDIPreset:
+			track.add(ticks, 1, midiProgramChange|channel, event.Data&0x7F)
+		case event.SID == SIDTempo:
+			if event.Data > 0 {
+				track.tempo(ticks, float64(event.Data))
+			}
+		}
+	})
+
+	// end notes which are tied to nothing
+	for _, note := range slices.Sorted(maps.Keys(tied)) {
+		track.add(end, 0, midiNoteOff|tied[note], note, 0)
+	}
+
+	return &track
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"image/png"
+	"io"
+)
+
+// PNGHotspotKeyword is the keyword of the PNG tEXt chunk which contains
+// the position of the GRAB chunk as "x,y".
+const PNGHotspotKeyword = "Hotspot"
+
+// pngHeaderSize is the size of the PNG signature and the IHDR chunk.
+const pngHeaderSize = 8 + 12 + 13
+
+// EncodePNG writes the picture as PNG. Transparency from the mask plane
+// or the transparent color becomes the alpha channel. The hotspot of the
+// GRAB chunk is stored in a tEXt chunk, the resolution of the DPI chunk
+// in a pHYs chunk.
+func EncodePNG(writer io.Writer, pic *ILBMPicture) error {
+	var buf bytes.Buffer
+
+	if err := png.Encode(&buf, pic.Image()); err != nil {
+		return err
+	}
+	data := buf.Bytes()
+	if len(data) < pngHeaderSize {
+		return fmt.Errorf("PNG encoder returned too little data")
+	}
+
+	// the metadata is inserted directly after IHDR, pHYs must precede IDAT
+	var meta bytes.Buffer
+	if pic.XDPI > 0 && pic.YDPI > 0 {
+		phys := make([]byte, 9)
+		binary.BigEndian.PutUint32(phys, dpiToPixelsPerMeter(pic.XDPI))
+		binary.BigEndian.PutUint32(phys[4:], dpiToPixelsPerMeter(pic.YDPI))
+		phys[8] = 1 // unit is meter
+		writePNGChunk(&meta, "pHYs", phys)
+	}
+	if pic.Hotspot != nil {
+		text := fmt.Sprintf("%s\x00%d,%d", PNGHotspotKeyword, pic.Hotspot.X, pic.Hotspot.Y)
+		writePNGChunk(&meta, "tEXt", []byte(text))
+	}
+
+	if _, err := writer.Write(data[:pngHeaderSize]); err != nil {
+		return err
+	}
+	if _, err := writer.Write(meta.Bytes()); err != nil {
+		return err
+	}
+	_, err := writer.Write(data[pngHeaderSize:])
+	return err
+}
+
+// dpiToPixelsPerMeter converts dots per inch to pixels per meter.
+func dpiToPixelsPerMeter(dpi uint16) uint32 {
+	return uint32((float64(dpi) / 0.0254) + 0.5)
+}
+
+// writePNGChunk appends a PNG chunk with length and CRC to the buffer.
+func writePNGChunk(buf *bytes.Buffer, id string, data []byte) {
+	var header [4]byte
+
+	binary.BigEndian.PutUint32(header[:], uint32(len(data)))
+	buf.Write(header[:])
+
+	crc := crc32.NewIEEE()
+	crc.Write([]byte(id))
+	crc.Write(data)
+	buf.WriteString(id)
+	buf.Write(data)
+
+	binary.BigEndian.PutUint32(header[:], crc.Sum32())
+	buf.Write(header[:])
+}
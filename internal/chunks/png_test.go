@@ -0,0 +1,104 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/png"
+	"testing"
+)
+
+// pngChunks returns the data of all chunks of a PNG stream by their ID.
+func pngChunks(t *testing.T, data []byte) map[string][]byte {
+	result := make(map[string][]byte)
+
+	for pos := 8; pos+8 <= len(data); {
+		size := int(binary.BigEndian.Uint32(data[pos:]))
+		id := string(data[pos+4 : pos+8])
+		if pos+12+size > len(data) {
+			t.Fatalf("PNG chunk %s is truncated", id)
+		}
+		result[id] = data[pos+8 : pos+8+size]
+		pos += 12 + size
+	}
+	return result
+}
+
+func TestEncodePNG(t *testing.T) {
+	_, root := readTestIFF(t, "test.bsh")
+	pic, err := DecodeILBMPicture(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := EncodePNG(&buf, pic); err != nil {
+		t.Fatal(err)
+	}
+
+	img, err := png.Decode(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.Bounds() != image.Rect(0, 0, 16, 16) {
+		t.Errorf("Size: got %v, want 16x16", img.Bounds())
+	}
+	// color 0 is the transparent color
+	for _, pt := range []image.Point{{0, 0}, {15, 15}} {
+		index := pic.Bitmap.ColorIndex(pt.X, pt.Y)
+		_, _, _, a := img.At(pt.X, pt.Y).RGBA()
+		if (index == 0) != (a == 0) {
+			t.Errorf("Alpha %v: got %d for color %d", pt, a, index)
+		}
+	}
+
+	pngData := pngChunks(t, buf.Bytes())
+	if pic.Hotspot != nil {
+		want := "Hotspot\x00" + fmt.Sprintf("%d,%d", pic.Hotspot.X, pic.Hotspot.Y)
+		if got := string(pngData["tEXt"]); got != want {
+			t.Errorf("tEXt: got %q, want %q", got, want)
+		}
+	} else {
+		t.Errorf("Hotspot: got nil, want GRAB position")
+	}
+	if phys, ok := pngData["pHYs"]; !ok || len(phys) != 9 {
+		t.Errorf("pHYs: got %v, want 9 bytes", phys)
+	} else if got, want := binary.BigEndian.Uint32(phys), dpiToPixelsPerMeter(pic.XDPI); got != want {
+		t.Errorf("pHYs: got %d, want %d", got, want)
+	}
+}
+
+func TestDecodeACBM(t *testing.T) {
+	// 8x2 pixels, 2 planes, contiguous
+	bmhd := []byte{0, 8, 0, 2, 0, 0, 0, 0, 2, MaskNone, CompressionNone, 0,
+		0, 0, 1, 1, 0, 8, 0, 2}
+	abit := []byte{
+		0xF0, 0x00, 0x00, 0x00, // plane 0, rows 0 and 1
+		0xFF, 0x00, 0x0F, 0x00, // plane 1, rows 0 and 1
+	}
+	form := &IFFChunk{ID: "FORM", SubID: "ACBM", Childs: []*IFFChunk{
+		{ID: "BMHD", Data: bmhd},
+		{ID: "ABIT", Data: abit},
+	}}
+
+	img, err := DecodeILBM(form)
+	if err != nil {
+		t.Fatal(err)
+	}
+	paletted := img.(*image.Paletted)
+	var tests = []struct {
+		x, y int
+		want uint8
+	}{
+		{0, 0, 3}, {4, 0, 2}, {0, 1, 0}, {4, 1, 2},
+	}
+	for _, tt := range tests {
+		if got := paletted.ColorIndexAt(tt.x, tt.y); got != tt.want {
+			t.Errorf("Pixel %d,%d: got %d, want %d", tt.x, tt.y, got, tt.want)
+		}
+	}
+}
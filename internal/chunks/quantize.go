@@ -0,0 +1,199 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"image"
+	"image/color"
+	"slices"
+)
+
+// colorCount is a color of an image and the number of its pixels.
+type colorCount struct {
+	rgb   [3]uint8
+	count int
+}
+
+// colorBox is a box of the RGB color space used by the median cut.
+type colorBox struct {
+	colors []colorCount
+}
+
+// QuantizeImage reduces the colors of the image to at most maxColors with
+// the median cut algorithm. Pixels with an alpha value below 128 are
+// ignored for the palette and get color index 0 if the image contains
+// any; the palette then starts with a transparent entry.
+// Images which already have few enough colors keep them exactly.
+func QuantizeImage(img image.Image, maxColors int) *image.Paletted {
+	bounds := img.Bounds()
+	maxColors = max(min(maxColors, 256), 1)
+
+	// collect the histogram of the opaque pixels
+	counts := make(map[[3]uint8]int)
+	hasTransparent := false
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			if c.A < 0x80 {
+				hasTransparent = true
+				continue
+			}
+			counts[[3]uint8{c.R, c.G, c.B}]++
+		}
+	}
+
+	var palette color.Palette
+	if hasTransparent {
+		palette = append(palette, color.NRGBA{0, 0, 0, 0})
+		maxColors = max(maxColors-1, 1)
+	}
+	for _, c := range medianCut(counts, maxColors) {
+		palette = append(palette, color.NRGBA{c[0], c[1], c[2], 0xFF})
+	}
+	if len(palette) == 0 {
+		palette = append(palette, color.NRGBA{0, 0, 0, 0xFF})
+	}
+
+	result := image.NewPaletted(image.Rect(0, 0, bounds.Dx(), bounds.Dy()), palette)
+	cache := make(map[color.NRGBA]uint8)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			if c.A < 0x80 {
+				result.SetColorIndex(x-bounds.Min.X, y-bounds.Min.Y, 0)
+				continue
+			}
+			c.A = 0xFF
+			index, exists := cache[c]
+			if !exists {
+				index = uint8(nearestOpaque(palette, c))
+				cache[c] = index
+			}
+			result.SetColorIndex(x-bounds.Min.X, y-bounds.Min.Y, index)
+		}
+	}
+
+	return result
+}
+
+// nearestOpaque returns the index of the opaque palette entry which is
+// closest to the color.
+func nearestOpaque(palette color.Palette, c color.NRGBA) int {
+	best, bestDist := 0, -1
+	for i, p := range palette {
+		pc := p.(color.NRGBA)
+		if pc.A == 0 {
+			continue
+		}
+		dr, dg, db := int(pc.R)-int(c.R), int(pc.G)-int(c.G), int(pc.B)-int(c.B)
+		dist := dr*dr + dg*dg + db*db
+		if bestDist < 0 || dist < bestDist {
+			best, bestDist = i, dist
+		}
+	}
+	return best
+}
+
+// medianCut splits the colors of the histogram into at most n boxes and
+// returns the weighted average color of each box.
+func medianCut(counts map[[3]uint8]int, n int) [][3]uint8 {
+	if len(counts) == 0 {
+		return nil
+	}
+
+	all := make([]colorCount, 0, len(counts))
+	for rgb, count := range counts {
+		all = append(all, colorCount{rgb, count})
+	}
+	// sort for reproducible results independent of the map order
+	slices.SortFunc(all, func(a, b colorCount) int {
+		return (int(a.rgb[0])<<16 | int(a.rgb[1])<<8 | int(a.rgb[2])) -
+			(int(b.rgb[0])<<16 | int(b.rgb[1])<<8 | int(b.rgb[2]))
+	})
+
+	boxes := []colorBox{{all}}
+	for len(boxes) < n {
+		// split the box with the largest extent along its longest axis
+		best, bestAxis, bestRange := -1, 0, 0
+		for i, box := range boxes {
+			if len(box.colors) < 2 {
+				continue
+			}
+			axis, r := box.longestAxis()
+			if r > bestRange {
+				best, bestAxis, bestRange = i, axis, r
+			}
+		}
+		if best < 0 {
+			break
+		}
+
+		low, high := boxes[best].split(bestAxis)
+		boxes[best] = low
+		boxes = append(boxes, high)
+	}
+
+	result := make([][3]uint8, len(boxes))
+	for i, box := range boxes {
+		result[i] = box.average()
+	}
+	return result
+}
+
+// longestAxis returns the color component with the largest range and
+// the range.
+func (box colorBox) longestAxis() (int, int) {
+	axis, axisRange := 0, 0
+	for a := 0; a < 3; a++ {
+		lo, hi := 255, 0
+		for _, c := range box.colors {
+			lo = min(lo, int(c.rgb[a]))
+			hi = max(hi, int(c.rgb[a]))
+		}
+		if hi-lo > axisRange {
+			axis, axisRange = a, hi-lo
+		}
+	}
+	return axis, axisRange
+}
+
+// split divides the box at the median pixel along the axis.
+func (box colorBox) split(axis int) (colorBox, colorBox) {
+	colors := slices.Clone(box.colors)
+	slices.SortStableFunc(colors, func(a, b colorCount) int {
+		return int(a.rgb[axis]) - int(b.rgb[axis])
+	})
+
+	total := 0
+	for _, c := range colors {
+		total += c.count
+	}
+	median, sum := 1, 0
+	for i, c := range colors[:len(colors)-1] {
+		sum += c.count
+		median = i + 1
+		if sum*2 >= total {
+			break
+		}
+	}
+
+	return colorBox{colors[:median]}, colorBox{colors[median:]}
+}
+
+// average returns the color of the box weighted by the pixel counts.
+func (box colorBox) average() [3]uint8 {
+	var sum [3]int
+	total := 0
+	for _, c := range box.colors {
+		for a := 0; a < 3; a++ {
+			sum[a] += int(c.rgb[a]) * c.count
+		}
+		total += c.count
+	}
+	return [3]uint8{
+		uint8((sum[0] + total/2) / total),
+		uint8((sum[1] + total/2) / total),
+		uint8((sum[2] + total/2) / total),
+	}
+}
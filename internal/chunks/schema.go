@@ -0,0 +1,455 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"golang.org/x/text/encoding/charmap"
+	"gopkg.in/yaml.v3"
+)
+
+// A chunk schema describes the layout of a chunk type in YAML, so the
+// chunk can be decoded without a handler written in Go:
+//
+//	chunks:
+//	  - type: PREF.PNTR          # chunk type as in structData
+//	    description: Pointer Preferences
+//	    fields:
+//	      - type: pad[16]        # skipped bytes, not shown
+//	      - name: Which
+//	        type: UWORD
+//	        enum: {0: Normal, 1: Busy}
+//	      - name: Flags
+//	        type: ULONG
+//	        format: "0x%08X"     # fmt.Sprintf format of the value
+//	        flags: {0: First Bit, 31: Last Bit}
+//	      - name: Size
+//	        type: UWORD
+//	        unit: bytes
+//	        if: "Which == 1"     # only decoded if the condition is met
+//	      - name: Pens
+//	        type: UWORD
+//	        count: 32            # array, the count can be an earlier field
+//	        item: "Pen %d"       # name of the array items
+//	      - name: Point
+//	        fields:              # nested structure
+//	          - {name: X, type: WORD}
+//	          - {name: Y, type: WORD}
+//
+// The types are UBYTE, BYTE, UWORD, WORD, ULONG and LONG in big-endian
+// byte order, string[n] for ISO-8859-1 texts in a buffer of n bytes,
+// bytes[n] for raw bytes and pad[n] for skipped bytes. string and bytes
+// without size take the rest of the chunk. Conditions compare an integer
+// field with a number using ==, !=, <, <=, >, >= or & (any bit set).
+//
+// Schemas for built-in chunk types are embedded, user-supplied schemas
+// are loaded with LoadSchemaFile or LoadSchemaDir. A schema replaces the
+// handler of an existing chunk type.
+
+//go:embed schemas/*.yaml
+var embeddedSchemas embed.FS
+
+// schemaFile is the top level object of a schema file.
+type schemaFile struct {
+	Chunks []chunkSchema `yaml:"chunks"`
+}
+
+// chunkSchema describes the layout of a chunk type.
+type chunkSchema struct {
+	Type        string        `yaml:"type"`
+	Description string        `yaml:"description"`
+	Fields      []schemaField `yaml:"fields"`
+}
+
+// schemaField describes a field of a chunk or of a nested structure.
+type schemaField struct {
+	Name   string           `yaml:"name"`
+	Type   string           `yaml:"type"`
+	Format string           `yaml:"format"`
+	Unit   string           `yaml:"unit"`
+	Enum   map[int64]string `yaml:"enum"`
+	Flags  map[uint]string  `yaml:"flags"`
+	Count  string           `yaml:"count"`
+	Item   string           `yaml:"item"`
+	If     string           `yaml:"if"`
+	Fields []schemaField    `yaml:"fields"`
+
+	kind string // the type without size, e.g. "string" for "string[32]"
+	size int    // the size of string, bytes and pad, -1 for the rest of the chunk
+	cond *schemaCondition
+}
+
+// schemaCondition is a parsed condition of a field.
+type schemaCondition struct {
+	field string
+	op    string
+	value int64
+}
+
+// schemaIntTypes contains the integer types.
+var schemaIntTypes = map[string]bool{
+	"UBYTE": true, "BYTE": true, "UWORD": true, "WORD": true, "ULONG": true, "LONG": true,
+}
+
+var schemaTypeRegexp = regexp.MustCompile(`^(string|bytes|pad)(?:\[(\d+)\])?$`)
+var schemaCondRegexp = regexp.MustCompile(`^(.+?)\s*(==|!=|<=|>=|<|>|&)\s*(-?\w+)$`)
+
+func init() {
+	if err := loadSchemaFS(embeddedSchemas, "schemas"); err != nil {
+		panic(err)
+	}
+}
+
+// LoadSchemaFile loads the chunk schemas of a YAML file and registers
+// them in the chunk database.
+func LoadSchemaFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return loadSchemas(data, path)
+}
+
+// LoadSchemaDir loads all .yaml and .yml files of a directory. The
+// errors of all files are returned together, the valid files are
+// registered anyway.
+func LoadSchemaDir(dir string) error {
+	return loadSchemaFS(os.DirFS(dir), ".")
+}
+
+// loadSchemaFS loads all schema files of a directory of fsys.
+func loadSchemaFS(fsys fs.FS, dir string) error {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		path := filepath.ToSlash(filepath.Join(dir, entry.Name()))
+		data, err := fs.ReadFile(fsys, path)
+		if err == nil {
+			err = loadSchemas(data, entry.Name())
+		}
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// loadSchemas parses a schema file and registers its chunk types. Either
+// all or none of the chunk types are registered.
+func loadSchemas(data []byte, source string) error {
+	var file schemaFile
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&file); err != nil {
+		return fmt.Errorf("%s: %w", source, err)
+	}
+
+	for i := range file.Chunks {
+		schema := &file.Chunks[i]
+		if err := checkChunkType(schema.Type); err != nil {
+			return fmt.Errorf("%s: %w", source, err)
+		}
+		if err := compileFields(schema.Fields, nil); err != nil {
+			return fmt.Errorf("%s: %s: %w", source, schema.Type, err)
+		}
+	}
+
+	for _, schema := range file.Chunks {
+		// the chunk types were checked above
+		_ = RegisterHandler(schema.Type, schema.decode, schema.Description)
+	}
+	return nil
+}
+
+// compileFields checks a list of fields. known contains the names of the
+// integer fields which can be used by conditions and counts.
+func compileFields(fields []schemaField, known []string) error {
+	for i := range fields {
+		field := &fields[i]
+
+		if field.If != "" {
+			match := schemaCondRegexp.FindStringSubmatch(field.If)
+			if match == nil {
+				return fmt.Errorf("%s: invalid condition %q", field.Name, field.If)
+			}
+			value, err := strconv.ParseInt(match[3], 0, 64)
+			if err != nil {
+				return fmt.Errorf("%s: invalid condition %q", field.Name, field.If)
+			}
+			if !slices.Contains(known, match[1]) {
+				return fmt.Errorf("%s: condition uses unknown field %q", field.Name, match[1])
+			}
+			field.cond = &schemaCondition{match[1], match[2], value}
+		}
+
+		if field.Count != "" {
+			if _, err := strconv.Atoi(field.Count); err != nil && !slices.Contains(known, field.Count) {
+				return fmt.Errorf("%s: count uses unknown field %q", field.Name, field.Count)
+			}
+		}
+
+		switch {
+		case len(field.Fields) > 0:
+			if field.Type != "" {
+				return fmt.Errorf("%s: a structure has no type", field.Name)
+			}
+			field.kind = "struct"
+			if err := compileFields(field.Fields, known); err != nil {
+				return fmt.Errorf("%s: %w", field.Name, err)
+			}
+		case schemaIntTypes[field.Type]:
+			field.kind = field.Type
+			for bit := range field.Flags {
+				if bit > 31 {
+					return fmt.Errorf("%s: invalid flag bit %d", field.Name, bit)
+				}
+			}
+			if field.Count == "" {
+				known = append(known, field.Name)
+			}
+		default:
+			match := schemaTypeRegexp.FindStringSubmatch(field.Type)
+			if match == nil {
+				return fmt.Errorf("%s: unknown type %q", field.Name, field.Type)
+			}
+			field.kind = match[1]
+			field.size = -1
+			if match[2] != "" {
+				field.size, _ = strconv.Atoi(match[2])
+			} else if field.kind == "pad" {
+				return fmt.Errorf("pad needs a size")
+			}
+		}
+
+		if field.Name == "" && field.kind != "pad" {
+			return fmt.Errorf("field %d has no name", i)
+		}
+	}
+	return nil
+}
+
+// schemaScope contains the values of the integer fields decoded so far.
+// Nested structures see the fields of the enclosing structures.
+type schemaScope struct {
+	values map[string]int64
+	parent *schemaScope
+}
+
+// lookup returns the value of an integer field.
+func (scope *schemaScope) lookup(name string) (int64, bool) {
+	for ; scope != nil; scope = scope.parent {
+		if value, ok := scope.values[name]; ok {
+			return value, true
+		}
+	}
+	return 0, false
+}
+
+// decode is the chunk handler of a schema.
+func (schema chunkSchema) decode(data []byte) (StructResult, error) {
+	var offset uint32
+	return decodeSchemaFields(data, &offset, schema.Fields, nil)
+}
+
+// decodeSchemaFields decodes a list of fields. In case of an error the
+// fields decoded so far are returned.
+func decodeSchemaFields(data []byte, offset *uint32, fields []schemaField, parent *schemaScope) (StructResult, error) {
+	var result StructResult
+	scope := &schemaScope{values: make(map[string]int64), parent: parent}
+
+	for _, field := range fields {
+		if field.cond != nil && !field.cond.eval(scope) {
+			continue
+		}
+		if field.kind == "pad" {
+			if _, err := getByteBuffer(data, offset, uint32(field.size)); err != nil {
+				return result, err
+			}
+			continue
+		}
+
+		if field.Count == "" {
+			decoded, err := field.decode(data, offset, scope)
+			if err != nil {
+				if field.kind == "struct" {
+					result = append(result, decoded)
+				}
+				return result, err
+			}
+			result = append(result, decoded)
+			if value, ok := schemaInt(decoded.Raw); ok {
+				scope.values[field.Name] = value
+			}
+			continue
+		}
+
+		count, convErr := strconv.Atoi(field.Count)
+		if convErr != nil {
+			value, _ := scope.lookup(field.Count)
+			count = int(value)
+		}
+		item := field.Item
+		if item == "" {
+			item = field.Name + " %d"
+		}
+		group := newField(field.Name, count)
+		group.Offset = *offset
+		// every item takes at least one byte, so one item more than
+		// the remaining bytes already reaches the end of the data
+		count = min(count, len(data)-int(*offset)+1)
+		var err error
+		for i := 0; i < count; i++ {
+			start := *offset
+			var decoded StructField
+			decoded, err = field.decode(data, offset, scope)
+			if err == nil || field.kind == "struct" {
+				decoded.Name = fmt.Sprintf(item, i)
+				group.Children = append(group.Children, decoded)
+			}
+			// stop at errors and at items without size, e.g. a string
+			// which took the rest of the chunk
+			if err != nil || *offset == start {
+				break
+			}
+		}
+		group.Length = *offset - group.Offset
+		result = append(result, group)
+		if err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// decode decodes a single value of the field.
+func (field schemaField) decode(data []byte, offset *uint32, scope *schemaScope) (StructField, error) {
+	start := *offset
+	var raw any
+	var err error
+
+	switch field.kind {
+	case "struct":
+		var children StructResult
+		children, err = decodeSchemaFields(data, offset, field.Fields, scope)
+		decoded := newField(field.Name, nil)
+		decoded.Offset, decoded.Length, decoded.Children = start, *offset-start, children
+		return decoded, err
+	case "UBYTE":
+		raw, err = getUbyte(data, offset)
+	case "BYTE":
+		raw, err = getByte(data, offset)
+	case "UWORD":
+		raw, err = getBeUword(data, offset)
+	case "WORD":
+		raw, err = getBeWord(data, offset)
+	case "ULONG":
+		raw, err = getBeUlong(data, offset)
+	case "LONG":
+		raw, err = getBeLong(data, offset)
+	case "string", "bytes":
+		size := uint32(field.size)
+		if field.size < 0 {
+			size = uint32(max(len(data)-int(*offset), 0))
+		}
+		var buf []byte
+		if buf, err = getByteBuffer(data, offset, size); err != nil {
+			break
+		}
+		if field.kind == "bytes" {
+			raw = buf
+			break
+		}
+		decoded, _ := charmap.ISO8859_1.NewDecoder().Bytes(buf)
+		raw = cString(decoded)
+	}
+	if err != nil {
+		return StructField{}, err
+	}
+
+	value := fieldValue{raw: raw, unit: field.Unit}
+	if field.Format != "" {
+		value.text = fmt.Sprintf(field.Format, raw)
+	}
+	number, isInt := schemaInt(raw)
+	if name, ok := field.Enum[number]; ok && isInt {
+		value.enum = name
+	}
+
+	decoded := newField(field.Name, value)
+	decoded.Offset, decoded.Length = start, *offset-start
+
+	if isInt {
+		bits := slices.Sorted(maps.Keys(field.Flags))
+		for _, bit := range bits {
+			if mask := int64(1) << bit; number&mask != 0 {
+				flag := newField("Flag", withEnum(mask, field.Flags[bit]))
+				flag.Offset, flag.Length = decoded.Offset, decoded.Length
+				decoded.Children = append(decoded.Children, flag)
+			}
+		}
+	}
+	return decoded, nil
+}
+
+// eval returns true if the condition is met.
+func (cond *schemaCondition) eval(scope *schemaScope) bool {
+	value, ok := scope.lookup(cond.field)
+	if !ok {
+		return false
+	}
+	switch cond.op {
+	case "==":
+		return value == cond.value
+	case "!=":
+		return value != cond.value
+	case "<":
+		return value < cond.value
+	case "<=":
+		return value <= cond.value
+	case ">":
+		return value > cond.value
+	case ">=":
+		return value >= cond.value
+	case "&":
+		return value&cond.value != 0
+	}
+	return false
+}
+
+// schemaInt converts the raw value of an integer field to int64.
+func schemaInt(raw any) (int64, bool) {
+	switch v := raw.(type) {
+	case uint8:
+		return int64(v), true
+	case int8:
+		return int64(v), true
+	case uint16:
+		return int64(v), true
+	case int16:
+		return int64(v), true
+	case uint32:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	}
+	return 0, false
+}
@@ -0,0 +1,204 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const testSchema = `
+chunks:
+  - type: TEST.HEAD
+    description: Test Header
+    fields:
+      - {name: Version, type: UBYTE, enum: {1: First, 2: Second}}
+      - type: pad[1]
+      - {name: Flags, type: UWORD, format: "0x%04X", flags: {0: Bit 0, 15: Bit 15}}
+      - {name: Extra, type: LONG, unit: ms, if: "Version >= 2"}
+      - {name: Count, type: UBYTE}
+      - {name: Values, type: WORD, count: Count, item: "Value %d"}
+      - name: Point
+        fields:
+          - {name: X, type: UWORD}
+          - {name: Y, type: UWORD, if: "X & 0x80"}
+      - {name: Name, type: string}
+`
+
+// loadTestSchema registers testSchema for the duration of the test.
+func loadTestSchema(t *testing.T) {
+	t.Helper()
+	if err := loadSchemas([]byte(testSchema), "test.yaml"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { delete(structData, "TEST.HEAD") })
+}
+
+func TestSchemaDecode(t *testing.T) {
+	loadTestSchema(t)
+
+	var tests = []struct {
+		name string
+		data []byte
+		want []fieldRow
+	}{
+		{"Version 1", []byte{1, 0, 0x80, 0x01, 1, 0xFF, 0xFE, 0, 5, 'a', 'b', 0}, []fieldRow{
+			{"Version", "First", 0, 1},
+			{"Flags", "0x8001", 2, 2},
+			{"  Flag", "Bit 0", 2, 2},
+			{"  Flag", "Bit 15", 2, 2},
+			{"Count", "1", 4, 1},
+			{"Values", "1", 5, 2},
+			{"  Value 0", "-2", 5, 2},
+			{"Point", "", 7, 2},
+			{"  X", "5", 7, 2},
+			{"Name", "ab", 9, 3},
+		}},
+		{"Version 2", []byte{2, 0, 0, 0, 0, 0, 0, 10, 0, 0, 0x80, 0, 3}, []fieldRow{
+			{"Version", "Second", 0, 1},
+			{"Flags", "0x0000", 2, 2},
+			{"Extra", "10 ms", 4, 4},
+			{"Count", "0", 8, 1},
+			{"Values", "0", 9, 0},
+			{"Point", "", 9, 4},
+			{"  X", "128", 9, 2},
+			{"  Y", "3", 11, 2},
+			{"Name", "", 13, 0},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			description, result, err := GetStructData("TEST.HEAD", tt.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if description != "Test Header" {
+				t.Errorf("Description: got %q, want %q", description, "Test Header")
+			}
+			if got := fieldRows(result); !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// a short chunk returns the fields decoded so far
+	_, result, err := GetStructData("TEST.HEAD", []byte{1, 0, 0, 0, 3, 0, 1, 0})
+	if err == nil {
+		t.Errorf("Short: got no error, want error")
+	}
+	if len(result) != 5 || len(result[3].Children) != 1 {
+		t.Errorf("Short: got %v", fieldRows(result))
+	}
+}
+
+func TestSchemaBounds(t *testing.T) {
+	schema := `
+chunks:
+  - type: TEST.HUGE
+    fields:
+      - {name: Count, type: ULONG}
+      - name: Items
+        count: Count
+        fields:
+          - type: pad[2]
+`
+	if err := loadSchemas([]byte(schema), "test.yaml"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { delete(structData, "TEST.HUGE") })
+
+	_, result, err := GetStructData("TEST.HUGE", []byte{0xFF, 0xFF, 0xFF, 0xFF, 0, 0, 0, 0, 0})
+	if err == nil {
+		t.Errorf("Error: got no error, want error")
+	}
+	if len(result) < 2 || len(result[1].Children) != 3 {
+		t.Errorf("Items: got %v", fieldRows(result))
+	}
+}
+
+func TestSchemaErrors(t *testing.T) {
+	var tests = []struct {
+		name   string
+		schema string
+		want   string
+	}{
+		{"Chunk Type", "chunks: [{type: BMHD}]", "invalid chunk type"},
+		{"Unknown Key", "chunks: [{type: TEST.FAIL, size: 4}]", "not found"},
+		{"Type", "chunks: [{type: TEST.FAIL, fields: [{name: A, type: QUAD}]}]", "unknown type"},
+		{"Name", "chunks: [{type: TEST.FAIL, fields: [{type: UBYTE}]}]", "no name"},
+		{"Pad", "chunks: [{type: TEST.FAIL, fields: [{type: pad}]}]", "pad needs a size"},
+		{"Condition", "chunks: [{type: TEST.FAIL, fields: [{name: A, type: UBYTE, if: A}]}]",
+			"invalid condition"},
+		{"Condition Field", "chunks: [{type: TEST.FAIL, fields: [{name: A, type: UBYTE, if: B == 1}]}]",
+			"unknown field"},
+		{"Count Field", "chunks: [{type: TEST.FAIL, fields: [{name: A, type: UBYTE, count: B}]}]",
+			"unknown field"},
+		{"Flag Bit", "chunks: [{type: TEST.FAIL, fields: [{name: A, type: ULONG, flags: {32: X}}]}]",
+			"invalid flag bit"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := loadSchemas([]byte(tt.schema), "test.yaml")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("got %v, want error with %q", err, tt.want)
+			}
+			if _, exists := structData["TEST.FAIL"]; exists {
+				t.Errorf("invalid schema was registered")
+			}
+		})
+	}
+}
+
+func TestLoadSchemaDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "test.yaml"), []byte(testSchema), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "broken.yml"), []byte("chunks: [{type: X}]"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { delete(structData, "TEST.HEAD") })
+
+	err := LoadSchemaDir(dir)
+	if err == nil || !strings.Contains(err.Error(), "broken.yml") {
+		t.Errorf("got %v, want error of broken.yml", err)
+	}
+	if _, exists := structData["TEST.HEAD"]; !exists {
+		t.Errorf("TEST.HEAD: not registered")
+	}
+}
+
+func TestSchemaPrefs(t *testing.T) {
+	pntr := make([]byte, 32)
+	copy(pntr[16:], []byte{0, 1, 0, 64, 0, 16, 0, 16, 0, 2, 0, 16, 0xFF, 0xFF, 0, 3})
+	_, result, err := GetStructData("PREF.PNTR", pntr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []fieldRow{
+		{"Which", "Busy", 16, 2},
+		{"Size", "64", 18, 2},
+		{"Width", "16", 20, 2},
+		{"Height", "16", 22, 2},
+		{"Depth", "2", 24, 2},
+		{"YSize", "16", 26, 2},
+		{"Hotspot", "", 28, 4},
+		{"  X", "-1", 28, 2},
+		{"  Y", "3", 30, 2},
+	}
+	if got := fieldRows(result); !slices.Equal(got, want) {
+		t.Errorf("PNTR: got %v, want %v", got, want)
+	}
+
+	// all embedded preferences schemas decode a zeroed chunk
+	for _, chType := range []string{"PREF.PRHD", "PREF.ASL ", "PREF.FONT", "PREF.ICTL", "PREF.INPT",
+		"PREF.KMSW", "PREF.LCLE", "PREF.OSCN", "PREF.PALT", "PREF.NPTR"} {
+		if _, _, err := GetStructData(chType, make([]byte, 1024)); err != nil {
+			t.Errorf("%s: %v", chType, err)
+		}
+	}
+}
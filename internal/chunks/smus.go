@@ -0,0 +1,324 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Event types (sID) of SMUS tracks. 0 to 127 are notes.
+const (
+	SIDLastNote   = 127
+	SIDRest       = 128
+	SIDInstrument = 129
+	SIDTimeSig    = 130
+	SIDKeySig     = 131
+	SIDDynamic    = 132
+	SIDMIDIChnl   = 133
+	SIDMIDIPreset = 134
+	SIDClef       = 135 // 0 = treble, 1 = bass, 2 = alto, 3 = tenor
+	SIDTempo      = 136 // quarter notes per minute
+	SIDMark       = 255
+)
+
+// Bits of the data of note and rest events.
+const (
+	noteChord    = 0x80 // the next note starts at the same time
+	noteTieOut   = 0x40 // the note is tied to the next note of the same pitch
+	noteTupletSh = 4    // 0 = none, 1 = triplet, 2 = quintuplet, 3 = septuplet
+	noteTuplet   = 0x30
+	noteDot      = 0x08
+	noteDivision = 0x07 // 0 = whole note, 1 = half note, ... 7 = 128th note
+)
+
+// Instrument reference types of INS1 chunks.
+const (
+	INS1Name = 0 // the instrument is found by its name
+	INS1MIDI = 1 // MIDI channel and preset
+)
+
+// SMUSTicksPerQuarter is the time resolution of SMUS events. It's the
+// smallest number of ticks which represents all durations exactly,
+// down to a dotted 128th septuplet.
+const SMUSTicksPerQuarter = 6720
+
+var noteDivisions = []string{"whole", "half", "quarter", "eighth", "16th", "32nd", "64th", "128th"}
+var noteTuplets = []string{"", "triplet", "quintuplet", "septuplet"}
+var noteNames = []string{"C", "C#", "D", "D#", "E", "F", "F#", "G", "G#", "A", "A#", "B"}
+var smusClefs = []string{"Treble", "Bass", "Alto", "Tenor"}
+
+// majorKeys contains the major keys of the key signatures 0 to 14:
+// C major, 1 to 7 sharps and 1 to 7 flats.
+var majorKeys = []string{"C", "G", "D", "A", "E", "B", "F#", "C#",
+	"F", "Bb", "Eb", "Ab", "Db", "Gb", "Cb"}
+
+// SMUSHeader contains the data of an SHDR chunk.
+type SMUSHeader struct {
+	Tempo  uint16 // 128ths of quarter notes per minute
+	Volume uint8  // 0-127
+	Tracks uint8
+}
+
+// SMUSInstrument contains the data of an INS1 chunk.
+type SMUSInstrument struct {
+	Register uint8
+	Type     uint8 // INS1Name or INS1MIDI
+	Data1    uint8 // MIDI channel for INS1MIDI
+	Data2    uint8 // MIDI preset for INS1MIDI
+	Name     string
+}
+
+// SMUSEvent is an event of a TRAK chunk.
+type SMUSEvent struct {
+	SID  uint8
+	Data uint8
+}
+
+// SMUSScore contains the decoded data of an SMUS FORM.
+type SMUSScore struct {
+	Header      SMUSHeader
+	Name        string
+	Instruments []SMUSInstrument
+	Tracks      [][]SMUSEvent
+}
+
+// ParseSMUSHeader decodes an SHDR chunk.
+func ParseSMUSHeader(data []byte) (SMUSHeader, error) {
+	var shdr SMUSHeader
+	var offset uint32
+	var err error
+
+	//typedef struct {
+	//	UWORD tempo;    // 128ths of quarter notes per minute
+	//	UBYTE volume;   // 0-127
+	//	UBYTE ctTrack;  // number of tracks
+	//} SScoreHeader;
+
+	if shdr.Tempo, err = getBeUword(data, &offset); err != nil {
+		return shdr, err
+	}
+	if shdr.Volume, err = getUbyte(data, &offset); err != nil {
+		return shdr, err
+	}
+	if shdr.Tracks, err = getUbyte(data, &offset); err != nil {
+		return shdr, err
+	}
+
+	return shdr, nil
+}
+
+// BPM returns the tempo in quarter notes per minute.
+func (shdr SMUSHeader) BPM() float64 {
+	return float64(shdr.Tempo) / 128
+}
+
+// ParseSMUSInstrument decodes an INS1 chunk.
+func ParseSMUSInstrument(data []byte) (SMUSInstrument, error) {
+	var ins SMUSInstrument
+	var offset uint32
+	var err error
+
+	//typedef struct {
+	//	UBYTE register;  // instrument register, selected by SID_Instrument
+	//	UBYTE type;      // 0 = name, 1 = MIDI
+	//	UBYTE data1;     // MIDI channel
+	//	UBYTE data2;     // MIDI preset
+	//	char name[];     // not zero terminated
+	//} RefInstrument;
+
+	fields := []*uint8{&ins.Register, &ins.Type, &ins.Data1, &ins.Data2}
+	for _, field := range fields {
+		if *field, err = getUbyte(data, &offset); err != nil {
+			return ins, err
+		}
+	}
+	ins.Name = cString(data[offset:])
+
+	return ins, nil
+}
+
+// ParseSMUSTrack decodes the events of a TRAK chunk. A trailing odd byte
+// is ignored.
+func ParseSMUSTrack(data []byte) []SMUSEvent {
+	events := make([]SMUSEvent, 0, len(data)/2)
+	for i := 0; i+1 < len(data); i += 2 {
+		events = append(events, SMUSEvent{data[i], data[i+1]})
+	}
+	return events
+}
+
+// IsNote returns true for note events.
+func (e SMUSEvent) IsNote() bool {
+	return e.SID <= SIDLastNote
+}
+
+// Duration returns the duration of a note or a rest in ticks, see
+// SMUSTicksPerQuarter.
+func (e SMUSEvent) Duration() int {
+	ticks := 4 * SMUSTicksPerQuarter >> (e.Data & noteDivision)
+	if e.Data&noteDot != 0 {
+		ticks = ticks * 3 / 2
+	}
+	switch (e.Data & noteTuplet) >> noteTupletSh {
+	case 1:
+		ticks = ticks * 2 / 3
+	case 2:
+		ticks = ticks * 4 / 5
+	case 3:
+		ticks = ticks * 6 / 7
+	}
+	return ticks
+}
+
+// NoteName returns the name of a MIDI note number, e.g. C4 for 60.
+func NoteName(note uint8) string {
+	return fmt.Sprintf("%s%d", noteNames[note%12], int(note)/12-1)
+}
+
+// String describes the event, e.g. "Note C4, dotted quarter, chord".
+func (e SMUSEvent) String() string {
+	switch {
+	case e.IsNote() || e.SID == SIDRest:
+		text := "Rest"
+		if e.IsNote() {
+			text = "Note " + NoteName(e.SID)
+		}
+		duration := noteDivisions[e.Data&noteDivision]
+		if e.Data&noteDot != 0 {
+			duration = "dotted " + duration
+		}
+		if tuplet := noteTuplets[(e.Data&noteTuplet)>>noteTupletSh]; tuplet != "" {
+			duration += " " + tuplet
+		}
+		text += ", " + duration
+		if e.Data&noteChord != 0 {
+			text += ", chord"
+		}
+		if e.Data&noteTieOut != 0 {
+			text += ", tied"
+		}
+		return text
+	case e.SID == SIDInstrument:
+		return fmt.Sprintf("Instrument %d", e.Data)
+	case e.SID == SIDTimeSig:
+		num, denom := e.TimeSignature()
+		return fmt.Sprintf("Time Signature %d/%d", num, denom)
+	case e.SID == SIDKeySig:
+		return "Key Signature " + describeKeySignature(e.Data)
+	case e.SID == SIDDynamic:
+		return fmt.Sprintf("Dynamic %d", e.Data)
+	case e.SID == SIDMIDIChnl:
+		return fmt.Sprintf("MIDI Channel %d", e.Data+1)
+	case e.SID == SIDMIDIPreset:
+		return fmt.Sprintf("MIDI Preset %d", e.Data)
+	case e.SID == SIDClef:
+		if int(e.Data) < len(smusClefs) {
+			return "Clef " + smusClefs[e.Data]
+		}
+		return fmt.Sprintf("Clef %d", e.Data)
+	case e.SID == SIDTempo:
+		return fmt.Sprintf("Tempo %d", e.Data)
+	case e.SID == SIDMark:
+		return "Mark"
+	}
+	return fmt.Sprintf("Unknown (sID %d, data %d)", e.SID, e.Data)
+}
+
+// TimeSignature returns numerator and denominator of a time signature
+// event.
+func (e SMUSEvent) TimeSignature() (int, int) {
+	return int(e.Data>>3) + 1, 1 << (e.Data & 0x07)
+}
+
+// Sharps returns the number of sharps of a key signature event, flats are
+// negative.
+func (e SMUSEvent) Sharps() int {
+	if e.Data > 7 {
+		return 7 - int(e.Data)
+	}
+	return int(e.Data)
+}
+
+// describeKeySignature returns the description of a key signature, e.g.
+// "2 sharps (D major)".
+func describeKeySignature(data uint8) string {
+	if int(data) >= len(majorKeys) {
+		return fmt.Sprintf("Unknown (%d)", data)
+	}
+	sharps := SMUSEvent{SIDKeySig, data}.Sharps()
+	switch {
+	case sharps == 0:
+		return "C major"
+	case sharps > 0:
+		return fmt.Sprintf("%d sharps (%s major)", sharps, majorKeys[data])
+	default:
+		return fmt.Sprintf("%d flats (%s major)", -sharps, majorKeys[data])
+	}
+}
+
+// smusTiming calls fn for each event of the track with its start time in
+// ticks. Notes of a chord start at the same time, the time advances by
+// the duration of the last note of the chord.
+func smusTiming(events []SMUSEvent, fn func(event SMUSEvent, ticks int)) {
+	ticks := 0
+	for _, event := range events {
+		fn(event, ticks)
+		if (event.IsNote() && event.Data&noteChord == 0) || event.SID == SIDRest {
+			ticks += event.Duration()
+		}
+	}
+}
+
+// IsScoreForm returns true for FORMs which can be decoded by DecodeSMUS.
+// Besides SMUS these are CMUS scores, which use the same chunks.
+func IsScoreForm(form *IFFChunk) bool {
+	return form != nil && form.ID == "FORM" && (form.SubID == "SMUS" || form.SubID == "CMUS")
+}
+
+// DecodeSMUS decodes the SHDR, NAME, INS1 and TRAK chunks of an SMUS or
+// CMUS FORM.
+func DecodeSMUS(form *IFFChunk) (*SMUSScore, error) {
+	var score SMUSScore
+	var err error
+
+	if !IsScoreForm(form) {
+		return nil, fmt.Errorf("not an SMUS or CMUS FORM")
+	}
+
+	shdr := findChild(form, "SHDR")
+	if shdr == nil {
+		return nil, fmt.Errorf("SHDR chunk is missing")
+	}
+	if score.Header, err = ParseSMUSHeader(shdr.Data); err != nil {
+		return nil, fmt.Errorf("SHDR: %w", err)
+	}
+
+	for _, child := range form.Childs {
+		switch child.ID {
+		case "NAME":
+			score.Name = strings.TrimRight(string(child.Data), "\x00")
+		case "INS1":
+			ins, err := ParseSMUSInstrument(child.Data)
+			if err != nil {
+				return nil, fmt.Errorf("INS1: %w", err)
+			}
+			score.Instruments = append(score.Instruments, ins)
+		case "TRAK":
+			score.Tracks = append(score.Tracks, ParseSMUSTrack(child.Data))
+		}
+	}
+
+	return &score, nil
+}
+
+// Instrument returns the instrument with the given register or nil.
+func (score *SMUSScore) Instrument(register uint8) *SMUSInstrument {
+	for i := range score.Instruments {
+		if score.Instruments[i].Register == register {
+			return &score.Instruments[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"bytes"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestSMUSEvent(t *testing.T) {
+	var tests = []struct {
+		event    SMUSEvent
+		text     string
+		duration int
+	}{
+		{SMUSEvent{60, 2}, "Note C4, quarter", SMUSTicksPerQuarter},
+		{SMUSEvent{69, 0x8B}, "Note A4, dotted eighth, chord", SMUSTicksPerQuarter * 3 / 4},
+		{SMUSEvent{61, 0x53}, "Note C#4, eighth triplet, tied", SMUSTicksPerQuarter / 3},
+		{SMUSEvent{SIDRest, 0}, "Rest, whole", 4 * SMUSTicksPerQuarter},
+		{SMUSEvent{SIDRest, 0x3F}, "Rest, dotted 128th septuplet", 270},
+		{SMUSEvent{SIDTimeSig, 0x12}, "Time Signature 3/4", 0},
+		{SMUSEvent{SIDKeySig, 2}, "Key Signature 2 sharps (D major)", 0},
+		{SMUSEvent{SIDKeySig, 10}, "Key Signature 3 flats (Eb major)", 0},
+		{SMUSEvent{SIDMIDIChnl, 9}, "MIDI Channel 10", 0},
+		{SMUSEvent{SIDClef, 1}, "Clef Bass", 0},
+		{SMUSEvent{200, 5}, "Unknown (sID 200, data 5)", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := tt.event.String(); got != tt.text {
+				t.Errorf("String: got %q, want %q", got, tt.text)
+			}
+			if tt.duration > 0 && tt.event.Duration() != tt.duration {
+				t.Errorf("Duration: got %d, want %d", tt.event.Duration(), tt.duration)
+			}
+		})
+	}
+}
+
+// makeTestSMUS returns an SMUS FORM with a MIDI instrument and one track
+// with a chord, a tied note and a rest.
+func makeTestSMUS() *IFFChunk {
+	return &IFFChunk{ID: "FORM", SubID: "SMUS", Childs: []*IFFChunk{
+		{ID: "SHDR", Data: []byte{0x3C, 0x00, 100, 1}}, // 120 bpm
+		{ID: "NAME", Data: []byte("Tune")},
+		{ID: "INS1", Data: []byte{1, INS1MIDI, 2, 5, 'P', 'i', 'a', 'n', 'o'}},
+		{ID: "TRAK", Data: []byte{
+			SIDInstrument, 1,
+			SIDDynamic, 90,
+			60, 0x82, // chord
+			64, 0x42, // quarter, tied
+			SIDRest, 3,
+			64, 3, // eighth
+		}},
+	}}
+}
+
+func TestDecodeSMUS(t *testing.T) {
+	score, err := DecodeSMUS(makeTestSMUS())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if score.Header != (SMUSHeader{0x3C00, 100, 1}) || score.Header.BPM() != 120 {
+		t.Errorf("Header: got %+v", score.Header)
+	}
+	if score.Name != "Tune" || len(score.Tracks) != 1 || len(score.Tracks[0]) != 6 {
+		t.Errorf("Score: got %+v", score)
+	}
+	want := SMUSInstrument{1, INS1MIDI, 2, 5, "Piano"}
+	if ins := score.Instrument(1); ins == nil || *ins != want {
+		t.Errorf("Instrument: got %+v, want %+v", ins, want)
+	}
+
+	if _, err := DecodeSMUS(&IFFChunk{ID: "FORM", SubID: "SMUS"}); err == nil {
+		t.Errorf("No SHDR: got no error, want error")
+	}
+}
+
+func TestWriteMIDI(t *testing.T) {
+	score, err := DecodeSMUS(makeTestSMUS())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := WriteMIDI(&buf, score); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+
+	header := []byte{'M', 'T', 'h', 'd', 0, 0, 0, 6, 0, 1, 0, 2, 0x1A, 0x40}
+	if !bytes.HasPrefix(data, header) {
+		t.Fatalf("Header: got % X, want % X", data[:14], header)
+	}
+
+	// the conductor track contains name and tempo (500000 µs per quarter)
+	conductor := []byte{0, 0xFF, 0x03, 4, 'T', 'u', 'n', 'e', 0, 0xFF, 0x51, 3, 0x07, 0xA1, 0x20,
+		0, 0xFF, 0x2F, 0}
+	if !bytes.Contains(data, conductor) {
+		t.Errorf("Conductor track not found in % X", data)
+	}
+
+	// quarter note = 6720 ticks = 0xB4 0x40
+	track := []byte{
+		0, 0xB0, 7, 100, // volume
+		0, 0xC2, 5, // program change of the MIDI instrument
+		0, 0xFF, 0x04, 5, 'P', 'i', 'a', 'n', 'o',
+		0, 0x92, 60, 90, // chord
+		0, 0x92, 64, 90,
+		0xB4, 0x40, 0x82, 60, 0, // end of the chord note
+		0xB4, 0x40, 0x82, 64, 0, // end of the tied note after the rest and the eighth
+		0, 0xFF, 0x2F, 0,
+	}
+	if !bytes.Contains(data, track) {
+		t.Errorf("Track: got % X, want % X", data[14+8+len(conductor)+8:], track)
+	}
+}
+
+func TestDecodeCMUS(t *testing.T) {
+	smus, err := DecodeSMUS(makeTestSMUS())
+	if err != nil {
+		t.Fatal(err)
+	}
+	form := makeTestSMUS()
+	form.SubID = "CMUS"
+	if !IsScoreForm(form) {
+		t.Errorf("IsScoreForm: got false, want true")
+	}
+	cmus, err := DecodeSMUS(form)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(cmus, smus) {
+		t.Errorf("Score: got %+v, want %+v", cmus, smus)
+	}
+
+	var want, got bytes.Buffer
+	if err := WriteMIDI(&want, smus); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteMIDI(&got, cmus); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got.Bytes(), want.Bytes()) {
+		t.Errorf("MIDI: got % X, want % X", got.Bytes(), want.Bytes())
+	}
+
+	_, wantTrack, _ := GetStructData("SMUS.TRAK", form.Childs[3].Data)
+	_, gotTrack, err := GetStructData("CMUS.TRAK", form.Childs[3].Data)
+	if err != nil || !reflect.DeepEqual(gotTrack, wantTrack) {
+		t.Errorf("TRAK: got %v, %v, want %v", gotTrack, err, wantTrack)
+	}
+}
+
+func TestHandleSmus(t *testing.T) {
+	form := makeTestSMUS()
+	_, result, err := GetStructData("SMUS.TRAK", form.Childs[3].Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []fieldRow{
+		{"Events", "6", 0, 12},
+		{"  Event 0", "Instrument 1 (beat 0)", 0, 2},
+		{"  Event 1", "Dynamic 90 (beat 0)", 2, 2},
+		{"  Event 2", "Note C4, quarter, chord (beat 0)", 4, 2},
+		{"  Event 3", "Note E4, quarter, tied (beat 0)", 6, 2},
+		{"  Event 4", "Rest, eighth (beat 1)", 8, 2},
+		{"  Event 5", "Note E4, eighth (beat 1.5)", 10, 2},
+	}
+	if got := fieldRows(result); !slices.Equal(got, want) {
+		t.Errorf("TRAK: got %v, want %v", got, want)
+	}
+
+	_, result, err = GetStructData("SMUS.SHDR", form.Childs[0].Data)
+	if err != nil || result[0].Value != "15360 (120 quarter notes/minute)" {
+		t.Errorf("SHDR: got %v, %v", result, err)
+	}
+	_, result, err = GetStructData("SMUS.INS1", form.Childs[2].Data)
+	if err != nil || len(result) != 5 || result[4].Value != "Piano" {
+		t.Errorf("INS1: got %v, %v", result, err)
+	}
+}
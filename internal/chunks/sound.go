@@ -0,0 +1,212 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// SoundMarker is a named position in a sound, e.g. the start of the
+// repeat part.
+type SoundMarker struct {
+	Position int // sample frame
+	Name     string
+}
+
+// EnvelopePoint is a point of a volume envelope.
+type EnvelopePoint struct {
+	Position int     // sample frame
+	Level    float64 // 0 is silent, 1 is full volume
+}
+
+// Sound contains the decoded samples of a sampled-sound FORM in a common
+// format for display.
+type Sound struct {
+	SampleRate float64
+	Channels   [][]float64 // samples of each channel from -1 to 1
+	Markers    []SoundMarker
+
+	// attack and release envelope as polyline, nil if there is none
+	Attack  []EnvelopePoint
+	Release []EnvelopePoint
+}
+
+// IsSoundForm returns true for FORMs which can be decoded by DecodeSound.
+func IsSoundForm(form *IFFChunk) bool {
+	if form == nil || form.ID != "FORM" {
+		return false
+	}
+	switch form.SubID {
+	case "8SVX", "16SV", "AIFF", "AIFC":
+		return true
+	}
+	return false
+}
+
+// DecodeSound decodes the samples of an 8SVX, 16SV, AIFF or AIFC FORM.
+// The one-shot and repeat parts and octaves of 8SVX and 16SV become
+// markers as well as the MARK chunk of AIFF.
+// In case of an error, the function returns nil and the error. If only
+// the sample data is too short, the available samples are returned
+// together with the error.
+func DecodeSound(form *IFFChunk) (*Sound, error) {
+	if !IsSoundForm(form) {
+		return nil, fmt.Errorf("not a sampled-sound FORM")
+	}
+
+	switch form.SubID {
+	case "8SVX":
+		voice, err := Decode8SVX(form)
+		if voice == nil {
+			return nil, err
+		}
+		octaves := make([][2][]int8, len(voice.Octaves))
+		for i, octave := range voice.Octaves {
+			octaves[i] = [2][]int8{octave.OneShot, octave.Repeat}
+		}
+		return voiceSound(form, &voice.Header, octaves, 1.0/0x80), err
+	case "16SV":
+		return decode16SV(form)
+	default:
+		return decodeAIFF(form)
+	}
+}
+
+// decode16SV decodes the samples of a 16SV FORM, which is an 8SVX FORM
+// with uncompressed 16 bit samples.
+func decode16SV(form *IFFChunk) (*Sound, error) {
+	vhdrChunk := findChild(form, "VHDR")
+	if vhdrChunk == nil {
+		return nil, fmt.Errorf("VHDR chunk is missing")
+	}
+	vhdr, err := ParseVoice8Header(vhdrChunk.Data)
+	if err != nil {
+		return nil, err
+	}
+	if vhdr.Compression != SoundCompressionNone {
+		return nil, fmt.Errorf("unsupported compression: %d", vhdr.Compression)
+	}
+
+	body := findChild(form, "BODY")
+	if body == nil {
+		return nil, fmt.Errorf("BODY chunk is missing")
+	}
+	samples := make([]int16, len(body.Data)/2)
+	for i := range samples {
+		samples[i] = int16(binary.BigEndian.Uint16(body.Data[i*2:]))
+	}
+
+	// the sizes of the VHDR are sample counts, not bytes
+	octaves, err := splitOctaves(samples, &vhdr)
+	if octaves == nil && err != nil {
+		return nil, err
+	}
+	return voiceSound(form, &vhdr, octaves, 1.0/0x8000), err
+}
+
+// voiceSound converts the samples of all octaves of an 8SVX or 16SV FORM
+// into a Sound. scale converts a sample to the range from -1 to 1.
+func voiceSound[T int8 | int16](form *IFFChunk, vhdr *Voice8Header, octaves [][2][]T,
+	scale float64) *Sound {
+
+	snd := Sound{SampleRate: float64(vhdr.SamplesPerSec)}
+
+	var samples []float64
+	for i, octave := range octaves {
+		if i > 0 {
+			snd.Markers = append(snd.Markers,
+				SoundMarker{len(samples), fmt.Sprintf("Octave %d", i+1)})
+		}
+		for _, s := range octave[0] {
+			samples = append(samples, float64(s)*scale)
+		}
+		if len(octave[1]) > 0 {
+			snd.Markers = append(snd.Markers, SoundMarker{len(samples), "Repeat"})
+		}
+		for _, s := range octave[1] {
+			samples = append(samples, float64(s)*scale)
+		}
+	}
+	snd.Channels = [][]float64{samples}
+
+	// the release starts when the note is released, which is shown at
+	// the end of the highest octave
+	end := 0
+	if len(octaves) > 0 {
+		end = len(octaves[0][0]) + len(octaves[0][1])
+	}
+	snd.Attack = envelope(findChild(form, "ATAK"), 0, 0, snd.SampleRate)
+	level := 1.0
+	if len(snd.Attack) > 0 {
+		level = snd.Attack[len(snd.Attack)-1].Level
+	}
+	snd.Release = envelope(findChild(form, "RLSE"), 0, level, snd.SampleRate)
+	if len(snd.Release) > 0 {
+		start := end - snd.Release[len(snd.Release)-1].Position
+		for i := range snd.Release {
+			snd.Release[i].Position += start
+		}
+	}
+
+	return &snd
+}
+
+// envelope converts the EGPoints of an ATAK or RLSE chunk into a polyline
+// which starts at the given position and level. The duration of each
+// point is given in milliseconds.
+func envelope(chunk *IFFChunk, start int, level float64, sampleRate float64) []EnvelopePoint {
+	if chunk == nil {
+		return nil
+	}
+
+	points := []EnvelopePoint{{start, level}}
+	ms := 0
+	for pos := 0; pos+6 <= len(chunk.Data); pos += 6 {
+		ms += int(binary.BigEndian.Uint16(chunk.Data[pos:]))
+		dest := int32(binary.BigEndian.Uint32(chunk.Data[pos+2:]))
+		points = append(points, EnvelopePoint{
+			Position: start + int(float64(ms)*sampleRate/1000),
+			Level:    float64(dest) / SoundVolumeUnity,
+		})
+	}
+	return points
+}
+
+// decodeAIFF decodes the samples and markers of an AIFF or AIFC FORM.
+func decodeAIFF(form *IFFChunk) (*Sound, error) {
+	var snd Sound
+
+	commChunk := findChild(form, "COMM")
+	if commChunk == nil {
+		return nil, fmt.Errorf("COMM chunk is missing")
+	}
+	comm, err := ParseAIFFCommon(commChunk.Data, form.SubID == "AIFC")
+	if err != nil {
+		return nil, err
+	}
+	snd.SampleRate = comm.SampleRate
+
+	ssnd := findChild(form, "SSND")
+	if ssnd == nil {
+		if comm.NumSampleFrames > 0 {
+			return nil, fmt.Errorf("SSND chunk is missing")
+		}
+		snd.Channels = make([][]float64, max(comm.NumChannels, 1))
+		return &snd, nil
+	}
+	snd.Channels, err = decodeAIFFSamples(&comm, ssnd.Data)
+	if snd.Channels == nil {
+		return nil, err
+	}
+
+	if mark := findChild(form, "MARK"); mark != nil {
+		markers, _ := ParseAIFFMarkers(mark.Data)
+		for _, m := range markers {
+			snd.Markers = append(snd.Markers, SoundMarker{int(m.Position), m.Name})
+		}
+	}
+
+	return &snd, err
+}
@@ -0,0 +1,202 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"fmt"
+)
+
+// Compression algorithms of the VHDR chunk.
+const (
+	SoundCompressionNone     = 0
+	SoundCompressionFibDelta = 1
+)
+
+// SoundVolumeUnity is the full volume of the VHDR chunk, 1.0 as Fixed.
+const SoundVolumeUnity = 0x10000
+
+// maxOctaves is the largest number of octaves supported by Decode8SVX.
+const maxOctaves = 16
+
+// fibonacciDeltaHeaderBytes is the number of bytes before the codes of
+// Fibonacci-delta compressed data.
+const fibonacciDeltaHeaderBytes = 2
+
+// fibonacciDeltas maps the 4 bit codes of Fibonacci-delta compression to
+// the difference to the previous sample.
+var fibonacciDeltas = [16]int8{-34, -21, -13, -8, -5, -3, -2, -1, 0, 1, 2, 3, 5, 8, 13, 21}
+
+// Voice8Header is the content of a VHDR chunk.
+type Voice8Header struct {
+	OneShotHiSamples  uint32
+	RepeatHiSamples   uint32
+	SamplesPerHiCycle uint32
+	SamplesPerSec     uint16
+	CtOctave          uint8
+	Compression       uint8
+	Volume            int32 // Fixed, SoundVolumeUnity is full volume
+}
+
+// Voice8Octave contains the samples of one octave. OneShot is played
+// once, then Repeat is looped.
+type Voice8Octave struct {
+	OneShot []int8
+	Repeat  []int8
+}
+
+// Voice8 contains the decoded data of an 8SVX FORM.
+type Voice8 struct {
+	Header Voice8Header
+
+	// octaves from the highest to the lowest; all are played with
+	// the sample rate of the header, each octave has twice the samples
+	// of the previous one
+	Octaves []Voice8Octave
+}
+
+// ParseVoice8Header decodes the data of a VHDR chunk.
+func ParseVoice8Header(data []byte) (Voice8Header, error) {
+	var vhdr Voice8Header
+	var offset uint32
+	var err error
+
+	if len(data) < 20 {
+		return vhdr, fmt.Errorf("VHDR is too short")
+	}
+
+	// the length has been checked, so no errors are expected below
+	vhdr.OneShotHiSamples, _ = getBeUlong(data, &offset)
+	vhdr.RepeatHiSamples, _ = getBeUlong(data, &offset)
+	vhdr.SamplesPerHiCycle, _ = getBeUlong(data, &offset)
+	vhdr.SamplesPerSec, _ = getBeUword(data, &offset)
+	vhdr.CtOctave, _ = getUbyte(data, &offset)
+	vhdr.Compression, _ = getUbyte(data, &offset)
+	vhdr.Volume, err = getBeLong(data, &offset)
+
+	return vhdr, err
+}
+
+// DecodeFibonacciDelta decompresses Fibonacci-delta encoded samples.
+// The first byte is padding, the second the initial value, and every
+// following byte contains two 4 bit codes, the upper one first.
+func DecodeFibonacciDelta(data []byte) ([]int8, error) {
+	if len(data) < fibonacciDeltaHeaderBytes {
+		return nil, fmt.Errorf("compressed data is too short")
+	}
+
+	result := make([]int8, 0, (len(data)-fibonacciDeltaHeaderBytes)*2)
+	value := int8(data[1])
+	for _, b := range data[fibonacciDeltaHeaderBytes:] {
+		value += fibonacciDeltas[b>>4]
+		result = append(result, value)
+		value += fibonacciDeltas[b&0x0F]
+		result = append(result, value)
+	}
+
+	return result, nil
+}
+
+// Decode8SVX decodes the samples of an 8SVX FORM and splits them into
+// the one-shot and repeat part of each octave.
+// In case of an error, the function returns nil and the error. If only
+// the BODY is too short, the available samples are returned together
+// with the error.
+func Decode8SVX(form *IFFChunk) (*Voice8, error) {
+	var voice Voice8
+	var err error
+
+	if form == nil || form.ID != "FORM" || form.SubID != "8SVX" {
+		return nil, fmt.Errorf("not an 8SVX FORM")
+	}
+
+	vhdr := findChild(form, "VHDR")
+	if vhdr == nil {
+		return nil, fmt.Errorf("VHDR chunk is missing")
+	}
+	voice.Header, err = ParseVoice8Header(vhdr.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	body := findChild(form, "BODY")
+	if body == nil {
+		return nil, fmt.Errorf("BODY chunk is missing")
+	}
+
+	var samples []int8
+	switch voice.Header.Compression {
+	case SoundCompressionNone:
+		samples = make([]int8, len(body.Data))
+		for i, b := range body.Data {
+			samples[i] = int8(b)
+		}
+	case SoundCompressionFibDelta:
+		samples, err = DecodeFibonacciDelta(body.Data)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported compression: %d", voice.Header.Compression)
+	}
+
+	octaves, err := splitOctaves(samples, &voice.Header)
+	if octaves == nil && err != nil {
+		return nil, err
+	}
+	for _, octave := range octaves {
+		voice.Octaves = append(voice.Octaves, Voice8Octave{octave[0], octave[1]})
+	}
+
+	return &voice, err
+}
+
+// splitOctaves splits the samples of a BODY into the one-shot and repeat
+// part of each octave. Each octave has twice the samples of the previous
+// one. If the samples are too short, the available parts are returned
+// together with an error.
+func splitOctaves[T int8 | int16](samples []T, vhdr *Voice8Header) ([][2][]T, error) {
+	var octaves [][2][]T
+
+	count := max(int(vhdr.CtOctave), 1)
+	if count > maxOctaves {
+		return nil, fmt.Errorf("unsupported number of octaves: %d", count)
+	}
+	oneShot := int(vhdr.OneShotHiSamples)
+	repeat := int(vhdr.RepeatHiSamples)
+	if oneShot+repeat == 0 {
+		// some programs don't set the sizes, the whole BODY is one-shot
+		oneShot = len(samples)
+	}
+
+	pos := 0
+	for i := 0; i < count && pos < len(samples); i++ {
+		end := min(pos+oneShot<<i, len(samples))
+		repeatEnd := min(pos+(oneShot+repeat)<<i, len(samples))
+		octaves = append(octaves, [2][]T{samples[pos:end], samples[end:repeatEnd]})
+		pos += (oneShot + repeat) << i
+	}
+	if pos > len(samples) {
+		return octaves, fmt.Errorf("BODY is too short, %d samples are missing", pos-len(samples))
+	}
+
+	return octaves, nil
+}
+
+// WAV converts an octave to an 8 bit WAV. A repeat part becomes a loop.
+func (voice *Voice8) WAV(octave int) (*WAV, error) {
+	if octave < 0 || octave >= len(voice.Octaves) {
+		return nil, fmt.Errorf("octave %d doesn't exist", octave)
+	}
+
+	oct := voice.Octaves[octave]
+	samples := append(append([]int8(nil), oct.OneShot...), oct.Repeat...)
+	wav := NewWAV8(samples, uint32(voice.Header.SamplesPerSec))
+	if len(oct.Repeat) > 0 {
+		wav.Loops = append(wav.Loops, WAVLoop{
+			Start: uint32(len(oct.OneShot)),
+			End:   uint32(len(samples) - 1),
+		})
+	}
+	return wav, nil
+}
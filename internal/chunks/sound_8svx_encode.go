@@ -0,0 +1,178 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// WAVImportOptions controls how ConvertWAVTo8SVX converts a WAV.
+type WAVImportOptions struct {
+	// sample rate of the 8SVX, 0 keeps the rate of the WAV
+	SampleRate uint32
+
+	// compress the BODY with Fibonacci-delta
+	Compress bool
+}
+
+// infoChunks maps the IDs of the LIST INFO chunk of WAV to the generic
+// text chunks of IFF, in the order they are written.
+var infoChunks = [][2]string{
+	{"INAM", "NAME"},
+	{"ICOP", "(c) "},
+	{"IART", "AUTH"},
+	{"ICMT", "ANNO"},
+}
+
+// EncodeFibonacciDelta compresses samples with Fibonacci-delta. For each
+// sample the code whose delta comes closest to it is chosen, so the
+// compression is lossy. An odd number of samples is padded with a code
+// for an unchanged value.
+func EncodeFibonacciDelta(samples []int8) []byte {
+	if len(samples) == 0 {
+		return []byte{0, 0}
+	}
+
+	result := make([]byte, 2, 2+(len(samples)+1)/2)
+	result[1] = byte(samples[0])
+
+	value := int(samples[0])
+	var code byte
+	for i := 0; i < len(samples)+len(samples)%2; i++ {
+		c := byte(8) // delta 0
+		if i < len(samples) {
+			c = nearestFibonacciCode(value, int(samples[i]))
+		}
+		value += int(fibonacciDeltas[c])
+
+		if i%2 == 0 {
+			code = c << 4
+		} else {
+			result = append(result, code|c)
+		}
+	}
+
+	return result
+}
+
+// nearestFibonacciCode returns the code which changes value closest to
+// target without leaving the range of a signed byte.
+func nearestFibonacciCode(value int, target int) byte {
+	best, bestDist := byte(8), math.MaxInt
+	for code, delta := range fibonacciDeltas {
+		next := value + int(delta)
+		if next < -128 || next > 127 {
+			continue
+		}
+		if dist := max(next-target, target-next); dist < bestDist {
+			best, bestDist = byte(code), dist
+		}
+	}
+	return best
+}
+
+// encodeVoice8Header returns the data of a VHDR chunk.
+func encodeVoice8Header(vhdr *Voice8Header) []byte {
+	data := make([]byte, 0, 20)
+	data = binary.BigEndian.AppendUint32(data, vhdr.OneShotHiSamples)
+	data = binary.BigEndian.AppendUint32(data, vhdr.RepeatHiSamples)
+	data = binary.BigEndian.AppendUint32(data, vhdr.SamplesPerHiCycle)
+	data = binary.BigEndian.AppendUint16(data, vhdr.SamplesPerSec)
+	data = append(data, vhdr.CtOctave, vhdr.Compression)
+	data = binary.BigEndian.AppendUint32(data, uint32(vhdr.Volume))
+	return data
+}
+
+// resample changes the sample rate with linear interpolation.
+func resample(samples []float64, from uint32, to uint32) []float64 {
+	if from == to || from == 0 || len(samples) == 0 {
+		return samples
+	}
+
+	count := int(uint64(len(samples)) * uint64(to) / uint64(from))
+	result := make([]float64, count)
+	for i := range result {
+		pos := float64(i) * float64(from) / float64(to)
+		index := int(pos)
+		frac := pos - float64(index)
+		next := min(index+1, len(samples)-1)
+		result[i] = samples[index]*(1-frac) + samples[next]*frac
+	}
+	return result
+}
+
+// ConvertWAVTo8SVX converts a WAV into an 8SVX FORM, which can be saved
+// with WriteIFFFile. The channels are mixed down to mono and quantized
+// to 8 bit. The first loop of the WAV becomes the repeat part, samples
+// after the loop are dropped as 8SVX can't play them. The name, copyright,
+// author and comment of the LIST INFO chunk become NAME, (c), AUTH and
+// ANNO chunks.
+func ConvertWAVTo8SVX(wav *WAV, opts WAVImportOptions) (*IFFChunk, error) {
+	rate := wav.SampleRate
+	if opts.SampleRate != 0 {
+		rate = opts.SampleRate
+	}
+	if rate == 0 || rate > math.MaxUint16 {
+		return nil, fmt.Errorf("unsupported sample rate for 8SVX: %d Hz", rate)
+	}
+
+	// mix down to mono
+	channels := wav.Samples()
+	if len(channels) == 0 {
+		return nil, fmt.Errorf("WAV has no samples")
+	}
+	mono := make([]float64, len(channels[0]))
+	for _, channel := range channels {
+		for i, s := range channel {
+			mono[i] += s / float64(len(channels))
+		}
+	}
+	mono = resample(mono, wav.SampleRate, rate)
+
+	samples := make([]int8, len(mono))
+	for i, s := range mono {
+		samples[i] = int8(min(max(math.Round(s*0x80), -0x80), 0x7F))
+	}
+
+	vhdr := Voice8Header{
+		OneShotHiSamples: uint32(len(samples)),
+		SamplesPerSec:    uint16(rate),
+		CtOctave:         1,
+		Volume:           SoundVolumeUnity,
+	}
+	if len(wav.Loops) > 0 {
+		scale := float64(rate) / float64(wav.SampleRate)
+		start := min(int(float64(wav.Loops[0].Start)*scale), len(samples))
+		end := min(int(float64(wav.Loops[0].End+1)*scale), len(samples))
+		if end > start {
+			samples = samples[:end]
+			vhdr.OneShotHiSamples = uint32(start)
+			vhdr.RepeatHiSamples = uint32(end - start)
+		}
+	}
+
+	var body []byte
+	if opts.Compress {
+		vhdr.Compression = SoundCompressionFibDelta
+		body = EncodeFibonacciDelta(samples)
+	} else {
+		body = make([]byte, len(samples))
+		for i, s := range samples {
+			body[i] = byte(s)
+		}
+	}
+
+	form := &IFFChunk{ID: "FORM", SubID: "8SVX"}
+	form.Childs = append(form.Childs, &IFFChunk{ID: "VHDR", Data: encodeVoice8Header(&vhdr)})
+	for _, ids := range infoChunks {
+		if text := wav.Info[ids[0]]; text != "" {
+			form.Childs = append(form.Childs, &IFFChunk{ID: ids[1], Data: []byte(text)})
+		}
+	}
+	form.Childs = append(form.Childs, &IFFChunk{ID: "BODY", Data: body})
+
+	return form, nil
+}
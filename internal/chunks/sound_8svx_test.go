@@ -0,0 +1,285 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"bytes"
+	"encoding/binary"
+	"slices"
+	"testing"
+)
+
+// makeVoice8Header returns the data of a VHDR chunk.
+func makeVoice8Header(oneShot, repeat uint32, rate uint16, octaves, compression uint8) []byte {
+	data := binary.BigEndian.AppendUint32(nil, oneShot)
+	data = binary.BigEndian.AppendUint32(data, repeat)
+	data = binary.BigEndian.AppendUint32(data, 0)
+	data = binary.BigEndian.AppendUint16(data, rate)
+	data = append(data, octaves, compression)
+	return binary.BigEndian.AppendUint32(data, SoundVolumeUnity)
+}
+
+func TestDecodeFibonacciDelta(t *testing.T) {
+	var tests = []struct {
+		name      string
+		data      []byte
+		want      []int8
+		wantIsErr bool
+	}{
+		{"Deltas", []byte{0, 10, 0x8F, 0x07}, []int8{10, 31, -3, -4}, false},
+		{"Wrap", []byte{0, 120, 0xF0}, []int8{-115, 107}, false},
+		{"TooShort", []byte{0}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeFibonacciDelta(tt.data)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Samples: got %v, want %v", got, tt.want)
+			}
+			if (err != nil) != tt.wantIsErr {
+				t.Errorf("Error: got %v, want %t", err, tt.wantIsErr)
+			}
+		})
+	}
+}
+
+func TestDecode8SVX(t *testing.T) {
+	// 2 octaves with 2 one-shot and 1 repeat sample in the highest octave
+	body := []byte{1, 2, 3, 0xFC, 0xFB, 0xFA, 0xF9, 0xF8, 0xF7}
+	form := &IFFChunk{ID: "FORM", SubID: "8SVX", Childs: []*IFFChunk{
+		{ID: "VHDR", Data: makeVoice8Header(2, 1, 8000, 2, SoundCompressionNone)},
+		{ID: "BODY", Data: body},
+	}}
+
+	voice, err := Decode8SVX(form)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Voice8Octave{
+		{[]int8{1, 2}, []int8{3}},
+		{[]int8{-4, -5, -6, -7}, []int8{-8, -9}},
+	}
+	if len(voice.Octaves) != len(want) {
+		t.Fatalf("Octaves: got %d, want %d", len(voice.Octaves), len(want))
+	}
+	for i, oct := range voice.Octaves {
+		if !slices.Equal(oct.OneShot, want[i].OneShot) || !slices.Equal(oct.Repeat, want[i].Repeat) {
+			t.Errorf("Octave %d: got %v, want %v", i, oct, want[i])
+		}
+	}
+
+	// one sample is missing
+	form.Childs[1].Data = body[:8]
+	if voice, err = Decode8SVX(form); err == nil || voice == nil {
+		t.Errorf("Short BODY: got %v, %v, want voice and error", voice, err)
+	}
+
+	// compressed
+	form.Childs[0].Data = makeVoice8Header(4, 0, 8000, 1, SoundCompressionFibDelta)
+	form.Childs[1].Data = []byte{0, 10, 0x8F, 0x07}
+	voice, err = Decode8SVX(form)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := voice.Octaves[0].OneShot; !slices.Equal(got, []int8{10, 31, -3, -4}) {
+		t.Errorf("Compressed: got %v", got)
+	}
+}
+
+func TestVoice8WAV(t *testing.T) {
+	voice := &Voice8{
+		Header:  Voice8Header{SamplesPerSec: 11025},
+		Octaves: []Voice8Octave{{[]int8{-128, 0}, []int8{127}}},
+	}
+	wav, err := voice.WAV(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := voice.WAV(1); err == nil {
+		t.Errorf("Octave 1: got no error, want error")
+	}
+
+	var buf bytes.Buffer
+	if err := WriteWAV(&buf, wav); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+
+	if string(data[0:4]) != "RIFF" || string(data[8:12]) != "WAVE" {
+		t.Fatalf("Header: got %q", data[:12])
+	}
+	if size := binary.LittleEndian.Uint32(data[4:]); int(size) != len(data)-8 {
+		t.Errorf("RIFF size: got %d, want %d", size, len(data)-8)
+	}
+	if rate := binary.LittleEndian.Uint32(data[24:]); rate != 11025 {
+		t.Errorf("Sample rate: got %d, want 11025", rate)
+	}
+	// data chunk with unsigned samples and pad byte
+	if !bytes.Equal(data[36:48], []byte{'d', 'a', 't', 'a', 3, 0, 0, 0, 0x00, 0x80, 0xFF, 0}) {
+		t.Errorf("data: got %v", data[36:48])
+	}
+	smpl := data[48:]
+	if string(smpl[0:4]) != "smpl" {
+		t.Fatalf("smpl: got %q", smpl[0:4])
+	}
+	start := binary.LittleEndian.Uint32(smpl[8+36+8:])
+	end := binary.LittleEndian.Uint32(smpl[8+36+12:])
+	if start != 2 || end != 2 {
+		t.Errorf("Loop: got %d-%d, want 2-2", start, end)
+	}
+}
+
+func TestEncodeFibonacciDelta(t *testing.T) {
+	var tests = []struct {
+		name    string
+		samples []int8
+		want    []int8
+	}{
+		{"Exact", []int8{10, 31, -3, -4}, []int8{10, 31, -3, -4}},
+		{"Odd", []int8{0, 1, 3}, []int8{0, 1, 3, 3}},
+		{"Lossy", []int8{0, 100}, []int8{0, 21}},
+		{"Limit", []int8{127, 127}, []int8{127, 127}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeFibonacciDelta(EncodeFibonacciDelta(tt.samples))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Samples: got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// makeTestWAV returns a stereo 16 bit WAV file with a loop and a
+// LIST INFO chunk.
+func makeTestWAV() []byte {
+	var buf bytes.Buffer
+
+	format := binary.LittleEndian.AppendUint16(nil, wavFormatPCM)
+	format = binary.LittleEndian.AppendUint16(format, 2)
+	format = binary.LittleEndian.AppendUint32(format, 16000)
+	format = binary.LittleEndian.AppendUint32(format, 16000*4)
+	format = binary.LittleEndian.AppendUint16(format, 4)
+	format = binary.LittleEndian.AppendUint16(format, 16)
+	writeRIFFChunk(&buf, "fmt ", format)
+
+	// 8 frames, the left channel rises, the right one is silent
+	var data []byte
+	for i := 0; i < 8; i++ {
+		data = binary.LittleEndian.AppendUint16(data, uint16(i*0x1000))
+		data = binary.LittleEndian.AppendUint16(data, 0)
+	}
+	writeRIFFChunk(&buf, "data", data)
+
+	smpl := make([]byte, 36)
+	binary.LittleEndian.PutUint32(smpl[28:], 1)
+	loop := make([]byte, 24)
+	binary.LittleEndian.PutUint32(loop[8:], 2)
+	binary.LittleEndian.PutUint32(loop[12:], 5)
+	writeRIFFChunk(&buf, "smpl", append(smpl, loop...))
+
+	var info bytes.Buffer
+	info.WriteString("INFO")
+	writeRIFFChunk(&info, "INAM", []byte("Bass\x00"))
+	writeRIFFChunk(&info, "IART", []byte("Me\x00"))
+	writeRIFFChunk(&buf, "LIST", info.Bytes())
+
+	header := []byte("RIFF\x00\x00\x00\x00WAVE")
+	binary.LittleEndian.PutUint32(header[4:], uint32(4+buf.Len()))
+	return append(header, buf.Bytes()...)
+}
+
+func TestReadWAV(t *testing.T) {
+	wav, err := ReadWAV(bytes.NewReader(makeTestWAV()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wav.SampleRate != 16000 || wav.Channels != 2 || wav.BitsPerSample != 16 {
+		t.Errorf("Format: got %d Hz, %d channels, %d bit", wav.SampleRate, wav.Channels, wav.BitsPerSample)
+	}
+	if !slices.Equal(wav.Loops, []WAVLoop{{2, 5}}) {
+		t.Errorf("Loops: got %v", wav.Loops)
+	}
+	if wav.Info["INAM"] != "Bass" || wav.Info["IART"] != "Me" {
+		t.Errorf("Info: got %v", wav.Info)
+	}
+
+	samples := wav.Samples()
+	if len(samples) != 2 || len(samples[0]) != 8 {
+		t.Fatalf("Samples: got %d channels", len(samples))
+	}
+	if samples[0][4] != 0.5 || samples[1][4] != 0 {
+		t.Errorf("Frame 4: got %v, %v, want 0.5, 0", samples[0][4], samples[1][4])
+	}
+
+	// round trip of an 8 bit WAV
+	var buf bytes.Buffer
+	if err := WriteWAV(&buf, NewWAV8([]int8{-128, 0, 127}, 8000)); err != nil {
+		t.Fatal(err)
+	}
+	if wav, err = ReadWAV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if got := wav.Samples()[0]; !slices.Equal(got, []float64{-1, 0, 127.0 / 128}) {
+		t.Errorf("8 bit: got %v", got)
+	}
+
+	if _, err := ReadWAV(bytes.NewReader([]byte("RIFF\x04\x00\x00\x00WAVE"))); err == nil {
+		t.Errorf("Empty: got no error, want error")
+	}
+}
+
+func TestConvertWAVTo8SVX(t *testing.T) {
+	wav, err := ReadWAV(bytes.NewReader(makeTestWAV()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	form, err := ConvertWAVTo8SVX(wav, WAVImportOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, child := range form.Childs {
+		ids = append(ids, child.ID)
+	}
+	if want := []string{"VHDR", "NAME", "AUTH", "BODY"}; !slices.Equal(ids, want) {
+		t.Errorf("Chunks: got %v, want %v", ids, want)
+	}
+
+	voice, err := Decode8SVX(form)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if voice.Header.SamplesPerSec != 16000 {
+		t.Errorf("Rate: got %d, want 16000", voice.Header.SamplesPerSec)
+	}
+	// mono mix of both channels, samples after the loop are dropped
+	oct := voice.Octaves[0]
+	if !slices.Equal(oct.OneShot, []int8{0, 8}) || !slices.Equal(oct.Repeat, []int8{16, 24, 32, 40}) {
+		t.Errorf("Samples: got %v", oct)
+	}
+
+	// resampled and compressed
+	form, err = ConvertWAVTo8SVX(wav, WAVImportOptions{SampleRate: 8000, Compress: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if voice, err = Decode8SVX(form); err != nil {
+		t.Fatal(err)
+	}
+	if voice.Header.Compression != SoundCompressionFibDelta || voice.Header.SamplesPerSec != 8000 {
+		t.Errorf("Header: got %+v", voice.Header)
+	}
+	if oct := voice.Octaves[0]; len(oct.OneShot) != 1 || len(oct.Repeat) != 2 {
+		t.Errorf("Loop: got %d+%d samples, want 1+2", len(oct.OneShot), len(oct.Repeat))
+	}
+
+	if _, err := ConvertWAVTo8SVX(wav, WAVImportOptions{SampleRate: 96000}); err == nil {
+		t.Errorf("96 kHz: got no error, want error")
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// decodeAIFFSamples converts the sample data of an SSND chunk into one
+// slice of normalized samples per channel.
+func decodeAIFFSamples(comm *AIFFCommon, ssnd []byte) ([][]float64, error) {
+	var err error
+
+	if len(ssnd) < 8 {
+		return nil, fmt.Errorf("SSND is too short")
+	}
+	offset := binary.BigEndian.Uint32(ssnd)
+	if uint64(offset)+8 > uint64(len(ssnd)) {
+		return nil, fmt.Errorf("SSND offset is too large")
+	}
+	data := ssnd[8+offset:]
+
+	channels := int(comm.NumChannels)
+	if channels <= 0 {
+		return nil, fmt.Errorf("invalid number of channels: %d", channels)
+	}
+
+	var bytesPerSample int
+	var decode func(b []byte) float64
+	switch comm.CompressionType {
+	case "NONE", "twos", "sowt":
+		if comm.SampleSize < 1 || comm.SampleSize > 32 {
+			return nil, fmt.Errorf("unsupported sample size: %d", comm.SampleSize)
+		}
+		bytesPerSample = (int(comm.SampleSize) + 7) / 8
+		littleEndian := comm.CompressionType == "sowt"
+		decode = func(b []byte) float64 {
+			// samples are left-justified in big endian order
+			var value int64
+			for i := 0; i < bytesPerSample; i++ {
+				if littleEndian {
+					value = value<<8 | int64(b[bytesPerSample-1-i])
+				} else {
+					value = value<<8 | int64(b[i])
+				}
+			}
+			shift := 64 - 8*bytesPerSample
+			return float64(value<<shift) / math.Exp2(63)
+		}
+	case "raw ":
+		bytesPerSample = 1
+		decode = func(b []byte) float64 {
+			return float64(int(b[0])-0x80) / 0x80
+		}
+	case "fl32", "FL32":
+		bytesPerSample = 4
+		decode = func(b []byte) float64 {
+			return float64(math.Float32frombits(binary.BigEndian.Uint32(b)))
+		}
+	case "fl64", "FL64":
+		bytesPerSample = 8
+		decode = func(b []byte) float64 {
+			return math.Float64frombits(binary.BigEndian.Uint64(b))
+		}
+	default:
+		return nil, fmt.Errorf("unsupported compression: %q", comm.CompressionType)
+	}
+
+	frameSize := bytesPerSample * channels
+	frames := int(comm.NumSampleFrames)
+	if frames*frameSize > len(data) {
+		err = fmt.Errorf("SSND is too short, %d sample frames are missing",
+			frames-len(data)/frameSize)
+		frames = len(data) / frameSize
+	}
+
+	result := make([][]float64, channels)
+	for c := range result {
+		result[c] = make([]float64, frames)
+	}
+	for f := 0; f < frames; f++ {
+		for c := 0; c < channels; c++ {
+			pos := f*frameSize + c*bytesPerSample
+			result[c][f] = decode(data[pos : pos+bytesPerSample])
+		}
+	}
+
+	return result, err
+}
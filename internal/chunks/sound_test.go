@@ -0,0 +1,236 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// encodeExtended converts a number into the IEEE 754 80 bit extended
+// precision format.
+func encodeExtended(value float64) []byte {
+	data := make([]byte, 10)
+
+	if value == 0 || math.IsNaN(value) {
+		return data
+	}
+	var sign uint16
+	if value < 0 {
+		sign = 0x8000
+		value = -value
+	}
+	if math.IsInf(value, 0) {
+		binary.BigEndian.PutUint16(data, sign|0x7FFF)
+		return data
+	}
+
+	fraction, exponent := math.Frexp(value) // value = fraction * 2^exponent, 0.5 <= fraction < 1
+	binary.BigEndian.PutUint16(data, sign|uint16(exponent-1+16383))
+	binary.BigEndian.PutUint64(data[2:], uint64(math.Ldexp(fraction, 64)))
+	return data
+}
+
+func TestDecodeExtended(t *testing.T) {
+	var tests = []struct {
+		name string
+		data []byte
+		want float64
+	}{
+		{"44100", []byte{0x40, 0x0E, 0xAC, 0x44, 0, 0, 0, 0, 0, 0}, 44100},
+		{"8363", []byte{0x40, 0x0C, 0x82, 0xAC, 0, 0, 0, 0, 0, 0}, 8363},
+		{"Half", []byte{0x3F, 0xFE, 0x80, 0, 0, 0, 0, 0, 0, 0}, 0.5},
+		{"Negative", []byte{0xC0, 0x00, 0x80, 0, 0, 0, 0, 0, 0, 0}, -2},
+		{"Zero", make([]byte, 10), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeExtended(tt.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Value: got %v, want %v", got, tt.want)
+			}
+			if enc := encodeExtended(tt.want); !bytes.Equal(enc, tt.data) {
+				t.Errorf("Encoded: got % X, want % X", enc, tt.data)
+			}
+		})
+	}
+
+	if _, err := DecodeExtended([]byte{0x40}); err == nil {
+		t.Errorf("Short: got no error, want error")
+	}
+}
+
+// makeAIFFCommon returns the data of a COMM chunk. For AIFC the
+// compression type and name are appended.
+func makeAIFFCommon(channels int16, frames uint32, bits int16, rate float64,
+	compression string) []byte {
+
+	data := binary.BigEndian.AppendUint16(nil, uint16(channels))
+	data = binary.BigEndian.AppendUint32(data, frames)
+	data = binary.BigEndian.AppendUint16(data, uint16(bits))
+	data = append(data, encodeExtended(rate)...)
+	if compression != "" {
+		data = append(data, compression...)
+		data = append(data, 3, 'a', 'b', 'c')
+	}
+	return data
+}
+
+func TestDecodeSound(t *testing.T) {
+	ssnd := []byte{0, 0, 0, 0, 0, 0, 0, 0, 0x40, 0x00, 0xC0, 0x00, 0x7F, 0xFF, 0x80, 0x00}
+	mark := []byte{0, 1, 0, 1, 0, 0, 0, 1, 4, 'L', 'o', 'o', 'p', 0}
+
+	var tests = []struct {
+		name        string
+		form        *IFFChunk
+		wantRate    float64
+		wantSamples [][]float64
+		wantMarkers []SoundMarker
+	}{
+		{"AIFF", &IFFChunk{ID: "FORM", SubID: "AIFF", Childs: []*IFFChunk{
+			{ID: "COMM", Data: makeAIFFCommon(2, 2, 16, 22050, "")},
+			{ID: "MARK", Data: mark},
+			{ID: "SSND", Data: ssnd},
+		}}, 22050, [][]float64{{0.5, 32767.0 / 32768}, {-0.5, -1}},
+			[]SoundMarker{{1, "Loop"}}},
+		{"AIFCLittleEndian", &IFFChunk{ID: "FORM", SubID: "AIFC", Childs: []*IFFChunk{
+			{ID: "COMM", Data: makeAIFFCommon(1, 2, 16, 8000, "sowt")},
+			{ID: "SSND", Data: []byte{0, 0, 0, 0, 0, 0, 0, 0, 0x00, 0x20, 0x00, 0xA0}},
+		}}, 8000, [][]float64{{0.25, -0.75}}, nil},
+		{"16SV", &IFFChunk{ID: "FORM", SubID: "16SV", Childs: []*IFFChunk{
+			{ID: "VHDR", Data: makeVoice8Header(1, 1, 16000, 1, SoundCompressionNone)},
+			{ID: "BODY", Data: ssnd[8:12]},
+		}}, 16000, [][]float64{{0.5, -0.5}}, []SoundMarker{{1, "Repeat"}}},
+		{"8SVX", &IFFChunk{ID: "FORM", SubID: "8SVX", Childs: []*IFFChunk{
+			{ID: "VHDR", Data: makeVoice8Header(1, 0, 8000, 2, SoundCompressionNone)},
+			{ID: "BODY", Data: []byte{0x40, 0x80, 0x00}},
+		}}, 8000, [][]float64{{0.5, -1, 0}}, []SoundMarker{{1, "Octave 2"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snd, err := DecodeSound(tt.form)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if snd.SampleRate != tt.wantRate {
+				t.Errorf("Sample rate: got %v, want %v", snd.SampleRate, tt.wantRate)
+			}
+			if !slices.EqualFunc(snd.Channels, tt.wantSamples, slices.Equal) {
+				t.Errorf("Samples: got %v, want %v", snd.Channels, tt.wantSamples)
+			}
+			if !slices.Equal(snd.Markers, tt.wantMarkers) {
+				t.Errorf("Markers: got %v, want %v", snd.Markers, tt.wantMarkers)
+			}
+		})
+	}
+}
+
+func TestDecodeSoundEnvelope(t *testing.T) {
+	// 1000 samples per second, attack to full volume in 10 ms,
+	// release to silence in 20 ms
+	atak := []byte{0, 10, 0, 1, 0, 0}
+	rlse := []byte{0, 20, 0, 0, 0, 0}
+	form := &IFFChunk{ID: "FORM", SubID: "8SVX", Childs: []*IFFChunk{
+		{ID: "VHDR", Data: makeVoice8Header(100, 0, 1000, 1, SoundCompressionNone)},
+		{ID: "ATAK", Data: atak},
+		{ID: "RLSE", Data: rlse},
+		{ID: "BODY", Data: make([]byte, 100)},
+	}}
+
+	snd, err := DecodeSound(form)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantAttack := []EnvelopePoint{{0, 0}, {10, 1}}
+	wantRelease := []EnvelopePoint{{80, 1}, {100, 0}}
+	if !slices.Equal(snd.Attack, wantAttack) {
+		t.Errorf("Attack: got %v, want %v", snd.Attack, wantAttack)
+	}
+	if !slices.Equal(snd.Release, wantRelease) {
+		t.Errorf("Release: got %v, want %v", snd.Release, wantRelease)
+	}
+}
+
+func TestAIFCChunkTypes(t *testing.T) {
+	form := &IFFChunk{ID: "FORM", SubID: "AIFC", Childs: []*IFFChunk{
+		{ID: "FVER", Data: []byte{0xA2, 0x80, 0x51, 0x40}},
+		{ID: "COMM", Data: makeAIFFCommon(2, 44100, 16, 44100, "sowt")},
+		{ID: "NAME", Data: []byte("Drum")},
+	}}
+	var buf bytes.Buffer
+	if err := WriteIFFFile(&buf, form); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, lenient := range []bool{false, true} {
+		var root *IFFChunk
+		var err error
+		if lenient {
+			root, _, err = ReadIFFFileLenient(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		} else {
+			root, err = ReadIFFFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		var types []string
+		for _, child := range root.Childs {
+			types = append(types, child.ChType())
+		}
+		if want := []string{"AIFC.FVER", "AIFC.COMM", "(any).NAME"}; !slices.Equal(types, want) {
+			t.Errorf("Lenient %t: got %v, want %v", lenient, types, want)
+		}
+	}
+}
+
+func TestHandleAiff(t *testing.T) {
+	var tests = []struct {
+		chType string
+		data   []byte
+		key    string
+		want   string
+	}{
+		{"AIFF.COMM", makeAIFFCommon(1, 22050, 8, 22050, ""), "Duration", "1s"},
+		{"AIFF.COMM", makeAIFFCommon(1, 22050, 8, 22050, ""), "Sample Rate", "22050 Hz"},
+		{"AIFC.COMM", makeAIFFCommon(2, 100, 16, 44100, "sowt"), "Compression Type",
+			`"sowt" (Not Compressed (Little Endian))`},
+		{"AIFC.COMM", makeAIFFCommon(2, 100, 16, 44100, "sowt"), "Compression Name", "abc"},
+		{"AIFC.FVER", []byte{0xA2, 0x80, 0x51, 0x40}, "Version", "AIFC Version 1"},
+		{"AIFF.SSND", []byte{0, 0, 0, 2, 0, 0, 0, 0, 9, 9, 1, 2, 3}, "Sound Data", "3 bytes"},
+		{"AIFF.MARK", []byte{0, 1, 0, 7, 0, 0, 0, 10, 4, 'L', 'o', 'o', 'p', 0}, "Marker 7",
+			`"Loop" at 10`},
+		{"AIFF.INST", []byte{60, 0, 0, 127, 1, 127, 0xFF, 0xFA, 0, 1, 0, 1, 0, 2, 0, 0, 0, 0, 0, 0},
+			"Sustain Loop Play Mode", "Forward Looping"},
+		{"AIFF.INST", []byte{60, 0, 0, 127, 1, 127, 0xFF, 0xFA, 0, 1, 0, 1, 0, 2, 0, 0, 0, 0, 0, 0},
+			"Gain", "-6 dB"},
+		{"AIFF.COMT", []byte{0, 1, 0, 0, 0, 0, 0, 0, 0, 3, 'a', 'b', 'c', 0}, "Time",
+			"1904-01-01 00:00:00"},
+		{"AIFF.COMT", []byte{0, 1, 0, 0, 0, 0, 0, 0, 0, 3, 'a', 'b', 'c', 0}, "Text", "abc"},
+		{"AIFF.APPL", []byte{'s', 't', 'o', 'c', 3, 'A', 'p', 'p', 1, 2}, "Application Name", "App"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.chType+" "+tt.key, func(t *testing.T) {
+			_, result, err := GetStructData(tt.chType, tt.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, row := range result.Rows() {
+				if strings.TrimSpace(row.Name) == tt.key {
+					if row.Value != tt.want {
+						t.Errorf("%s: got %q, want %q", tt.key, row.Value, tt.want)
+					}
+					return
+				}
+			}
+			t.Errorf("%s: missing in %v", tt.key, result)
+		})
+	}
+}
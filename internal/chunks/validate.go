@@ -0,0 +1,203 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// formRule describes the chunks of a well-known FORM type.
+type formRule struct {
+	// chunks which must be present in the FORM or in a PROP of an
+	// enclosing LIST
+	mandatory []string
+
+	// the chunk which contains the main data
+	body string
+
+	// property chunks which must precede the body chunk
+	properties []string
+}
+
+// formRules contains the rules for all FORM types which are validated.
+var formRules = map[string]formRule{
+	"8SVX": {[]string{"VHDR"}, "BODY",
+		[]string{"VHDR", "ATAK", "RLSE", "CHAN", "PAN ", "SEQN", "FADE"}},
+	"ACBM": {[]string{"BMHD"}, "ABIT",
+		[]string{"BMHD", "CMAP", "CAMG", "GRAB", "DEST", "SPRT"}},
+	"AIFF": {[]string{"COMM"}, "", nil},
+	"AIFC": {[]string{"FVER", "COMM"}, "", nil},
+	"ILBM": {[]string{"BMHD"}, "BODY",
+		[]string{"BMHD", "CMAP", "CAMG", "GRAB", "DEST", "SPRT", "CRNG", "CCRT",
+			"DRNG", "DPI ", "CTBL", "SHAM", "PCHG"}},
+	"PREF": {[]string{"PRHD"}, "", nil},
+	"RGB8": {[]string{"BMHD"}, "BODY", []string{"BMHD", "CMAP", "CAMG", "GRAB"}},
+	"RGBN": {[]string{"BMHD"}, "BODY", []string{"BMHD", "CMAP", "CAMG", "GRAB"}},
+	"SMUS": {[]string{"SHDR"}, "TRAK", []string{"SHDR", "INS1"}},
+	"CMUS": {[]string{"SHDR"}, "TRAK", []string{"SHDR", "INS1"}},
+}
+
+// validator contains the state of Validate.
+type validator struct {
+	diags []Diagnostic
+}
+
+// Validate checks the chunk tree for conformance with EA IFF 85 and the
+// rules of well-known FORM types. Trailing bytes after the root chunk are
+// taken from its TrailingSize. It returns the problems found.
+func Validate(root *IFFChunk) []Diagnostic {
+	var v validator
+
+	if root == nil {
+		return nil
+	}
+
+	if root.ID != "FORM" && root.ID != "CAT " && root.ID != "LIST" {
+		v.add(SeverityError, root.Offset, "", "file doesn't start with FORM, CAT, or LIST")
+	}
+	v.validateChunk(root, nil, ChunkPath("", root, -1), nil)
+
+	if root.TrailingSize > 0 {
+		v.add(SeverityWarning, root.EndOffset, "",
+			fmt.Sprintf("%d bytes after the end of the root chunk", root.TrailingSize))
+	}
+
+	return v.diags
+}
+
+// add appends a diagnostic.
+func (v *validator) add(severity Severity, offset int64, path string, message string) {
+	v.diags = append(v.diags, Diagnostic{
+		Severity: severity,
+		Offset:   offset,
+		Path:     path,
+		Message:  message,
+	})
+}
+
+// validateChunk recursively checks the chunk and its children.
+// props contains the PROP chunks of all enclosing LISTs.
+func (v *validator) validateChunk(chunk *IFFChunk, parent *IFFChunk, path string,
+	props []*IFFChunk) {
+
+	v.validateID(chunk.ID, "chunk ID", chunk.Offset, path)
+
+	if !isGroup(chunk.ID) {
+		return
+	}
+
+	// CAT and LIST may use 4 spaces as type if their content is mixed
+	if chunk.SubID != "    " || (chunk.ID != "CAT " && chunk.ID != "LIST") {
+		v.validateID(chunk.SubID, "SubID", chunk.Offset+8, path)
+	}
+	if strings.ToUpper(chunk.SubID) != chunk.SubID {
+		v.add(SeverityError, chunk.Offset+8, path,
+			fmt.Sprintf("%s type %q contains lower case letters",
+				strings.TrimRight(chunk.ID, " "), chunk.SubID))
+	}
+
+	if chunk.ID == "PROP" && (parent == nil || parent.ID != "LIST") {
+		v.add(SeverityError, chunk.Offset, path, "PROP outside of a LIST")
+	}
+
+	switch chunk.ID {
+	case "CAT ", "LIST":
+		for i, child := range chunk.Childs {
+			if !isGroup(child.ID) {
+				v.add(SeverityError, child.Offset, ChunkPath(path, child, i),
+					fmt.Sprintf("data chunk %q in %s", child.ID, strings.TrimRight(chunk.ID, " ")))
+			}
+		}
+	case "FORM":
+		v.validateForm(chunk, parent, path, props)
+	}
+
+	if chunk.ID == "LIST" {
+		// the properties of the LIST apply to all following FORMs
+		props = slices.Clone(props)
+	}
+	for i, child := range chunk.Childs {
+		v.validateChunk(child, chunk, ChunkPath(path, child, i), props)
+		if chunk.ID == "LIST" && child.ID == "PROP" {
+			props = append(props, child)
+		}
+	}
+}
+
+// validateID checks that an ID consists of printable ASCII characters
+// and doesn't contain leading or embedded spaces.
+func (v *validator) validateID(id string, what string, offset int64, path string) {
+	if !isValidID(id) {
+		v.add(SeverityError, offset, path,
+			fmt.Sprintf("%s %q contains illegal characters", what, id))
+		return
+	}
+	if id[0] == ' ' {
+		v.add(SeverityError, offset, path, fmt.Sprintf("%s %q has leading spaces", what, id))
+	} else if trimmed := strings.TrimRight(id, " "); strings.Contains(trimmed, " ") {
+		v.add(SeverityError, offset, path, fmt.Sprintf("%s %q has embedded spaces", what, id))
+	}
+}
+
+// validateForm checks the rules of the FORM type.
+func (v *validator) validateForm(form *IFFChunk, parent *IFFChunk, path string,
+	props []*IFFChunk) {
+
+	rule, exists := formRules[form.SubID]
+	if !exists {
+		return
+	}
+
+	// in an ANIM only the first frame contains the full picture
+	isFirstFrame := true
+	if parent != nil && parent.ID == "FORM" && parent.SubID == "ANIM" {
+		isFirstFrame = len(parent.Childs) > 0 && parent.Childs[0] == form
+	}
+
+	if isFirstFrame {
+		for _, id := range rule.mandatory {
+			if findProperty(form, props, id) == nil {
+				v.add(SeverityError, form.Offset, path,
+					fmt.Sprintf("mandatory chunk %s is missing", strings.TrimRight(id, " ")))
+			}
+		}
+	}
+
+	if rule.body == "" {
+		return
+	}
+	bodySeen := false
+	for i, child := range form.Childs {
+		if child.ID == rule.body {
+			bodySeen = true
+		} else if bodySeen && slices.Contains(rule.properties, child.ID) {
+			v.add(SeverityWarning, child.Offset, ChunkPath(path, child, i),
+				fmt.Sprintf("property chunk %s after %s", strings.TrimRight(child.ID, " "),
+					rule.body))
+		}
+	}
+}
+
+// findProperty returns the data chunk with the given ID from the FORM or,
+// if it's not there, from the last matching PROP of an enclosing LIST.
+func findProperty(form *IFFChunk, props []*IFFChunk, id string) *IFFChunk {
+	for _, child := range form.Childs {
+		if child.ID == id {
+			return child
+		}
+	}
+	for i := len(props) - 1; i >= 0; i-- {
+		if props[i].SubID != form.SubID {
+			continue
+		}
+		for _, child := range props[i].Childs {
+			if child.ID == id {
+				return child
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	var tests = []struct {
+		name      string
+		root      *IFFChunk
+		wantDiags []string
+	}{
+		{"WellFormed", &IFFChunk{ID: "FORM", SubID: "ILBM", Childs: []*IFFChunk{
+			{ID: "BMHD"}, {ID: "CMAP"}, {ID: "BODY"}, {ID: "ANNO"},
+		}}, nil},
+		{"IllegalID", &IFFChunk{ID: "FORM", SubID: "ILBM", Childs: []*IFFChunk{
+			{ID: "BMHD"}, {ID: "BO\x01Y"},
+		}}, []string{"illegal characters"}},
+		{"LeadingSpace", &IFFChunk{ID: "FORM", SubID: "ILBM", Childs: []*IFFChunk{
+			{ID: "BMHD"}, {ID: " DPI"}, {ID: "A  B"},
+		}}, []string{"leading spaces", "embedded spaces"}},
+		{"LowerCaseType", &IFFChunk{ID: "FORM", SubID: "Ilbm"},
+			[]string{"lower case"}},
+		{"PropOutsideList", &IFFChunk{ID: "CAT ", SubID: "ILBM", Childs: []*IFFChunk{
+			{ID: "PROP", SubID: "ILBM"},
+		}}, []string{"PROP outside"}},
+		{"DataInList", &IFFChunk{ID: "LIST", SubID: "ILBM", Childs: []*IFFChunk{
+			{ID: "BMHD"},
+		}}, []string{"data chunk"}},
+		{"MissingMandatory", &IFFChunk{ID: "CAT ", SubID: "    ", Childs: []*IFFChunk{
+			{ID: "FORM", SubID: "ILBM", Childs: []*IFFChunk{{ID: "BODY"}}},
+			{ID: "FORM", SubID: "8SVX", Childs: []*IFFChunk{{ID: "BODY"}}},
+		}}, []string{"BMHD is missing", "VHDR is missing"}},
+		{"MandatoryInProp", &IFFChunk{ID: "LIST", SubID: "ILBM", Childs: []*IFFChunk{
+			{ID: "PROP", SubID: "ILBM", Childs: []*IFFChunk{{ID: "BMHD"}}},
+			{ID: "FORM", SubID: "ILBM", Childs: []*IFFChunk{{ID: "BODY"}}},
+		}}, nil},
+		{"AnimFrames", &IFFChunk{ID: "FORM", SubID: "ANIM", Childs: []*IFFChunk{
+			{ID: "FORM", SubID: "ILBM", Childs: []*IFFChunk{{ID: "BMHD"}, {ID: "BODY"}}},
+			{ID: "FORM", SubID: "ILBM", Childs: []*IFFChunk{{ID: "ANHD"}, {ID: "DLTA"}}},
+		}}, nil},
+		{"PropertyAfterBody", &IFFChunk{ID: "FORM", SubID: "ILBM", Childs: []*IFFChunk{
+			{ID: "BMHD"}, {ID: "BODY"}, {ID: "CMAP"},
+		}}, []string{"CMAP after BODY"}},
+		{"Trailing", &IFFChunk{ID: "FORM", SubID: "PREF", TrailingSize: 3,
+			Childs: []*IFFChunk{{ID: "PRHD"}}}, []string{"3 bytes after"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := Validate(tt.root)
+
+			if len(diags) != len(tt.wantDiags) {
+				t.Fatalf("Diagnostics: got %v, want %v", diags, tt.wantDiags)
+			}
+			for i, want := range tt.wantDiags {
+				if !strings.Contains(diags[i].Message, want) {
+					t.Errorf("Diagnostic %d: got %q, want %q", i, diags[i].Message, want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateTestFiles(t *testing.T) {
+	var tests = []string{
+		"test.bsh",
+		"test2.bsh",
+		"KeyShow.catalog",
+		"TextEditor_mcp.catalog",
+	}
+	for _, name := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, root := readTestIFF(t, name)
+
+			if diags := Validate(root); len(diags) > 0 {
+				t.Errorf("Diagnostics: got %v, want none", diags)
+			}
+		})
+	}
+}
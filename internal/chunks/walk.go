@@ -0,0 +1,74 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// WalkFunc is called by Walk for every chunk. path is the chunk path
+// (see ChunkPath) and level the nesting depth, 0 for the root chunk.
+// If the function returns an error, the walk is stopped.
+type WalkFunc func(chunk *IFFChunk, path string, level int) error
+
+// Walk calls fn for the root chunk and all its children in file order.
+// It returns the first error returned by fn.
+func Walk(root *IFFChunk, fn WalkFunc) error {
+	if root == nil {
+		return nil
+	}
+	return walkChunk(root, ChunkPath("", root, -1), 0, fn)
+}
+
+// walkChunk recursively calls fn for the chunk and its children.
+func walkChunk(chunk *IFFChunk, path string, level int, fn WalkFunc) error {
+	if err := fn(chunk, path, level); err != nil {
+		return err
+	}
+	for i, child := range chunk.Childs {
+		if err := walkChunk(child, ChunkPath(path, child, i), level+1, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FindForm returns the first FORM in the chunk tree, in file order,
+// whose type is one of the given types or nil if there is none.
+func FindForm(root *IFFChunk, types ...string) *IFFChunk {
+	if root == nil {
+		return nil
+	}
+	if root.ID == "FORM" && slices.Contains(types, root.SubID) {
+		return root
+	}
+	for _, child := range root.Childs {
+		if form := FindForm(child, types...); form != nil {
+			return form
+		}
+	}
+	return nil
+}
+
+// ChunkPath returns the path of a chunk. The path consists of the path
+// of the parent and an element for the chunk, separated by a slash.
+// The element is the ID with trailing spaces removed, followed by
+// ":SubID" for group chunks and "[index]" for the position within the
+// parent. The root chunk is called with an index < 0 and gets no
+// index, e.g. "FORM:ILBM/BMHD[0]".
+func ChunkPath(parentPath string, chunk *IFFChunk, index int) string {
+	element := strings.TrimRight(chunk.ID, " ")
+	if isGroup(chunk.ID) {
+		element += ":" + strings.TrimRight(chunk.SubID, " ")
+	}
+	if index >= 0 {
+		element += fmt.Sprintf("[%d]", index)
+	}
+	if parentPath == "" {
+		return element
+	}
+	return parentPath + "/" + element
+}
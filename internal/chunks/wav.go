@@ -0,0 +1,267 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Format tags of the fmt chunk.
+const (
+	wavFormatPCM        = 0x0001
+	wavFormatFloat      = 0x0003
+	wavFormatExtensible = 0xFFFE
+)
+
+// WAVLoop is a sustain loop of a WAV file. Start and End are the indices
+// of the first and the last frame of the loop.
+type WAVLoop struct {
+	Start uint32
+	End   uint32
+}
+
+// WAV contains the data of a RIFF WAVE file with PCM samples.
+type WAV struct {
+	SampleRate    uint32
+	Channels      uint16
+	BitsPerSample uint16 // 8 (unsigned samples) or more (signed samples)
+	Float         bool   // samples are IEEE floats, only read by ReadWAV
+	Data          []byte // interleaved samples in WAV format
+	Loops         []WAVLoop
+	Info          map[string]string // texts of the LIST INFO chunk by ID
+}
+
+// NewWAV8 creates a mono WAV with 8 bit samples. The signed samples
+// are converted to the unsigned format of WAV.
+func NewWAV8(samples []int8, sampleRate uint32) *WAV {
+	data := make([]byte, len(samples))
+	for i, s := range samples {
+		data[i] = byte(int(s) + 0x80)
+	}
+	return &WAV{SampleRate: sampleRate, Channels: 1, BitsPerSample: 8, Data: data}
+}
+
+// WriteWAV writes the WAV as RIFF WAVE file with 8 or 16 bit PCM samples.
+// Loops are stored in a smpl chunk.
+func WriteWAV(writer io.Writer, wav *WAV) error {
+	var buf bytes.Buffer
+
+	if wav.Channels == 0 || wav.Float || (wav.BitsPerSample != 8 && wav.BitsPerSample != 16) {
+		return fmt.Errorf("unsupported WAV format: %d channels with %d bit",
+			wav.Channels, wav.BitsPerSample)
+	}
+	blockAlign := wav.Channels * wav.BitsPerSample / 8
+
+	var format [16]byte
+	binary.LittleEndian.PutUint16(format[0:], 1) // PCM
+	binary.LittleEndian.PutUint16(format[2:], wav.Channels)
+	binary.LittleEndian.PutUint32(format[4:], wav.SampleRate)
+	binary.LittleEndian.PutUint32(format[8:], wav.SampleRate*uint32(blockAlign))
+	binary.LittleEndian.PutUint16(format[12:], blockAlign)
+	binary.LittleEndian.PutUint16(format[14:], wav.BitsPerSample)
+	writeRIFFChunk(&buf, "fmt ", format[:])
+	writeRIFFChunk(&buf, "data", wav.Data)
+
+	if len(wav.Loops) > 0 {
+		smpl := make([]byte, 36, 36+24*len(wav.Loops))
+		if wav.SampleRate > 0 {
+			// sample period in nanoseconds
+			binary.LittleEndian.PutUint32(smpl[8:], 1000000000/wav.SampleRate)
+		}
+		binary.LittleEndian.PutUint32(smpl[12:], 60) // MIDI unity note C4
+		binary.LittleEndian.PutUint32(smpl[28:], uint32(len(wav.Loops)))
+		for i, loop := range wav.Loops {
+			var l [24]byte
+			binary.LittleEndian.PutUint32(l[0:], uint32(i)) // cue point ID
+			binary.LittleEndian.PutUint32(l[8:], loop.Start)
+			binary.LittleEndian.PutUint32(l[12:], loop.End)
+			smpl = append(smpl, l[:]...) // type forward, play count infinite
+		}
+		writeRIFFChunk(&buf, "smpl", smpl)
+	}
+
+	var header [12]byte
+	copy(header[0:], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:], uint32(4+buf.Len()))
+	copy(header[8:], "WAVE")
+	if _, err := writer.Write(header[:]); err != nil {
+		return err
+	}
+	_, err := writer.Write(buf.Bytes())
+	return err
+}
+
+// writeRIFFChunk appends a RIFF chunk with little endian size and pad
+// byte to the buffer.
+func writeRIFFChunk(buf *bytes.Buffer, id string, data []byte) {
+	var size [4]byte
+
+	binary.LittleEndian.PutUint32(size[:], uint32(len(data)))
+	buf.WriteString(id)
+	buf.Write(size[:])
+	buf.Write(data)
+	if len(data)%2 != 0 {
+		buf.WriteByte(0)
+	}
+}
+
+// ReadWAV reads a RIFF WAVE file with PCM or IEEE float samples
+// including the loops of the smpl chunk and the texts of the LIST INFO
+// chunk.
+func ReadWAV(reader io.Reader) (*WAV, error) {
+	var wav WAV
+	var hasFormat, hasData bool
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 12 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WAVE" {
+		return nil, fmt.Errorf("not a RIFF WAVE file")
+	}
+
+	for pos := 12; pos+8 <= len(data); {
+		id := string(data[pos : pos+4])
+		size := int(binary.LittleEndian.Uint32(data[pos+4:]))
+		start := pos + 8
+		end := min(start+size, len(data))
+		chunk := data[start:end]
+		pos = start + size + size%2
+
+		switch id {
+		case "fmt ":
+			if err := wav.parseFormat(chunk); err != nil {
+				return nil, err
+			}
+			hasFormat = true
+		case "data":
+			wav.Data = chunk
+			hasData = true
+		case "smpl":
+			wav.Loops = parseSampleLoops(chunk)
+		case "LIST":
+			if len(chunk) >= 4 && string(chunk[0:4]) == "INFO" {
+				wav.Info = parseInfoList(chunk[4:])
+			}
+		}
+	}
+
+	if !hasFormat {
+		return nil, fmt.Errorf("fmt chunk is missing")
+	}
+	if !hasData {
+		return nil, fmt.Errorf("data chunk is missing")
+	}
+	return &wav, nil
+}
+
+// parseFormat decodes the fmt chunk.
+func (wav *WAV) parseFormat(data []byte) error {
+	if len(data) < 16 {
+		return fmt.Errorf("fmt chunk is too short")
+	}
+
+	formatTag := binary.LittleEndian.Uint16(data)
+	wav.Channels = binary.LittleEndian.Uint16(data[2:])
+	wav.SampleRate = binary.LittleEndian.Uint32(data[4:])
+	wav.BitsPerSample = binary.LittleEndian.Uint16(data[14:])
+
+	if formatTag == wavFormatExtensible && len(data) >= 26 {
+		// the format is the first word of the sub format GUID
+		formatTag = binary.LittleEndian.Uint16(data[24:])
+	}
+	switch formatTag {
+	case wavFormatPCM:
+		if wav.BitsPerSample == 0 || wav.BitsPerSample > 32 {
+			return fmt.Errorf("unsupported sample size: %d", wav.BitsPerSample)
+		}
+	case wavFormatFloat:
+		if wav.BitsPerSample != 32 && wav.BitsPerSample != 64 {
+			return fmt.Errorf("unsupported float sample size: %d", wav.BitsPerSample)
+		}
+		wav.Float = true
+	default:
+		return fmt.Errorf("unsupported WAV format: 0x%04X", formatTag)
+	}
+	if wav.Channels == 0 {
+		return fmt.Errorf("WAV has no channels")
+	}
+	return nil
+}
+
+// parseSampleLoops decodes the loops of a smpl chunk.
+func parseSampleLoops(data []byte) []WAVLoop {
+	var loops []WAVLoop
+
+	if len(data) < 36 {
+		return nil
+	}
+	count := int(binary.LittleEndian.Uint32(data[28:]))
+	for i := 0; i < count && 36+(i+1)*24 <= len(data); i++ {
+		loop := data[36+i*24:]
+		loops = append(loops, WAVLoop{
+			Start: binary.LittleEndian.Uint32(loop[8:]),
+			End:   binary.LittleEndian.Uint32(loop[12:]),
+		})
+	}
+	return loops
+}
+
+// parseInfoList decodes the texts of a LIST INFO chunk, e.g. INAM for
+// the name. Trailing zero bytes are removed.
+func parseInfoList(data []byte) map[string]string {
+	info := make(map[string]string)
+
+	for pos := 0; pos+8 <= len(data); {
+		id := string(data[pos : pos+4])
+		size := int(binary.LittleEndian.Uint32(data[pos+4:]))
+		end := min(pos+8+size, len(data))
+		info[id] = string(bytes.TrimRight(data[pos+8:end], "\x00"))
+		pos += 8 + size + size%2
+	}
+	return info
+}
+
+// Samples returns the samples of each channel from -1 to 1.
+func (wav *WAV) Samples() [][]float64 {
+	bytesPerSample := (int(wav.BitsPerSample) + 7) / 8
+	channels := int(wav.Channels)
+	if bytesPerSample == 0 || channels == 0 {
+		return nil
+	}
+	frames := len(wav.Data) / (bytesPerSample * channels)
+
+	result := make([][]float64, channels)
+	for c := range result {
+		result[c] = make([]float64, frames)
+	}
+	for f := 0; f < frames; f++ {
+		for c := 0; c < channels; c++ {
+			b := wav.Data[(f*channels+c)*bytesPerSample:]
+			var value float64
+			switch {
+			case wav.Float && bytesPerSample == 4:
+				value = float64(math.Float32frombits(binary.LittleEndian.Uint32(b)))
+			case wav.Float:
+				value = math.Float64frombits(binary.LittleEndian.Uint64(b))
+			case bytesPerSample == 1:
+				// 8 bit samples are unsigned
+				value = float64(int(b[0])-0x80) / 0x80
+			default:
+				var v int64
+				for i := bytesPerSample - 1; i >= 0; i-- {
+					v = v<<8 | int64(b[i])
+				}
+				v <<= 64 - 8*bytesPerSample // sign extension
+				value = float64(v) / math.Exp2(63)
+			}
+			result[c][f] = value
+		}
+	}
+	return result
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// WriteIFFFile writes the chunk tree to the writer as an EA IFF 85 file.
+// The size of data chunks is taken from the length of their data, the size
+// of group chunks is recomputed from their children. Size, SumSize and
+// the offsets of all chunks are updated accordingly. Data chunks with an
+// odd size are followed by a pad byte.
+// In case of an error, the function returns the error. The writer may
+// contain an incomplete file then.
+func WriteIFFFile(writer io.Writer, root *IFFChunk) error {
+	if root == nil {
+		return fmt.Errorf("no root chunk")
+	}
+	if root.ID != "FORM" && root.ID != "CAT " && root.ID != "LIST" {
+		return fmt.Errorf("root chunk isn't FORM, CAT, or LIST")
+	}
+
+	if err := updateLayout(root, 0); err != nil {
+		return err
+	}
+	root.TrailingSize = 0
+
+	return writeChunk(writer, root)
+}
+
+// updateLayout recursively recomputes Size, SumSize and the offsets of
+// the chunk and its children. offset is the file offset of the chunk.
+func updateLayout(chunk *IFFChunk, offset int64) error {
+	if len(chunk.ID) != 4 {
+		return fmt.Errorf("invalid chunk ID %q", chunk.ID)
+	}
+	chunk.Offset = offset
+
+	if isGroup(chunk.ID) {
+		if len(chunk.SubID) != 4 {
+			return fmt.Errorf("invalid SubID %q of %s chunk", chunk.SubID, chunk.ID)
+		}
+		chunk.DataOffset = offset + 12
+		sumSize := int64(12)
+		for _, child := range chunk.Childs {
+			if err := updateLayout(child, offset+sumSize); err != nil {
+				return err
+			}
+			sumSize += child.SumSize
+		}
+		if sumSize-8 > 0xFFFFFFFF {
+			return fmt.Errorf("%s %s chunk is too large", chunk.ID, chunk.SubID)
+		}
+		chunk.Size = uint32(sumSize - 8)
+		chunk.SumSize = sumSize
+	} else {
+		if int64(len(chunk.Data)) > 0xFFFFFFFF {
+			return fmt.Errorf("%s chunk is too large", chunk.ID)
+		}
+		chunk.DataOffset = offset + 8
+		chunk.Size = uint32(len(chunk.Data))
+		chunk.SumSize = 8 + int64(chunk.Size) + int64(chunk.Size%2)
+	}
+	chunk.EndOffset = offset + chunk.SumSize
+
+	return nil
+}
+
+// writeChunk recursively writes the chunk and its children to the writer.
+// The sizes must have been updated with updateLayout before.
+func writeChunk(writer io.Writer, chunk *IFFChunk) error {
+	if _, err := io.WriteString(writer, chunk.ID); err != nil {
+		return err
+	}
+	if err := binary.Write(writer, binary.BigEndian, chunk.Size); err != nil {
+		return err
+	}
+
+	if isGroup(chunk.ID) {
+		if _, err := io.WriteString(writer, chunk.SubID); err != nil {
+			return err
+		}
+		for _, child := range chunk.Childs {
+			if err := writeChunk(writer, child); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if _, err := writer.Write(chunk.Data); err != nil {
+		return err
+	}
+	// odd sized chunks are followed by a pad byte
+	if chunk.Size%2 != 0 {
+		if _, err := writer.Write([]byte{0}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
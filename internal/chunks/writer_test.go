@@ -0,0 +1,110 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package chunks
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+)
+
+func TestWriteIFFFileRoundTrip(t *testing.T) {
+	var tests = []string{
+		"test.bsh",
+		"test2.bsh",
+		"KeyShow.catalog",
+		"TextEditor_mcp.catalog",
+	}
+	for _, name := range tests {
+		t.Run(name, func(t *testing.T) {
+			data, root := readTestIFF(t, name)
+
+			// remember the offsets recorded by the reader
+			var offsets [][3]int64
+			var collect func(chunk *IFFChunk)
+			collect = func(chunk *IFFChunk) {
+				offsets = append(offsets,
+					[3]int64{chunk.Offset, chunk.DataOffset, chunk.EndOffset})
+				for _, child := range chunk.Childs {
+					collect(child)
+				}
+			}
+			collect(root)
+			readOffsets := offsets
+			offsets = nil
+
+			var buf bytes.Buffer
+			if err := WriteIFFFile(&buf, root); err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(buf.Bytes(), data) {
+				t.Errorf("Data: got %d bytes, want %d bytes, content differs",
+					buf.Len(), len(data))
+			}
+
+			collect(root)
+			if !slices.Equal(offsets, readOffsets) {
+				t.Errorf("Offsets: got %v, want %v", offsets, readOffsets)
+			}
+			if root.EndOffset != int64(len(data)) {
+				t.Errorf("EndOffset: got %d, want %d", root.EndOffset, len(data))
+			}
+		})
+	}
+}
+
+func TestWriteIFFFileSizes(t *testing.T) {
+	root := &IFFChunk{ID: "LIST", SubID: "ILBM", Childs: []*IFFChunk{
+		{ID: "PROP", SubID: "ILBM", Childs: []*IFFChunk{
+			{ID: "CMAP", Data: []byte{1, 2, 3}},
+		}},
+		{ID: "FORM", SubID: "ILBM", Childs: []*IFFChunk{
+			{ID: "BODY", Data: []byte{1, 2, 3, 4, 5}},
+		}},
+	}}
+
+	var buf bytes.Buffer
+	if err := WriteIFFFile(&buf, root); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []byte("LIST\x00\x00\x00\x36ILBM" +
+		"PROP\x00\x00\x00\x10ILBMCMAP\x00\x00\x00\x03\x01\x02\x03\x00" +
+		"FORM\x00\x00\x00\x12ILBMBODY\x00\x00\x00\x05\x01\x02\x03\x04\x05\x00")
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Errorf("Data: got %q, want %q", buf.Bytes(), want)
+	}
+	if root.Size != 0x36 || root.SumSize != 0x3E {
+		t.Errorf("Size: got %d/%d, want %d/%d", root.Size, root.SumSize, 0x36, 0x3E)
+	}
+
+	reread, err := ReadIFFFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reread.Childs) != 2 || reread.Childs[1].Childs[0].ChType() != "ILBM.BODY" {
+		t.Errorf("Reread: unexpected tree")
+	}
+}
+
+func TestWriteIFFFileErrors(t *testing.T) {
+	var tests = []struct {
+		name string
+		root *IFFChunk
+	}{
+		{"NoRoot", nil},
+		{"DataRoot", &IFFChunk{ID: "BODY"}},
+		{"ShortID", &IFFChunk{ID: "FORM", SubID: "ILBM",
+			Childs: []*IFFChunk{{ID: "BOD"}}}},
+		{"ShortSubID", &IFFChunk{ID: "FORM", SubID: "ILB"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteIFFFile(&buf, tt.root); err == nil {
+				t.Errorf("Error: got nil, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package gui
+
+import (
+	"fmt"
+	"image"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/mattrust/iffmaster/iff"
+)
+
+// AnimView plays the frames of an ANIM FORM.
+type AnimView struct {
+	image      *canvas.Image
+	label      *widget.Label
+	playButton *widget.Button
+
+	form   *iff.Chunk // the decoded ANIM FORM
+	anim   *iff.Animation
+	status string // format or error shown after the frame counter
+	frame  int
+	images []image.Image // cache of the converted frames
+
+	stop chan struct{} // closed to stop the playback, nil if stopped
+}
+
+// NewAnimView creates the view which plays the animation of the file.
+func NewAnimView(appData *AppData) fyne.CanvasObject {
+	view := &AnimView{}
+	appData.animView = view
+
+	view.image = canvas.NewImageFromImage(nil)
+	view.image.FillMode = canvas.ImageFillContain
+	view.image.ScaleMode = canvas.ImageScalePixels
+
+	view.label = widget.NewLabel("")
+	view.label.Alignment = fyne.TextAlignCenter
+
+	view.playButton = widget.NewButtonWithIcon("", theme.MediaPlayIcon(), func() {
+		if view.stop != nil {
+			view.pause()
+		} else {
+			view.play()
+		}
+	})
+	controls := container.NewHBox(
+		widget.NewButtonWithIcon("", theme.MediaSkipPreviousIcon(), func() {
+			view.pause()
+			view.showFrame(0)
+		}),
+		widget.NewButtonWithIcon("", theme.NavigateBackIcon(), func() {
+			view.pause()
+			view.showFrame(view.frame - 1)
+		}),
+		view.playButton,
+		widget.NewButtonWithIcon("", theme.NavigateNextIcon(), func() {
+			view.pause()
+			view.showFrame(view.frame + 1)
+		}),
+	)
+
+	bottom := container.NewVBox(container.NewCenter(controls), view.label)
+	return container.NewBorder(nil, bottom, nil, nil, view.image)
+}
+
+// updateAnimView decodes the ANIM FORM which contains the selected chunk
+// or, if there is none, the first animation of the file and shows its
+// first frame. The animation keeps playing while chunks of the same
+// FORM are selected.
+func updateAnimView(appData *AppData) {
+	view := appData.animView
+	form := currentForm(appData, "ANIM")
+	if form != nil && form == view.form {
+		return
+	}
+
+	view.pause()
+	view.form = form
+	view.anim = nil
+	view.images = nil
+	view.status = ""
+
+	if form == nil {
+		view.image.Image = nil
+		view.image.Refresh()
+		view.label.SetText("(no animation)")
+		return
+	}
+
+	anim, err := iff.DecodeANIM(form)
+	view.anim = anim
+	if anim != nil {
+		view.images = make([]image.Image, len(anim.Frames))
+		pic := anim.Frames[0].Picture
+		view.status = fmt.Sprintf("%d x %d pixels, %d planes",
+			pic.Bitmap.Width, pic.Bitmap.Height, len(pic.Bitmap.Planes))
+	}
+	if err != nil {
+		view.status = fmt.Sprintf("(error: %s)", err)
+	}
+	view.showFrame(0)
+}
+
+// showFrame shows the frame with the given index, which wraps around at
+// both ends of the animation.
+func (view *AnimView) showFrame(index int) {
+	if view.anim == nil || len(view.anim.Frames) == 0 {
+		view.image.Image = nil
+		view.image.Refresh()
+		view.label.SetText(view.status)
+		return
+	}
+
+	count := len(view.anim.Frames)
+	view.frame = (index%count + count) % count
+	if view.images[view.frame] == nil {
+		view.images[view.frame] = view.anim.Frames[view.frame].Picture.Image()
+	}
+	view.image.Image = view.images[view.frame]
+	view.image.Refresh()
+	view.label.SetText(fmt.Sprintf("Frame %d / %d, %s", view.frame+1, count, view.status))
+}
+
+// play starts the playback at the current frame. Each frame is shown
+// for the time given in its ANHD chunk.
+func (view *AnimView) play() {
+	if view.anim == nil || len(view.anim.Frames) < 2 || view.stop != nil {
+		return
+	}
+	stop := make(chan struct{})
+	view.stop = stop
+	view.playButton.SetIcon(theme.MediaPauseIcon())
+
+	first := view.anim.Frames[view.frame].Delay
+	go func() {
+		timer := time.NewTimer(first)
+		defer timer.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-timer.C:
+			}
+
+			var delay time.Duration
+			stopped := false
+			fyne.DoAndWait(func() {
+				if view.stop != stop {
+					stopped = true
+					return
+				}
+				view.showFrame(view.frame + 1)
+				delay = view.anim.Frames[view.frame].Delay
+			})
+			if stopped {
+				return
+			}
+			timer.Reset(delay)
+		}
+	}()
+}
+
+// pause stops the playback.
+func (view *AnimView) pause() {
+	if view.stop == nil {
+		return
+	}
+	close(view.stop)
+	view.stop = nil
+	view.playButton.SetIcon(theme.MediaPlayIcon())
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package gui
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+	"github.com/mattrust/iffmaster/iff"
+)
+
+// catalogColumns are the headers of the catalog table.
+var catalogColumns = []string{"ID", "Length", "Text"}
+
+// CatalogView shows the strings of a CTLG.STRS chunk in a table which
+// can be sorted by clicking on the column headers.
+type CatalogView struct {
+	table   *widget.Table
+	strings []iff.CatalogString
+
+	sortColumn int
+	descending bool
+}
+
+// NewStructureView creates the content of the structure tab. It shows the
+// catalog table for STRS chunks of catalogs and the structure table for
+// all other iff.
+func NewStructureView(appData *AppData) fyne.CanvasObject {
+	view := &CatalogView{}
+	appData.catalogView = view
+
+	view.table = widget.NewTable(
+		func() (int, int) {
+			return len(view.strings), len(catalogColumns)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("AAAAAAAAAA")
+		},
+		func(i widget.TableCellID, o fyne.CanvasObject) {
+			o.(*widget.Label).SetText(view.cell(i.Row, i.Col))
+		},
+	)
+	view.table.SetColumnWidth(0, 80)
+	view.table.SetColumnWidth(1, 80)
+	view.table.SetColumnWidth(2, 500)
+
+	view.table.ShowHeaderRow = true
+	view.table.CreateHeader = func() fyne.CanvasObject {
+		return widget.NewButton("", nil)
+	}
+	view.table.UpdateHeader = func(i widget.TableCellID, o fyne.CanvasObject) {
+		button := o.(*widget.Button)
+		if i.Col < 0 {
+			button.Hide()
+			return
+		}
+		text := catalogColumns[i.Col]
+		if i.Col == view.sortColumn {
+			if view.descending {
+				text += " ▼"
+			} else {
+				text += " ▲"
+			}
+		}
+		button.SetText(text)
+		button.OnTapped = func() {
+			view.sortBy(i.Col)
+		}
+	}
+
+	view.table.Hide()
+	return container.NewStack(appData.structTableView, view.table)
+}
+
+// cell returns the text of a cell of the catalog table.
+func (view *CatalogView) cell(row int, col int) string {
+	if row >= len(view.strings) {
+		return ""
+	}
+	entry := view.strings[row]
+	switch col {
+	case 0:
+		return fmt.Sprintf("%d", entry.ID)
+	case 1:
+		return fmt.Sprintf("%d", entry.Length)
+	default:
+		return entry.Text
+	}
+}
+
+// sortBy sorts the table by the given column. Clicking on the current
+// column again reverses the order.
+func (view *CatalogView) sortBy(col int) {
+	if col == view.sortColumn {
+		view.descending = !view.descending
+	} else {
+		view.sortColumn = col
+		view.descending = false
+	}
+	view.sort()
+	view.table.Refresh()
+}
+
+// sort sorts the strings by the current column, equal entries by ID.
+func (view *CatalogView) sort() {
+	slices.SortStableFunc(view.strings, func(a, b iff.CatalogString) int {
+		var result int
+		switch view.sortColumn {
+		case 1:
+			result = cmp.Compare(a.Length, b.Length)
+		case 2:
+			result = cmp.Compare(a.Text, b.Text)
+		}
+		if result == 0 {
+			result = cmp.Compare(a.ID, b.ID)
+		}
+		if view.descending {
+			result = -result
+		}
+		return result
+	})
+}
+
+// updateCatalogView shows the catalog table if the selected chunk is
+// the STRS chunk of a catalog. The strings are decoded with the
+// character set of the CSET chunk.
+func updateCatalogView(appData *AppData) {
+	view := appData.catalogView
+	view.strings = nil
+
+	if appData.currentListIndex < len(appData.nodeList) {
+		entry := appData.nodeList[appData.currentListIndex]
+		if entry.ID == "STRS" && iff.IsCatalogForm(entry.form) {
+			ctlg, _ := iff.DecodeCatalog(entry.form)
+			if ctlg != nil {
+				view.strings = ctlg.Strings
+			}
+		}
+	}
+
+	if view.strings == nil {
+		view.table.Hide()
+		appData.structTableView.Show()
+		return
+	}
+	view.sort()
+	appData.structTableView.Hide()
+	view.table.Show()
+	view.table.ScrollToTop()
+	view.table.Refresh()
+}
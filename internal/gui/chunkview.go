@@ -5,8 +5,12 @@ package gui
 
 import (
 	"fmt"
+	"image/color"
 
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 	"golang.org/x/text/encoding/charmap"
 )
@@ -27,7 +31,7 @@ func NewHexTableView(appData *AppData) *widget.Table {
 
 		// Provide the content template
 		func() fyne.CanvasObject {
-			return widget.NewLabel("AA")
+			return newByteCell()
 		},
 
 		// Provide the content for a specific cell
@@ -35,14 +39,19 @@ func NewHexTableView(appData *AppData) *widget.Table {
 			if appData.currentListIndex < len(appData.nodeList) {
 				idx := i.Row*16 + i.Col
 				if idx < len(appData.nodeList[appData.currentListIndex].Data) {
-					o.(*widget.Label).SetText(fmt.Sprintf("%02X",
-						appData.nodeList[appData.currentListIndex].Data[idx]))
+					setByteCell(o, fmt.Sprintf("%02X",
+						appData.nodeList[appData.currentListIndex].Data[idx]),
+						isFieldByte(appData, idx))
 					return
 				}
 			}
-			o.(*widget.Label).SetText("")
+			setByteCell(o, "", false)
 		},
 	)
+	showAddressColumn(table, appData)
+	table.OnSelected = func(id widget.TableCellID) {
+		selectByte(appData, id.Row*16+id.Col)
+	}
 
 	return table
 }
@@ -63,7 +72,7 @@ func NewIsoTableView(appData *AppData) *widget.Table {
 
 		// Provide the content template
 		func() fyne.CanvasObject {
-			return widget.NewLabel("AA")
+			return newByteCell()
 		},
 
 		// Provide the content for a specific cell
@@ -72,14 +81,18 @@ func NewIsoTableView(appData *AppData) *widget.Table {
 				idx := i.Row*16 + i.Col
 				if idx < len(appData.nodeList[appData.currentListIndex].Data) {
 					data := appData.nodeList[appData.currentListIndex].Data[idx]
-					o.(*widget.Label).SetText(iso8859ToUtf8Char(data))
+					setByteCell(o, iso8859ToUtf8Char(data), isFieldByte(appData, idx))
 
 					return
 				}
 			}
-			o.(*widget.Label).SetText("")
+			setByteCell(o, "", false)
 		},
 	)
+	showAddressColumn(table, appData)
+	table.OnSelected = func(id widget.TableCellID) {
+		selectByte(appData, id.Row*16+id.Col)
+	}
 
 	return table
 }
@@ -106,16 +119,120 @@ func NewStructTableView(appData *AppData) *widget.Table {
 		// Provide the content for a specific cell
 		func(i widget.TableCellID, o fyne.CanvasObject) {
 			if appData.currentListIndex < len(appData.nodeList) {
-				o.(*widget.Label).SetText(appData.nodeList[appData.currentListIndex].structure[i.Row][i.Col])
+				field := appData.nodeList[appData.currentListIndex].structure[i.Row]
+				if i.Col == 0 {
+					o.(*widget.Label).SetText(field.Name)
+				} else {
+					o.(*widget.Label).SetText(field.Value)
+				}
 				return
 			}
 			o.(*widget.Label).SetText("")
 		},
 	)
+	table.OnSelected = func(id widget.TableCellID) {
+		selectField(appData, id.Row)
+	}
 
 	return table
 }
 
+// newByteCell creates a cell of the hex and ISO8859-1 tables. It's a
+// label on a rectangle which is shown if the byte belongs to the selected
+// structure field.
+func newByteCell() fyne.CanvasObject {
+	background := canvas.NewRectangle(color.Transparent)
+	return container.NewStack(background, widget.NewLabel("AA"))
+}
+
+// setByteCell sets the text of a cell created by newByteCell and shows
+// or hides its highlight.
+func setByteCell(o fyne.CanvasObject, text string, highlighted bool) {
+	cell := o.(*fyne.Container)
+	background := cell.Objects[0].(*canvas.Rectangle)
+	if highlighted {
+		background.FillColor = theme.Color(theme.ColorNameSelection)
+	} else {
+		background.FillColor = color.Transparent
+	}
+	background.Refresh()
+	cell.Objects[1].(*widget.Label).SetText(text)
+}
+
+// isFieldByte returns true if the byte at index idx of the current chunk
+// belongs to the selected structure field.
+func isFieldByte(appData *AppData, idx int) bool {
+	if appData.selectedField < 0 || appData.currentListIndex >= len(appData.nodeList) {
+		return false
+	}
+	structure := appData.nodeList[appData.currentListIndex].structure
+	if appData.selectedField >= len(structure) {
+		return false
+	}
+	field := structure[appData.selectedField]
+	return uint32(idx) >= field.Offset && uint32(idx) < field.Offset+field.Length
+}
+
+// selectField highlights the bytes of a structure field in the hex and
+// ISO8859-1 tables and scrolls to them. A negative index removes the
+// highlight.
+func selectField(appData *AppData, index int) {
+	appData.selectedField = index
+	appData.hexTableView.Refresh()
+	appData.isoTableView.Refresh()
+
+	if index < 0 || appData.currentListIndex >= len(appData.nodeList) {
+		return
+	}
+	structure := appData.nodeList[appData.currentListIndex].structure
+	if index < len(structure) && structure[index].Length > 0 {
+		row := int(structure[index].Offset / 16)
+		appData.hexTableView.ScrollTo(widget.TableCellID{Row: row, Col: 0})
+		appData.isoTableView.ScrollTo(widget.TableCellID{Row: row, Col: 0})
+	}
+}
+
+// selectByte selects the structure field which was decoded from the byte
+// at index idx of the current chunk.
+func selectByte(appData *AppData, idx int) {
+	index := -1
+	if appData.currentListIndex < len(appData.nodeList) {
+		index = appData.nodeList[appData.currentListIndex].structure.FieldAt(uint32(idx))
+	}
+	if index < 0 {
+		appData.structTableView.UnselectAll()
+		selectField(appData, -1)
+		return
+	}
+	appData.structTableView.Select(widget.TableCellID{Row: index, Col: 0})
+}
+
+// resetFieldSelection removes the selection of all tables, e.g. if
+// another chunk is selected.
+func resetFieldSelection(appData *AppData) {
+	appData.hexTableView.UnselectAll()
+	appData.isoTableView.UnselectAll()
+	appData.structTableView.UnselectAll()
+	appData.selectedField = -1
+}
+
+// showAddressColumn adds a header column to a table with 16 bytes per row.
+// The header shows the absolute file offset of the first byte of each row.
+func showAddressColumn(table *widget.Table, appData *AppData) {
+	table.ShowHeaderColumn = true
+	table.CreateHeader = func() fyne.CanvasObject {
+		return widget.NewLabel("00000000")
+	}
+	table.UpdateHeader = func(i widget.TableCellID, o fyne.CanvasObject) {
+		if appData.currentListIndex < len(appData.nodeList) && i.Row >= 0 {
+			offset := appData.nodeList[appData.currentListIndex].DataOffset
+			o.(*widget.Label).SetText(fmt.Sprintf("%08X", offset+int64(i.Row)*16))
+			return
+		}
+		o.(*widget.Label).SetText("")
+	}
+}
+
 // iso8859ToUtf8Char converts an ISO-8859-1 character to a UTF-8 string.
 // Some special characters are converted to escape sequences.
 func iso8859ToUtf8Char(isoChar byte) string {
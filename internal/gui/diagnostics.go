@@ -0,0 +1,35 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package gui
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/mattrust/iffmaster/iff"
+)
+
+// showDiagnostics opens a dialog which lists the diagnostics.
+func showDiagnostics(appData *AppData, title string, diags []iff.Diagnostic) {
+	list := widget.NewList(
+		// The number of items in the list
+		func() int {
+			return len(diags)
+		},
+
+		// The function to create the widget for each item
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+
+		// The function to populate the widget with the data for each item
+		func(i widget.ListItemID, obj fyne.CanvasObject) {
+			obj.(*widget.Label).SetText(diags[i].String())
+		},
+	)
+
+	dlg := dialog.NewCustom(title, "Close", list, appData.win)
+	dlg.Resize(fyne.NewSize(700, 400))
+	dlg.Show()
+}
@@ -9,33 +9,46 @@ import (
 	"io"
 	"log"
 	"os"
+	"slices"
+	"strings"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
+	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
-	"github.com/mattrust/iffmaster/internal/chunks"
+	"github.com/mattrust/iffmaster/iff"
 )
 
 type AppData struct {
-	chunks *chunks.IFFChunk
+	chunks *iff.Chunk
 
 	app          fyne.App
 	win          fyne.Window
 	topContainer *fyne.Container
 
-	listView *widget.List
-	nodeList []ListEntry
+	treeView  *widget.Tree
+	nodeList  []TreeEntry
+	nodeIndex map[string]int // node indices by chunk path
 
 	currentListIndex int
+	selectedField    int // index of the highlighted structure field or -1
 
 	chunkInfo *widget.Label
 
 	hexTableView    *widget.Table
 	isoTableView    *widget.Table
 	structTableView *widget.Table
+
+	imageCanvas *canvas.Image
+	imageLabel  *widget.Label
+
+	waveformView *WaveformView
+	animView     *AnimView
+	catalogView  *CatalogView
+	textView     *TextView
 }
 
 // OpenGUI layouts the main window and opens it.
@@ -45,7 +58,8 @@ func OpenGUI(filename string, version string) {
 	var appData AppData
 	var fileDlg *dialog.FileDialog
 
-	appData.nodeList = make([]ListEntry, 0)
+	appData.nodeList = make([]TreeEntry, 0)
+	appData.selectedField = -1
 
 	appData.app = app.NewWithID("github.mattrust.iffmaster")
 	appData.win = appData.app.NewWindow("IFF Master")
@@ -58,10 +72,12 @@ func OpenGUI(filename string, version string) {
 				}
 
 				// reset AppData and GUI
-				appData.nodeList = make([]ListEntry, 0)
+				appData.nodeList = make([]TreeEntry, 0)
+				appData.nodeIndex = nil
 				appData.currentListIndex = 0
 				appData.chunkInfo.SetText("")
-				appData.listView.UnselectAll()
+				appData.treeView.UnselectAll()
+				appData.treeView.Refresh()
 
 				appData.topContainer.Refresh()
 
@@ -72,20 +88,19 @@ func OpenGUI(filename string, version string) {
 					return
 				}
 
-				appData.chunks, err = chunks.ReadIFFFile(bytes.NewReader(data),
-					int64(len(data)))
-				if err != nil {
-					dialog.ShowError(err, appData.win)
-					return
-				}
-				chunks.PrintIffChunk(appData.chunks, 0)
-
-				appData.nodeList = ConvertIFFChunkToListNode(appData.chunks)
-				appData.listView.UnselectAll()
-				appData.topContainer.Refresh()
+				loadData(&appData, data)
 			}, appData.win)
 			fileDlg.Show()
 		}),
+		widget.NewToolbarAction(theme.DocumentSaveIcon(), func() {
+			savePNG(&appData)
+		}),
+		widget.NewToolbarAction(theme.MediaMusicIcon(), func() {
+			saveWAV(&appData)
+		}),
+		widget.NewToolbarAction(theme.ConfirmIcon(), func() {
+			validate(&appData)
+		}),
 		widget.NewToolbarAction(theme.InfoIcon(), func() {
 			dialog.ShowInformation("About",
 				"IFF Master\n"+
@@ -97,20 +112,29 @@ func OpenGUI(filename string, version string) {
 		}),
 	)
 
-	appData.listView = NewListView(&appData)
+	treeView := NewTreeView(&appData)
 	appData.hexTableView = NewHexTableView(&appData)
 	appData.isoTableView = NewIsoTableView(&appData)
 	appData.structTableView = NewStructTableView(&appData)
+	imageView := NewImageView(&appData)
+	waveformView := NewWaveformView(&appData)
+	animView := NewAnimView(&appData)
+	structureView := NewStructureView(&appData)
+	textView := NewTextView(&appData)
 
 	tabs := container.NewAppTabs(
 		container.NewTabItem("Hex", appData.hexTableView),
 		container.NewTabItem("ISO8859-1", appData.isoTableView),
-		container.NewTabItem("Structure", appData.structTableView))
+		container.NewTabItem("Structure", structureView),
+		container.NewTabItem("Image", imageView),
+		container.NewTabItem("Waveform", waveformView),
+		container.NewTabItem("Animation", animView),
+		container.NewTabItem("Text", textView))
 
 	appData.chunkInfo = widget.NewLabel("")
 
 	cont1 := container.NewBorder(appData.chunkInfo, nil, nil, nil, tabs)
-	appData.topContainer = container.NewBorder(toolBar, nil, appData.listView, nil, cont1)
+	appData.topContainer = container.NewBorder(toolBar, nil, treeView, nil, cont1)
 	appData.win.SetContent(appData.topContainer)
 
 	appData.win.Resize(fyne.NewSize(800, 600))
@@ -131,13 +155,76 @@ func readFileName(appData *AppData, filename string) {
 			return
 		}
 
-		appData.chunks, err = chunks.ReadIFFFile(bytes.NewReader(data), int64(len(data)))
-		if err != nil {
-			dialog.ShowError(err, appData.win)
-			return
-		}
+		loadData(appData, data)
+	}
+}
+
+// logChunkTree logs the ID, size, SubID and total size of all chunks.
+func logChunkTree(root *iff.Chunk) {
+	_ = iff.Walk(root, func(chunk *iff.Chunk, path string, level int) error {
+		log.Printf("%s%s %d %s %d", strings.Repeat("  ", level), chunk.ID, chunk.Size,
+			chunk.SubID, chunk.SumSize)
+		return nil
+	})
+}
 
-		appData.nodeList = ConvertIFFChunkToListNode(appData.chunks)
-		appData.topContainer.Refresh()
+// loadData parses the content of an IFF file and displays it.
+// Broken files are parsed leniently, the problems found are shown
+// in a dialog.
+func loadData(appData *AppData, data []byte) {
+	var diags []iff.Diagnostic
+	var err error
+
+	appData.chunks, diags, err = iff.ParseLenient(bytes.NewReader(data),
+		int64(len(data)))
+	if err != nil {
+		dialog.ShowError(err, appData.win)
+		return
+	}
+	if appData.chunks == nil {
+		return
+	}
+	logChunkTree(appData.chunks)
+
+	appData.nodeList, appData.nodeIndex = ConvertIFFChunkToTreeNodes(appData.chunks)
+	resetFieldSelection(appData)
+	showChunkTree(appData)
+	updateImageView(appData)
+	updateWaveformView(appData)
+	updateAnimView(appData)
+	updateCatalogView(appData)
+	updateTextView(appData)
+	appData.topContainer.Refresh()
+
+	if len(diags) > 0 {
+		showDiagnostics(appData, "Problems found while reading", diags)
+	}
+}
+
+// validate checks the loaded file for conformance with EA IFF 85
+// and shows the result.
+func validate(appData *AppData) {
+	if appData.chunks == nil {
+		return
+	}
+
+	diags := iff.Validate(appData.chunks)
+	if len(diags) == 0 {
+		dialog.ShowInformation("Validation", "No problems found.", appData.win)
+		return
+	}
+	showDiagnostics(appData, "Validation", diags)
+}
+
+// currentForm returns the FORM which contains the selected chunk if it has
+// one of the given types. Otherwise the first FORM of these types in the
+// file is returned, or nil if there is none.
+func currentForm(appData *AppData, types ...string) *iff.Chunk {
+	if appData.currentListIndex < len(appData.nodeList) {
+		form := appData.nodeList[appData.currentListIndex].form
+		if form != nil && slices.Contains(types, form.SubID) {
+			return form
+		}
 	}
+	return iff.FindForm(appData.chunks, types...)
 }
@@ -0,0 +1,106 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package gui
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+	"github.com/mattrust/iffmaster/iff"
+)
+
+// NewImageView creates the view which shows the picture of the FORM
+// the selected chunk belongs to.
+func NewImageView(appData *AppData) fyne.CanvasObject {
+	appData.imageCanvas = canvas.NewImageFromImage(nil)
+	appData.imageCanvas.FillMode = canvas.ImageFillContain
+	appData.imageCanvas.ScaleMode = canvas.ImageScalePixels
+
+	appData.imageLabel = widget.NewLabel("")
+	appData.imageLabel.Alignment = fyne.TextAlignCenter
+
+	return container.NewBorder(nil, appData.imageLabel, nil, nil, appData.imageCanvas)
+}
+
+// updateImageView decodes the picture of the FORM which contains the
+// selected chunk and shows it in the image view.
+func updateImageView(appData *AppData) {
+	appData.imageCanvas.Image = nil
+	appData.imageLabel.SetText("")
+
+	if appData.currentListIndex >= len(appData.nodeList) {
+		appData.imageCanvas.Refresh()
+		return
+	}
+
+	form := appData.nodeList[appData.currentListIndex].form
+	if !iff.IsPictureForm(form) {
+		appData.imageLabel.SetText("(no picture)")
+		appData.imageCanvas.Refresh()
+		return
+	}
+
+	pic, err := iff.DecodeILBM(form)
+	if pic != nil {
+		img := pic.Image()
+		appData.imageCanvas.Image = img
+		appData.imageLabel.SetText(fmt.Sprintf("%d x %d pixels, %d planes, %s",
+			img.Bounds().Dx(), img.Bounds().Dy(), len(pic.Bitmap.Planes), pictureMode(pic)))
+	}
+	if err != nil {
+		appData.imageLabel.SetText(fmt.Sprintf("(error: %s)", err))
+	}
+	appData.imageCanvas.Refresh()
+}
+
+// pictureMode describes how the colors of the picture are decoded.
+func pictureMode(pic *iff.Picture) string {
+	mode := pic.ViewMode.String()
+	switch {
+	case pic.IsHAM():
+		mode += ", Hold-And-Modify"
+	case pic.IsEHB():
+		mode += ", Extra-Halfbrite"
+	}
+	if pic.LinePalettes != nil {
+		mode += ", line palettes"
+	}
+	return mode
+}
+
+// savePNG asks for a filename and saves the picture of the FORM which
+// contains the selected chunk as PNG. If the selected chunk doesn't
+// belong to a picture, the first picture of the file is used.
+func savePNG(appData *AppData) {
+	form := currentForm(appData, "ILBM", "ACBM")
+	if form == nil {
+		dialog.ShowInformation("Save as PNG", "The file contains no picture.", appData.win)
+		return
+	}
+
+	pic, err := iff.DecodeILBM(form)
+	if pic == nil {
+		dialog.ShowError(err, appData.win)
+		return
+	}
+
+	fileDlg := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil || writer == nil {
+			return
+		}
+		defer writer.Close()
+
+		if err := iff.EncodePNG(writer, pic); err != nil {
+			dialog.ShowError(err, appData.win)
+		}
+	}, appData.win)
+	fileDlg.SetFileName("picture.png")
+	fileDlg.SetFilter(storage.NewExtensionFileFilter([]string{".png"}))
+	fileDlg.Show()
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package gui
+
+import (
+	"fmt"
+	"log"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+	"github.com/mattrust/iffmaster/iff"
+)
+
+// TreeEntry is a struct to hold the data of a node of the tree view.
+// It embeds the chunk to hold the chunk data.
+type TreeEntry struct {
+	label       string
+	description string
+	structure   iff.Fields
+	form        *iff.Chunk // the chunk itself or its enclosing FORM
+	path        string     // the chunk path, used as node ID
+	children    []string   // the paths of the child chunks
+	*iff.Chunk             // Embedding the chunk
+}
+
+// NewTreeView creates a new fyne tree view with buttons to expand and
+// collapse all nodes. The nodes are identified by their chunk path,
+// the root node of the tree is the empty string.
+func NewTreeView(appData *AppData) fyne.CanvasObject {
+	tree := widget.NewTree(
+
+		// The child nodes of a node
+		func(uid widget.TreeNodeID) []widget.TreeNodeID {
+			if uid == "" {
+				if len(appData.nodeList) == 0 {
+					return nil
+				}
+				return []widget.TreeNodeID{appData.nodeList[0].path}
+			}
+			if i, ok := appData.nodeIndex[uid]; ok {
+				return appData.nodeList[i].children
+			}
+			return nil
+		},
+
+		// Whether a node can have child nodes
+		func(uid widget.TreeNodeID) bool {
+			if uid == "" {
+				return true
+			}
+			i, ok := appData.nodeIndex[uid]
+			return ok && len(appData.nodeList[i].children) > 0
+		},
+
+		// The function to create the widget for each node
+		func(branch bool) fyne.CanvasObject {
+			return widget.NewLabel("WWWW WWWW (00000000)")
+		},
+
+		// The function to populate the widget with the data for each node
+		func(uid widget.TreeNodeID, branch bool, obj fyne.CanvasObject) {
+			if i, ok := appData.nodeIndex[uid]; ok {
+				obj.(*widget.Label).SetText(appData.nodeList[i].label)
+			}
+		},
+	)
+
+	tree.OnSelected = func(uid widget.TreeNodeID) {
+		i, ok := appData.nodeIndex[uid]
+		if !ok {
+			return
+		}
+		appData.chunkInfo.SetText(appData.nodeList[i].description)
+		appData.currentListIndex = i
+		resetFieldSelection(appData)
+		updateImageView(appData)
+		updateWaveformView(appData)
+		updateAnimView(appData)
+		updateCatalogView(appData)
+		updateTextView(appData)
+		appData.topContainer.Refresh()
+	}
+	appData.treeView = tree
+
+	expand := widget.NewButton("Expand All", tree.OpenAllBranches)
+	collapse := widget.NewButton("Collapse All", tree.CloseAllBranches)
+
+	return container.NewBorder(container.NewGridWithColumns(2, expand, collapse),
+		nil, nil, nil, tree)
+}
+
+// showChunkTree fills the tree view with the nodes of appData.nodeList.
+// Only the root chunk is expanded, large files like ANIMs with hundreds
+// of frames stay clear.
+func showChunkTree(appData *AppData) {
+	appData.treeView.UnselectAll()
+	appData.treeView.CloseAllBranches()
+	if len(appData.nodeList) > 0 {
+		appData.treeView.OpenBranch(appData.nodeList[0].path)
+	}
+	appData.treeView.ScrollToTop()
+	appData.treeView.Refresh()
+}
+
+// ConvertIFFChunkToTreeNodes traverses to a IFF chunk nodes and appends
+// data which is needed for the GUI. It returns the nodes in file order,
+// the root chunk first, and a map from chunk paths to node indices.
+func ConvertIFFChunkToTreeNodes(chunk *iff.Chunk) ([]TreeEntry, map[string]int) {
+	var nodeList []TreeEntry
+	nodeIndex := make(map[string]int)
+
+	var traverse func(chunk *iff.Chunk, form *iff.Chunk, path string)
+	traverse = func(chunk *iff.Chunk, form *iff.Chunk, path string) {
+		if chunk.ID == "FORM" {
+			form = chunk
+		}
+		description, structData, err := iff.Decode(chunk.Type(), chunk.Data)
+		if err != nil {
+			log.Printf("Error getting struct data for %s: %s", chunk.Type(), err)
+		}
+		label := chunk.ID
+		if chunk.SubID != "" {
+			label += " " + chunk.SubID
+		}
+		label += fmt.Sprintf(" (%d)", chunk.Size)
+		if chunk.Truncated {
+			label += " (truncated)"
+		}
+
+		index := len(nodeList)
+		nodeIndex[path] = index
+		nodeList = append(nodeList, TreeEntry{
+			label: label,
+			description: fmt.Sprintf(
+				"Type: %s - Desc.: %s - Size: %d - Offset: 0x%08X (Data: 0x%08X, End: 0x%08X)",
+				chunk.Type(), description, chunk.Size,
+				chunk.Offset, chunk.DataOffset, chunk.EndOffset),
+			Chunk:     chunk,
+			form:      form,
+			path:      path,
+			structure: structData.Rows()})
+		for i, child := range chunk.Children {
+			childPath := iff.Path(path, child, i)
+			nodeList[index].children = append(nodeList[index].children, childPath)
+			traverse(child, form, childPath)
+		}
+	}
+
+	traverse(chunk, nil, iff.Path("", chunk, -1))
+	return nodeList, nodeIndex
+}
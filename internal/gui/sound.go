@@ -0,0 +1,47 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package gui
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"github.com/mattrust/iffmaster/iff"
+)
+
+// saveWAV asks for a filename and saves the highest octave of the 8SVX
+// FORM which contains the selected chunk as WAV. If the selected chunk
+// doesn't belong to a sound, the first sound of the file is used.
+func saveWAV(appData *AppData) {
+	form := currentForm(appData, "8SVX")
+	if form == nil {
+		dialog.ShowInformation("Save as WAV", "The file contains no 8SVX sound.", appData.win)
+		return
+	}
+
+	voice, err := iff.Decode8SVX(form)
+	if voice == nil {
+		dialog.ShowError(err, appData.win)
+		return
+	}
+	wav, err := voice.WAV(0)
+	if err != nil {
+		dialog.ShowError(err, appData.win)
+		return
+	}
+
+	fileDlg := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil || writer == nil {
+			return
+		}
+		defer writer.Close()
+
+		if err := iff.WriteWAV(writer, wav); err != nil {
+			dialog.ShowError(err, appData.win)
+		}
+	}, appData.win)
+	fileDlg.SetFileName("sound.wav")
+	fileDlg.SetFilter(storage.NewExtensionFileFilter([]string{".wav"}))
+	fileDlg.Show()
+}
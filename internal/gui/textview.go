@@ -0,0 +1,124 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package gui
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/mattrust/iffmaster/iff"
+)
+
+// penColorPrefix is the prefix of the theme color names of the pens,
+// e.g. "ftxtPen3".
+const penColorPrefix = "ftxtPen"
+
+// penTheme adds the colors of the FTXT pens to the default theme.
+type penTheme struct {
+	fyne.Theme
+}
+
+// Color returns the color of a pen or of the default theme.
+func (t penTheme) Color(name fyne.ThemeColorName, variant fyne.ThemeVariant) color.Color {
+	var pen int
+	if _, err := fmt.Sscanf(string(name), penColorPrefix+"%d", &pen); err == nil &&
+		pen >= 0 && pen < len(iff.TextPenColors) {
+		return iff.TextPenColors[pen]
+	}
+	return t.Theme.Color(name, variant)
+}
+
+// TextView shows the styled text of an FTXT FORM.
+type TextView struct {
+	richText *widget.RichText
+	label    *widget.Label
+}
+
+// NewTextView creates the view which shows formatted text.
+func NewTextView(appData *AppData) fyne.CanvasObject {
+	view := &TextView{}
+	appData.textView = view
+
+	view.richText = widget.NewRichText()
+	view.richText.Wrapping = fyne.TextWrapWord
+	view.label = widget.NewLabel("")
+
+	themed := container.NewThemeOverride(view.richText, penTheme{theme.DefaultTheme()})
+	return container.NewBorder(nil, view.label, nil, nil, container.NewVScroll(themed))
+}
+
+// updateTextView decodes the formatted text of the FORM which contains
+// the selected chunk and shows it in the text view. Bold, italic,
+// underline and the text pen are shown, background pens only in the
+// HTML export.
+func updateTextView(appData *AppData) {
+	view := appData.textView
+	view.richText.Segments = nil
+	view.label.SetText("")
+
+	form := currentForm(appData, "FTXT")
+	if form == nil {
+		view.label.SetText("(no formatted text)")
+		view.richText.Refresh()
+		return
+	}
+
+	ftxt, err := iff.DecodeFTXT(form)
+	if ftxt != nil {
+		view.richText.Segments = textSegments(ftxt)
+		var fonts []string
+		for _, font := range ftxt.Fonts {
+			fonts = append(fonts, fmt.Sprintf("%d: %s", font.ID, font.Name))
+		}
+		if len(fonts) > 0 {
+			view.label.SetText("Fonts " + strings.Join(fonts, ", "))
+		}
+	}
+	if err != nil {
+		view.label.SetText(fmt.Sprintf("(error: %s)", err))
+	}
+	view.richText.Refresh()
+}
+
+// textSegments converts the spans of the text into segments of a rich
+// text widget. Lines become separate segments because a text segment
+// can't contain line breaks.
+func textSegments(ftxt *iff.FormattedText) []widget.RichTextSegment {
+	var segments []widget.RichTextSegment
+
+	for _, span := range ftxt.Spans {
+		style := widget.RichTextStyleInline
+		style.TextStyle = fyne.TextStyle{
+			Bold:      span.Style.Bold,
+			Italic:    span.Style.Italic,
+			Underline: span.Style.Underline,
+		}
+		if font := ftxt.Font(span.Style.Font); font != nil && font.Proportional == iff.FontNo {
+			style.TextStyle.Monospace = true
+		}
+		pen := span.Style.Foreground
+		if span.Style.Inverse {
+			pen = max(span.Style.Background, 0)
+		}
+		if pen >= 0 {
+			style.ColorName = fyne.ThemeColorName(fmt.Sprintf("%s%d", penColorPrefix, pen))
+		}
+
+		lines := strings.Split(span.Text, "\n")
+		for i, line := range lines {
+			segStyle := style
+			if i < len(lines)-1 {
+				segStyle.Inline = false // line break after the segment
+			}
+			segments = append(segments, &widget.TextSegment{Text: line, Style: segStyle})
+		}
+	}
+
+	return segments
+}
@@ -0,0 +1,204 @@
+// Copyright (c) 2025 Matthias Rustler
+// Licensed under the MIT License - see LICENSE for details
+
+package gui
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/mattrust/iffmaster/iff"
+)
+
+// maxWaveformZoom is the largest zoom factor of the waveform view.
+const maxWaveformZoom = 64
+
+// Colors of the waveform view.
+var (
+	waveBackground = color.NRGBA{0x20, 0x20, 0x20, 0xFF}
+	waveAxis       = color.NRGBA{0x60, 0x60, 0x60, 0xFF}
+	waveSamples    = color.NRGBA{0x40, 0xA0, 0xFF, 0xFF}
+	waveClipping   = color.NRGBA{0xFF, 0x30, 0x30, 0xFF}
+	waveMarker     = color.NRGBA{0xFF, 0xA0, 0x00, 0xFF}
+	waveEnvelope   = color.NRGBA{0x40, 0xFF, 0x40, 0xFF}
+)
+
+// WaveformView shows the samples of a sampled-sound FORM.
+type WaveformView struct {
+	raster *canvas.Raster
+	scroll *container.Scroll
+	label  *widget.Label
+
+	sound *iff.Sound
+	zoom  float32
+}
+
+// NewWaveformView creates the view which shows the samples of the FORM
+// the selected chunk belongs to.
+func NewWaveformView(appData *AppData) fyne.CanvasObject {
+	view := &WaveformView{zoom: 1}
+	appData.waveformView = view
+
+	view.raster = canvas.NewRaster(view.draw)
+	view.scroll = container.NewHScroll(view.raster)
+	view.label = widget.NewLabel("")
+	view.label.Wrapping = fyne.TextWrapWord
+
+	toolBar := widget.NewToolbar(
+		widget.NewToolbarAction(theme.ZoomInIcon(), func() {
+			view.setZoom(view.zoom * 2)
+		}),
+		widget.NewToolbarAction(theme.ZoomOutIcon(), func() {
+			view.setZoom(view.zoom / 2)
+		}),
+		widget.NewToolbarAction(theme.ZoomFitIcon(), func() {
+			view.setZoom(1)
+		}),
+	)
+
+	return container.NewBorder(toolBar, view.label, nil, nil, view.scroll)
+}
+
+// setZoom changes the zoom factor. At 1 the whole sound fits into the view.
+func (view *WaveformView) setZoom(zoom float32) {
+	view.zoom = min(max(zoom, 1), maxWaveformZoom)
+
+	width := float32(0)
+	if view.zoom > 1 {
+		width = view.scroll.Size().Width * view.zoom
+	}
+	view.raster.SetMinSize(fyne.NewSize(width, 0))
+	view.scroll.Refresh()
+	view.raster.Refresh()
+}
+
+// updateWaveformView decodes the sound of the FORM which contains the
+// selected chunk and shows it in the waveform view.
+func updateWaveformView(appData *AppData) {
+	view := appData.waveformView
+	view.sound = nil
+	view.label.SetText("")
+
+	var form *iff.Chunk
+	if appData.currentListIndex < len(appData.nodeList) {
+		form = appData.nodeList[appData.currentListIndex].form
+	}
+
+	if !iff.IsSoundForm(form) {
+		view.label.SetText("(no sound)")
+	} else {
+		snd, err := iff.DecodeSound(form)
+		view.sound = snd
+		if snd != nil {
+			view.label.SetText(describeSound(snd))
+		}
+		if err != nil {
+			view.label.SetText(fmt.Sprintf("(error: %s)", err))
+		}
+	}
+
+	view.setZoom(1)
+}
+
+// describeSound returns the format and the markers of the sound.
+func describeSound(snd *iff.Sound) string {
+	frames := 0
+	if len(snd.Channels) > 0 {
+		frames = len(snd.Channels[0])
+	}
+	text := fmt.Sprintf("%d channels, %d sample frames, %.0f Hz",
+		len(snd.Channels), frames, snd.SampleRate)
+
+	var markers []string
+	for _, m := range snd.Markers {
+		markers = append(markers, fmt.Sprintf("%s at %d", m.Name, m.Position))
+	}
+	if len(markers) > 0 {
+		text += "; markers: " + strings.Join(markers, ", ")
+	}
+	if snd.Attack != nil || snd.Release != nil {
+		text += "; envelope shown in green"
+	}
+	return text
+}
+
+// draw renders the waveform with markers and envelope into an image of
+// the given size.
+func (view *WaveformView) draw(w int, h int) image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for i := 0; i < len(img.Pix); i += 4 {
+		copy(img.Pix[i:], []uint8{waveBackground.R, waveBackground.G, waveBackground.B, 0xFF})
+	}
+
+	snd := view.sound
+	if snd == nil || len(snd.Channels) == 0 || len(snd.Channels[0]) == 0 || w == 0 {
+		return img
+	}
+	frames := len(snd.Channels[0])
+	bandHeight := h / len(snd.Channels)
+
+	for c, samples := range snd.Channels {
+		top := c * bandHeight
+		center := top + bandHeight/2
+		scale := float64(bandHeight/2 - 1)
+		toY := func(value float64) int {
+			return min(max(center-int(math.Round(value*scale)), top), top+bandHeight-1)
+		}
+
+		for x := 0; x < w; x++ {
+			img.SetNRGBA(x, center, waveAxis)
+
+			// all samples which belong to the column
+			first := x * frames / w
+			last := max((x+1)*frames/w, first+1)
+			lo, hi := samples[first], samples[first]
+			for _, s := range samples[first:min(last, len(samples))] {
+				lo, hi = min(lo, s), max(hi, s)
+			}
+
+			col := waveSamples
+			if hi >= 0.999 || lo <= -0.999 {
+				col = waveClipping
+			}
+			for y := toY(hi); y <= toY(lo); y++ {
+				img.SetNRGBA(x, y, col)
+			}
+		}
+
+		// the envelope is drawn symmetrically around the center
+		for _, env := range [][]iff.EnvelopePoint{snd.Attack, snd.Release} {
+			for i := 1; i < len(env); i++ {
+				x0, x1 := env[i-1].Position*w/frames, env[i].Position*w/frames
+				for x := max(x0, 0); x <= min(x1, w-1); x++ {
+					level := env[i].Level
+					if x1 > x0 {
+						level = env[i-1].Level + (env[i].Level-env[i-1].Level)*
+							float64(x-x0)/float64(x1-x0)
+					}
+					img.SetNRGBA(x, toY(level), waveEnvelope)
+					img.SetNRGBA(x, toY(-level), waveEnvelope)
+				}
+			}
+		}
+	}
+
+	for _, m := range snd.Markers {
+		x := m.Position * w / frames
+		if x < 0 || x >= w {
+			continue
+		}
+		for y := 0; y < h; y++ {
+			img.SetNRGBA(x, y, waveMarker)
+		}
+	}
+
+	return img
+}